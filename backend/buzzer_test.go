@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestBuzzerWebsocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataBuzzer{Data: qg.BuzzerGameData{}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create new game:", err)
+	}
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	alice := startTestWebsocket(ctx, t, srv, "alice")
+	bob := startTestWebsocket(ctx, t, srv, "bob")
+	all := []*west.WebsocketTest{admin, alice, bob}
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	for _, player := range []struct {
+		name string
+		ws   *west.WebsocketTest
+	}{
+		{"Alice", alice},
+		{"Bob", bob},
+	} {
+		sendCommand(ctx, t, player.ws, qg.CommandJoinGame{
+			GameID:     r.GameID,
+			PlayerName: player.name,
+		})
+		joined := expectEvent[qg.EventJoinedGame](ctx, t, player.ws)
+		assert.Equal(t, qg.GameInfo{Value: qg.GameInfoBuzzer{Data: qg.BuzzerGameInfo{}}}, joined.GameInfo)
+	}
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+	for _, ws := range all {
+		expectEvent[qg.EventGameStarted](ctx, t, ws)
+	}
+
+	t.Run("round", func(t *testing.T) {
+		// Alice presses before the round is open.
+		sendCommand(ctx, t, alice, qg.CommandBuzzerPress{})
+		for _, ws := range all {
+			lockout := expectEvent[qg.EventBuzzerLockedOut](ctx, t, ws)
+			assert.Equal(t, qg.EventBuzzerLockedOut{PlayerName: "Alice", Penalty: 250}, lockout)
+		}
+
+		err := expectCommandError(ctx, t, alice, qg.CommandBuzzerOpenRound{})
+		assert.Contains(t, err.Message, "only admins")
+
+		sendCommand(ctx, t, admin, qg.CommandBuzzerOpenRound{})
+		for _, ws := range all {
+			opened := expectEvent[qg.EventBuzzerRoundOpened](ctx, t, ws)
+			assert.Equal(t, 0, opened.Round)
+		}
+
+		err = expectCommandError(ctx, t, alice, qg.CommandBuzzerPress{})
+		assert.Contains(t, err.Message, "locked out")
+
+		sendCommand(ctx, t, bob, qg.CommandBuzzerPress{})
+		for _, ws := range all {
+			buzzes := expectEvent[qg.EventBuzzerBuzzes](ctx, t, ws)
+			assert.Equal(t, []qg.BuzzerBuzz{{PlayerName: "Bob"}}, buzzes.Buzzes)
+		}
+
+		// Wait out the lockout from pressing early.
+		time.Sleep(games.DefaultEarlyBuzzPenalty)
+
+		sendCommand(ctx, t, alice, qg.CommandBuzzerPress{})
+		for _, ws := range all {
+			buzzes := expectEvent[qg.EventBuzzerBuzzes](ctx, t, ws)
+			assert.Equal(t, []qg.BuzzerBuzz{
+				{PlayerName: "Bob"},
+				{PlayerName: "Alice"},
+			}, buzzes.Buzzes)
+		}
+
+		err = expectCommandError(ctx, t, bob, qg.CommandBuzzerPress{})
+		assert.Contains(t, err.Message, "already pressed")
+
+		sendCommand(ctx, t, admin, qg.CommandBuzzerCloseRound{})
+		for _, ws := range all {
+			closed := expectEvent[qg.EventBuzzerRoundClosed](ctx, t, ws)
+			assert.Equal(t, qg.EventBuzzerRoundClosed{
+				Round: 0,
+				Buzzes: []qg.BuzzerBuzz{
+					{PlayerName: "Bob"},
+					{PlayerName: "Alice"},
+				},
+			}, closed)
+		}
+	})
+
+	t.Run("award_points", func(t *testing.T) {
+		err := expectCommandError(ctx, t, bob, qg.CommandBuzzerAwardPoints{PlayerName: "Bob", Points: 100})
+		assert.Contains(t, err.Message, "only admins")
+
+		err = expectCommandError(ctx, t, admin, qg.CommandBuzzerAwardPoints{PlayerName: "Admin", Points: 100})
+		assert.Contains(t, err.Message, "admins cannot get points")
+
+		err = expectCommandError(ctx, t, admin, qg.CommandBuzzerAwardPoints{PlayerName: "Carol", Points: 100})
+		assert.Contains(t, err.Message, "unknown player")
+
+		sendCommand(ctx, t, admin, qg.CommandBuzzerAwardPoints{PlayerName: "Bob", Points: 100})
+		for _, ws := range all {
+			awarded := expectEvent[qg.EventBuzzerPointsAwarded](ctx, t, ws)
+			assert.Equal(t, qg.EventBuzzerPointsAwarded{
+				PlayerName: "Bob",
+				Points:     100,
+				Leaderboard: qg.Leaderboard{
+					{PlayerName: "Bob", Score: 100},
+					{PlayerName: "Alice", Score: 0},
+				},
+			}, awarded)
+		}
+
+		// Points can be awarded while a round is open, and they can also be
+		// taken away.
+		sendCommand(ctx, t, admin, qg.CommandBuzzerOpenRound{})
+		for _, ws := range all {
+			opened := expectEvent[qg.EventBuzzerRoundOpened](ctx, t, ws)
+			assert.Equal(t, 1, opened.Round)
+		}
+
+		sendCommand(ctx, t, admin, qg.CommandBuzzerAwardPoints{PlayerName: "Alice", Points: -50})
+		for _, ws := range all {
+			awarded := expectEvent[qg.EventBuzzerPointsAwarded](ctx, t, ws)
+			assert.Equal(t, qg.Leaderboard{
+				{PlayerName: "Bob", Score: 100},
+				{PlayerName: "Alice", Score: -50},
+			}, awarded.Leaderboard)
+		}
+	})
+
+	t.Run("game_ended", func(t *testing.T) {
+		err := expectCommandError(ctx, t, alice, qg.CommandEndGame{})
+		assert.Contains(t, err.Message, "only admins")
+
+		sendCommand(ctx, t, admin, qg.CommandEndGame{})
+		for _, ws := range all {
+			ended := expectEvent[qg.EventGameEnded](ctx, t, ws)
+			assert.Equal(t, qg.Leaderboard{
+				{PlayerName: "Bob", Score: 100},
+				{PlayerName: "Alice", Score: -50},
+			}, ended.Leaderboard)
+		}
+	})
+}
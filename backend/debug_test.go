@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestDebugGame(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game", qg.RequestNewGame{
+		AdminPassword: "admin",
+		Data:          qg.GameData{Value: qg.GameDataJeopardy{Data: jeopardyGameData}},
+	})
+	must(t, err)
+
+	debug := func(t *testing.T, password string) (*qg.ResponseDebugGame, error) {
+		return hc.POST[qg.ResponseDebugGame](ctx, client, "/game/debug", qg.RequestDebugGame{
+			GameID:        r.GameID,
+			AdminPassword: password,
+		})
+	}
+
+	_, err = debug(t, "wrong")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+
+	game, err := debug(t, "admin")
+	must(t, err)
+	assert.Equal(t, "init", game.Current)
+	assert.False(t, game.Ended)
+	assert.Equal(t, []string{"qg.CommandBeginGame", "games.autoBeginGame"}, game.Next)
+	assert.Equal(t, []string{"qg.CommandJoinGame", "qg.CommandPauseGame", "qg.CommandResumeGame", "qg.CommandRequestSnapshot"}, game.Superstate)
+
+	var endReactors int
+	for _, reactor := range game.Reactors {
+		if reactor == (qg.DebugReactor{Hook: "react", From: "any", To: "end"}) {
+			endReactors++
+		}
+	}
+	assert.NotZero(t, endReactors)
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	// Joining happens in the superstate, so the game is still in its lobby.
+	game, err = debug(t, "admin")
+	must(t, err)
+	assert.Equal(t, "init", game.Current)
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+	expectEvent[qg.EventGameStarted](ctx, t, admin)
+
+	game, err = debug(t, "admin")
+	must(t, err)
+	assert.Equal(t, "qg.CommandBeginGame", game.Current)
+	assert.Equal(t, []string{"qg.CommandJeopardyChooseQuestion"}, game.Next)
+	assert.Contains(t, game.Dot, `"qg.CommandBeginGame" [style=filled];`)
+	assert.Contains(t, game.Dot, `"qg.CommandBeginGame" -> "qg.CommandJeopardyChooseQuestion" [style=bold];`)
+	assert.Contains(t, game.Mermaid, "[*] --> s0")
+}
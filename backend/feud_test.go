@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+var feudGameData = qg.FeudGameData{
+	Questions: []qg.FeudQuestion{
+		{
+			Question: "Name a fruit",
+			Answers: []qg.FeudAnswer{
+				{Answer: "Apple", Points: 40},
+				{Answer: "Banana", Points: 30},
+				{Answer: "Cherry", Points: 20},
+			},
+		},
+		{
+			Question: "Name a color",
+			Answers: []qg.FeudAnswer{
+				{Answer: "Red", Points: 50},
+			},
+		},
+	},
+	TeamNames: []string{"Cats", "Dogs"},
+}
+
+func TestFeudWebsocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataFeud{Data: feudGameData},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create new game:", err)
+	}
+
+	assert.Equal(t, r.GameType, qg.GameTypeFeud)
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	alice := startTestWebsocket(ctx, t, srv, "alice")
+	bob := startTestWebsocket(ctx, t, srv, "bob")
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	sendCommand(ctx, t, alice, qg.CommandJoinGame{
+		GameID:     r.GameID,
+		PlayerName: "Alice",
+	})
+	joined := expectEvent[qg.EventJoinedGame](ctx, t, alice)
+	assert.Equal(t, qg.GameInfo{Value: qg.GameInfoFeud{Data: qg.FeudGameInfo{
+		NumQuestions: 2,
+		TeamNames:    []string{"Cats", "Dogs"},
+	}}}, joined.GameInfo)
+
+	sendCommand(ctx, t, bob, qg.CommandJoinGame{
+		GameID:     r.GameID,
+		PlayerName: "Bob",
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, bob)
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+
+	round := expectEvent[qg.EventFeudBeginRound](ctx, t, alice)
+	assert.Equal(t, qg.EventFeudBeginRound{
+		Round:      0,
+		Question:   "Name a fruit",
+		NumAnswers: 3,
+		FaceOff:    []qg.PlayerName{"Alice", "Bob"},
+		Teams: []qg.FeudTeam{
+			{Name: "Cats", Players: []qg.PlayerName{"Alice"}},
+			{Name: "Dogs", Players: []qg.PlayerName{"Bob"}},
+		},
+	}, round)
+
+	// Everyone sees the same round.
+	assert.Equal(t, round, expectEvent[qg.EventFeudBeginRound](ctx, t, bob))
+	assert.Equal(t, round, expectEvent[qg.EventFeudBeginRound](ctx, t, admin))
+
+	// Only admins get the answers before they are revealed.
+	assert.Equal(t, qg.EventFeudAnswerKey{
+		Round:   0,
+		Answers: feudGameData.Questions[0].Answers,
+	}, expectEvent[qg.EventFeudAnswerKey](ctx, t, admin))
+
+	t.Run("face_off", func(t *testing.T) {
+		err := expectCommandError(ctx, t, admin, qg.CommandFeudPressButton{})
+		assert.Contains(t, err.Message, "face-off players")
+		assert.Equal(t, p(qg.ErrorCodeNotYourTurn), err.Code)
+
+		// Carol joins too late to be on a team, so she can only watch.
+		carol := startTestWebsocket(ctx, t, srv, "carol")
+		sendCommand(ctx, t, carol, qg.CommandJoinGame{
+			GameID:     r.GameID,
+			PlayerName: "Carol",
+		})
+		expectEvent[qg.EventJoinedGame](ctx, t, carol)
+
+		err = expectCommandError(ctx, t, carol, qg.CommandFeudPressButton{})
+		assert.Contains(t, err.Message, "after the teams were picked")
+		assert.Equal(t, p(qg.ErrorCodeUnauthorized), err.Code)
+
+		sendCommand(ctx, t, bob, qg.CommandFeudPressButton{})
+		pressed := expectEvent[qg.EventFeudButtonPressed](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudButtonPressed{PlayerName: "Bob", Team: 1}, pressed)
+		turn := expectEvent[qg.EventFeudTurn](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudTurn{Phase: qg.FeudPhaseFaceOff, Team: 1, PlayerName: "Bob"}, turn)
+
+		// Bob isn't on top, so Alice gets to try to beat him.
+		sendCommand(ctx, t, admin, qg.CommandFeudRevealAnswer{Answer: 1})
+		revealed := expectEvent[qg.EventFeudAnswerRevealed](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudAnswerRevealed{
+			Answer: 1,
+			Text:   "Banana",
+			Points: 30,
+			Team:   1,
+			Bank:   30,
+		}, revealed)
+		turn = expectEvent[qg.EventFeudTurn](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudTurn{Phase: qg.FeudPhaseFaceOff, Team: 0, PlayerName: "Alice"}, turn)
+
+		// Alice misses, so the Dogs take control.
+		sendCommand(ctx, t, admin, qg.CommandFeudStrike{})
+		strike := expectEvent[qg.EventFeudStrike](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudStrike{Team: 0, Strikes: 0}, strike)
+		turn = expectEvent[qg.EventFeudTurn](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudTurn{Phase: qg.FeudPhasePlay, Team: 1, PlayerName: "Bob"}, turn)
+	})
+
+	t.Run("steal", func(t *testing.T) {
+		for i := 1; i <= qg.DefaultFeudMaxStrikes; i++ {
+			sendCommand(ctx, t, admin, qg.CommandFeudStrike{})
+			strike := expectEvent[qg.EventFeudStrike](ctx, t, admin)
+			assert.Equal(t, qg.EventFeudStrike{Team: 1, Strikes: int32(i)}, strike)
+		}
+
+		for i := 1; i < qg.DefaultFeudMaxStrikes; i++ {
+			turn := expectEvent[qg.EventFeudTurn](ctx, t, admin)
+			assert.Equal(t, qg.FeudPhasePlay, turn.Phase)
+		}
+
+		turn := expectEvent[qg.EventFeudTurn](ctx, t, admin)
+		assert.Equal(t, qg.EventFeudTurn{Phase: qg.FeudPhaseSteal, Team: 0, PlayerName: "Alice"}, turn)
+
+		sendCommand(ctx, t, admin, qg.CommandFeudRevealAnswer{Answer: 0})
+		revealed := expectEvent[qg.EventFeudAnswerRevealed](ctx, t, admin)
+		assert.Equal(t, float32(70), revealed.Bank)
+
+		ended := expectEvent[qg.EventFeudRoundEnded](ctx, t, bob)
+		assert.Equal(t, qg.EventFeudRoundEnded{
+			Winner:  0,
+			Points:  70,
+			Answers: feudGameData.Questions[0].Answers,
+			Teams: []qg.FeudTeam{
+				{Name: "Cats", Players: []qg.PlayerName{"Alice"}, Score: 70},
+				{Name: "Dogs", Players: []qg.PlayerName{"Bob"}, Score: 0},
+			},
+		}, ended)
+		assert.Equal(t, ended, expectEvent[qg.EventFeudRoundEnded](ctx, t, admin))
+	})
+
+	t.Run("top_answer", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandFeudNextRound{})
+		round := expectEvent[qg.EventFeudBeginRound](ctx, t, admin)
+		assert.Equal(t, int32(1), round.Round)
+
+		sendCommand(ctx, t, alice, qg.CommandFeudPressButton{})
+		expectEvent[qg.EventFeudButtonPressed](ctx, t, admin)
+
+		// The top answer wins the face-off, which also clears the board.
+		sendCommand(ctx, t, admin, qg.CommandFeudRevealAnswer{Answer: 0})
+		ended := expectEvent[qg.EventFeudRoundEnded](ctx, t, admin)
+		assert.Equal(t, int32(0), ended.Winner)
+		assert.Equal(t, float32(50), ended.Points)
+	})
+
+	t.Run("game_ended", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandFeudNextRound{})
+		ended := expectEvent[qg.EventGameEnded](ctx, t, alice)
+		assert.Equal(t, qg.Leaderboard{
+			{PlayerName: "Alice", Score: 120},
+			{PlayerName: "Bob", Score: 0},
+		}, ended.Leaderboard)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/http/httptest"
 	"sync"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	"oss.acmcsuf.com/qg/backend/internal/hc"
 	"oss.acmcsuf.com/qg/backend/internal/west"
 	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
 	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
 )
 
@@ -27,7 +29,12 @@ var jeopardyGameData = qg.JeopardyGameData{
 		{
 			Name: "Lorem Ipsum 1",
 			Questions: []qg.JeopardyQuestion{
-				{Question: "1"},
+				{
+					Question: "1",
+					Content: []qg.QuestionContent{
+						{Value: qg.QuestionContentCode{Code: "fmt.Println(1)", Language: p("go")}},
+					},
+				},
 				{Question: "2"},
 				{Question: "3"},
 			},
@@ -52,7 +59,7 @@ func TestJeopardyWebsocket(t *testing.T) {
 		t.Fatal("failed to open SQLite DB:", err)
 	}
 
-	handler := newHandler(store)
+	handler := newHandler(ctx, store, store)
 	t.Cleanup(func() { handler.Close() })
 
 	srv := httptest.NewServer(handler)
@@ -88,6 +95,71 @@ func TestJeopardyWebsocket(t *testing.T) {
 		assert.Equal(t, r.GameType, qg.GameTypeJeopardy)
 	})
 
+	t.Run("get_unknown_game", func(t *testing.T) {
+		_, err := hc.GET[qg.ResponseGetGame](ctx, client, "/game/nope", nil)
+
+		var httpErr *hc.Error
+		assert.True(t, errors.As(err, &httpErr), "unexpected error: %v", err)
+		assert.Equal(t, http.StatusNotFound, httpErr.Status)
+		assert.Equal(t, p(qg.ErrorCodeNotFound), httpErr.Err.Code)
+	})
+
+	t.Run("new_invalid_game", func(t *testing.T) {
+		_, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game", json.RawMessage(`{
+			"admin_password": "admin",
+			"data": {
+				"game": "jeopardy",
+				"data": {
+					"categories": [{ "name": "Lorem Ipsum 1" }],
+					"chooser_policy": "whoever"
+				}
+			}
+		}`))
+
+		var httpErr *hc.Error
+		assert.True(t, errors.As(err, &httpErr), "unexpected error: %v", err)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		assert.Equal(t, p(qg.ErrorCodeInvalidRequest), httpErr.Err.Code)
+		assert.Equal(t, []qg.ErrorDetail{
+			{
+				Message:    "must be an array",
+				Path:       p("/data/data/categories/0/questions"),
+				SchemaPath: p("/definitions/JeopardyCategory/properties/questions/elements"),
+			},
+			{
+				Message:    `must be one of "random", "last_correct", "round_robin", "lowest_score"`,
+				Path:       p("/data/data/chooser_policy"),
+				SchemaPath: p("/definitions/JeopardyChooserPolicy/enum"),
+			},
+		}, httpErr.Err.Details)
+	})
+
+	t.Run("new_unplayable_game", func(t *testing.T) {
+		data := jeopardyGameData
+		data.Categories = []qg.JeopardyCategory{
+			data.Categories[0],
+			{Name: "Empty 1", Questions: []qg.JeopardyQuestion{}},
+			{Name: "Empty 2", Questions: []qg.JeopardyQuestion{}},
+		}
+
+		_, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+			qg.RequestNewGame{
+				AdminPassword: "admin",
+				Data: qg.GameData{
+					Value: qg.GameDataJeopardy{Data: data},
+				},
+			},
+		)
+
+		var httpErr *hc.Error
+		assert.True(t, errors.As(err, &httpErr), "unexpected error: %v", err)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		assert.Equal(t, p(qg.ErrorCodeInvalidRequest), httpErr.Err.Code)
+		assert.Equal(t, 2, len(httpErr.Err.Details))
+		assert.Equal(t, p("/categories/1/questions"), httpErr.Err.Details[0].Path)
+		assert.Equal(t, p("/categories/2/questions"), httpErr.Err.Details[1].Path)
+	})
+
 	type gameSequencer struct {
 		who string
 		act func(t *testing.T, ctx context.Context, ws *west.WebsocketTest)
@@ -117,6 +189,10 @@ func TestJeopardyWebsocket(t *testing.T) {
 
 				player := expectEvent[qg.EventPlayerJoined](ctx, t, ws)
 				assert.Equal(t, player.PlayerName, "Player 1")
+
+				snapshot := expectEvent[qg.EventGameSnapshot](ctx, t, ws)
+				assert.False(t, snapshot.Started)
+				assert.Equal(t, []qg.PlayerName{"Player 1"}, snapshot.Players)
 			},
 		},
 		{
@@ -190,6 +266,7 @@ func TestJeopardyWebsocket(t *testing.T) {
 				assert.Equal(t, question.Category, 0)
 				assert.Equal(t, question.Question, "1")
 				assert.Equal(t, question.Points, 100)
+				assert.Equal(t, question.Content, jeopardyGameData.Categories[0].Questions[0].Content)
 			},
 		},
 		{
@@ -204,10 +281,39 @@ func TestJeopardyWebsocket(t *testing.T) {
 		{
 			who: "player 1",
 			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				// The admin is still reading the question.
+				sendCommand(ctx, t, ws, qg.CommandJeopardyPressButton{})
+
+				lockout := expectEvent[qg.EventJeopardyBuzzerLockedOut](ctx, t, ws)
+				assert.Equal(t, lockout.PlayerName, "Player 1")
+				assert.Equal(t, lockout.Penalty, 250)
+			},
+		},
+		{
+			who: "admin",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				lockout := expectEvent[qg.EventJeopardyBuzzerLockedOut](ctx, t, ws)
+				assert.Equal(t, lockout.PlayerName, "Player 1")
+
+				sendCommand(ctx, t, ws, qg.CommandJeopardyArmBuzzers{})
+				expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, ws)
+			},
+		},
+		{
+			who: "player 1",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, ws)
+
+				// Wait out the lockout from pressing early.
+				time.Sleep(games.DefaultEarlyBuzzPenalty)
+
 				sendCommand(ctx, t, ws, qg.CommandJeopardyPressButton{})
 
 				press := expectEvent[qg.EventJeopardyButtonPressed](ctx, t, ws)
 				assert.Equal(t, press.PlayerName, "Player 1")
+				assert.Equal(t, press.Buzzes, []qg.JeopardyBuzz{
+					{PlayerName: "Player 1"},
+				})
 			},
 		},
 		{
@@ -258,11 +364,15 @@ func TestJeopardyWebsocket(t *testing.T) {
 				assert.Equal(t, question.Category, 1)
 				assert.Equal(t, question.Question, "5")
 				assert.Equal(t, question.Points, 200)
+
+				sendCommand(ctx, t, ws, qg.CommandJeopardyArmBuzzers{})
+				expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, ws)
 			},
 		},
 		{
 			who: "player 1",
 			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, ws)
 				sendCommand(ctx, t, ws, qg.CommandJeopardyPressButton{})
 
 				press := expectEvent[qg.EventJeopardyButtonPressed](ctx, t, ws)
@@ -294,6 +404,87 @@ func TestJeopardyWebsocket(t *testing.T) {
 				assert.Equal(t, turn.Answered, qg.JeopardyAnsweredQuestions{
 					{Category: 0, Question: 0, Player: "Player 1"},
 				})
+
+				err := expectCommandError(ctx, t, ws, qg.CommandPauseGame{})
+				assert.Contains(t, err.Message, "only admins")
+				assert.Equal(t, p(qg.ErrorCodeUnauthorized), err.Code)
+			},
+		},
+		{
+			who: "admin",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				sendCommand(ctx, t, ws, qg.CommandPauseGame{})
+
+				paused := expectEvent[qg.EventGamePaused](ctx, t, ws)
+				assert.Equal(t, paused.PlayerName, "Admin")
+			},
+		},
+		{
+			who: "player 1",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				paused := expectEvent[qg.EventGamePaused](ctx, t, ws)
+				assert.Equal(t, paused.PlayerName, "Admin")
+
+				err := expectCommandError(ctx, t, ws, qg.CommandJeopardyChooseQuestion{
+					Category: 0,
+					Question: 1,
+				})
+				assert.Contains(t, err.Message, "paused")
+				assert.Equal(t, p(qg.ErrorCodeInvalidState), err.Code)
+			},
+		},
+		{
+			who: "admin",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				sendCommand(ctx, t, ws, qg.CommandResumeGame{})
+
+				resumed := expectEvent[qg.EventGameResumed](ctx, t, ws)
+				assert.Equal(t, resumed.PlayerName, "Admin")
+			},
+		},
+		{
+			who: "player 1",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				expectEvent[qg.EventGameResumed](ctx, t, ws)
+
+				sendCommand(ctx, t, ws, qg.CommandJeopardyChooseQuestion{
+					Category: 0,
+					Question: 1,
+				})
+
+				question := expectEvent[qg.EventJeopardyBeginQuestion](ctx, t, ws)
+				assert.Equal(t, question.Question, "2")
+			},
+		},
+		{
+			who: "display",
+			act: func(t *testing.T, ctx context.Context, ws *west.WebsocketTest) {
+				// The game has begun, but we may still join, and we are
+				// caught up right away.
+				sendCommand(ctx, t, ws, qg.CommandJoinGame{
+					GameID:     gameID,
+					PlayerName: "Latecomer",
+				})
+
+				joined := expectEvent[qg.EventJoinedGame](ctx, t, ws)
+				assert.False(t, joined.IsAdmin)
+
+				snapshot := expectEvent[qg.EventGameSnapshot](ctx, t, ws)
+				assert.True(t, snapshot.Started)
+				assert.False(t, snapshot.Paused)
+				assert.Equal(t, []qg.PlayerName{"Player 1", "Latecomer"}, snapshot.Players)
+				assert.NotZero(t, snapshot.Game)
+
+				game, ok := snapshot.Game.Value.(qg.GameSnapshotJeopardy)
+				assert.True(t, ok)
+				assert.Equal(t, qg.JeopardyPhaseReading, game.Data.Phase)
+				assert.Equal(t, "Player 1", game.Data.Chooser)
+				assert.Equal(t, qg.JeopardyAnsweredQuestions{
+					{Category: 0, Question: 0, Player: "Player 1"},
+				}, game.Data.Answered)
+				assert.NotZero(t, game.Data.Question)
+				assert.Equal(t, "2", game.Data.Question.Question)
+				assert.Equal(t, int32(1), game.Data.Question.Index)
 			},
 		},
 	}
@@ -391,12 +582,66 @@ bLoop:
 	}
 }
 
+// sendCommand sends a command and waits for the server to acknowledge it.
+func TestWebsocketInvalidCommandID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ws := startTestWebsocket(ctx, t, srv, "player")
+
+	// The command itself is fine, but its ID is not a string.
+	must(t, ws.Send(ctx, map[string]any{
+		"type": "RequestSnapshot",
+		"id":   1,
+	}))
+
+	ev := expectEvent[qg.EventError](ctx, t, ws)
+	assert.Equal(t, p(qg.ErrorCodeInvalidRequest), ev.Error.Code)
+	assert.Zero(t, ev.ID)
+
+	// The connection stays open.
+	joinErr := expectCommandError(ctx, t, ws, qg.CommandJoinGame{
+		GameID:     "nope",
+		PlayerName: "Player",
+	})
+	assert.Equal(t, p(qg.ErrorCodeNotFound), joinErr.Code)
+}
+
 func sendCommand(ctx context.Context, t *testing.T, ws *west.WebsocketTest, cmd qg.ICommand) {
 	t.Helper()
-	err := ws.Send(ctx, cmd)
+	err := ws.Command(ctx, cmd)
 	must(t, err)
 }
 
+// expectCommandError sends a command and returns the error that the server
+// replied with. It fails the test if the server acknowledged the command.
+func expectCommandError(ctx context.Context, t *testing.T, ws *west.WebsocketTest, cmd qg.ICommand) qg.Error {
+	t.Helper()
+
+	err := ws.Command(ctx, cmd)
+	if err == nil {
+		t.Fatalf("expected %T to fail", cmd)
+	}
+
+	var cmdErr *west.CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatal(err)
+	}
+
+	return cmdErr.Err
+}
+
 func expectEvent[T qg.IEvent](ctx context.Context, t *testing.T, w *west.WebsocketTest) T {
 	t.Helper()
 
@@ -49,6 +49,7 @@ type NextStates []NextState
 // NextState describes the next state to transition to.
 type NextState struct {
 	nextType reflect.Type
+	guards   []Guard
 }
 
 // Next constructs a NextState that can be used to change the current State to
@@ -60,23 +61,205 @@ func Next[T any]() NextState {
 	}
 }
 
+// When returns a copy of the NextState whose transition is only taken once
+// all of the given guards pass. The guards are checked in order, before the
+// guards of the state itself.
+func (n NextState) When(guards ...Guard) NextState {
+	n.guards = append(append([]Guard(nil), n.guards...), guards...)
+	return n
+}
+
+// Guard is a condition that must hold for a transition to be taken. If it
+// does not, the guard returns an error that explains why, and the machine
+// stays where it is.
+type Guard func(ctx context.Context) error
+
+func checkGuards(ctx context.Context, guards []Guard) error {
+	for _, guard := range guards {
+		if err := guard(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stay is the type of the next state returned by Stay.
+type stay struct{}
+
+// Stay returns the next states of a superstate's state that keeps the machine
+// in the substate that it was in. See MachineData.Superstate.
+func Stay() NextStates {
+	return NextStates{Next[stay]()}
+}
+
+func isStay(next NextStates) bool {
+	return len(next) == 1 && next[0].nextType == reflect.TypeOf(stay{})
+}
+
 // EndReaction is a special type that indicates to the machine that the function
-// is meant to react to the end of the state machine.
+// is meant to react to the end of the state machine. A machine ends once a
+// state returns no next states, after which EndReaction is its only next
+// state.
 type EndReaction struct{}
 
-// React describes a function that reacts to a change in state. The previous
-// and next types will describe the state that was left and the state that was
-// entered, respectively.
-func React[PrevT, NextT any](f func(ctx context.Context, prev PrevT) error) AnyReactor {
-	var prevZ PrevT
-	var nextZ NextT
-	return reactor[PrevT, NextT]{
-		f: f,
-		t: [2]reflect.Type{
-			reflect.TypeOf(prevZ),
-			reflect.TypeOf(nextZ),
-		},
+// Hook is the kind of change that a reactor reacts to.
+//
+// After every change of state, the reactors run in three phases: first the
+// leave reactors, then the transition reactors, and then the enter and React
+// reactors. Within a phase, reactors run in the order that they are given in
+// MachineData.Reactors. Reactors only run once the new state was entered
+// without an error.
+type Hook string
+
+const (
+	// HookLeave is the hook of OnLeave.
+	HookLeave Hook = "leave"
+	// HookTransition is the hook of OnTransition.
+	HookTransition Hook = "transition"
+	// HookEnter is the hook of OnEnter.
+	HookEnter Hook = "enter"
+	// HookReact is the hook of React.
+	HookReact Hook = "react"
+)
+
+// phase returns the phase that reactors of the hook run in.
+func (h Hook) phase() Hook {
+	if h == HookReact {
+		return HookEnter
+	}
+	return h
+}
+
+var hookPhases = [...]Hook{HookLeave, HookTransition, HookEnter}
+
+// change is a change of state that reactors react to.
+type change struct {
+	// left is true if the machine left a state. It is false once the machine
+	// starts and when a superstate's state stays.
+	left     bool
+	from     reflect.Type
+	fromData any
+	to       reflect.Type
+	toData   any
+	// next lists the next states after the change.
+	next []reflect.Type
+	// stayed is true if a superstate's state kept the machine in its
+	// substate. See MachineData.Superstate.
+	stayed bool
+}
+
+// matchType returns true if the type t of a state matches the type want of a
+// reactor. A nil want matches any state, including the initial state, whose
+// type is nil.
+func matchType(want, t reflect.Type) bool {
+	return want == nil || (t != nil && t.AssignableTo(want))
+}
+
+// entered returns true if the change enters a state of type want. A state of
+// the superstate that stays is only entered if want names it.
+func (c *change) entered(want reflect.Type) bool {
+	if c.stayed && want == nil {
+		return false
+	}
+	return matchType(want, c.to)
+}
+
+// offers returns true if the machine offers a next state of type want after
+// the change.
+func (c *change) offers(want reflect.Type) bool {
+	if want == nil {
+		return true
+	}
+	for _, t := range c.next {
+		if t.AssignableTo(want) {
+			return true
+		}
 	}
+	return false
+}
+
+// as converts the data of a state to T. The data of the initial state is nil,
+// which becomes the zero value.
+func as[T any](data any) T {
+	v, _ := data.(T)
+	return v
+}
+
+func typeOf[T any]() reflect.Type {
+	var z T
+	return reflect.TypeOf(z)
+}
+
+// OnEnter describes a function that runs once the machine enters a state of
+// type T. It is given the input that the state was entered with. If T is any,
+// it runs after every change of state except for superstate states that stay.
+func OnEnter[T any](f func(ctx context.Context, data T) error) AnyReactor {
+	return enterReactor[T]{f, typeOf[T]()}
+}
+
+type enterReactor[T any] struct {
+	f func(ctx context.Context, data T) error
+	t reflect.Type
+}
+
+func (r enterReactor[T]) hook() Hook                 { return HookEnter }
+func (r enterReactor[T]) dataTypes() [2]reflect.Type { return [2]reflect.Type{nil, r.t} }
+func (r enterReactor[T]) matches(c *change) bool     { return c.entered(r.t) }
+
+func (r enterReactor[T]) react(ctx context.Context, c *change) error {
+	return r.f(ctx, as[T](c.toData))
+}
+
+// OnLeave describes a function that runs once the machine leaves a state of
+// type T for another state. It is given the input that the state was entered
+// with. A state is left even if the machine enters another state of the same
+// type, but not when a superstate's state stays.
+func OnLeave[T any](f func(ctx context.Context, data T) error) AnyReactor {
+	return leaveReactor[T]{f, typeOf[T]()}
+}
+
+type leaveReactor[T any] struct {
+	f func(ctx context.Context, data T) error
+	t reflect.Type
+}
+
+func (r leaveReactor[T]) hook() Hook                 { return HookLeave }
+func (r leaveReactor[T]) dataTypes() [2]reflect.Type { return [2]reflect.Type{r.t, nil} }
+func (r leaveReactor[T]) matches(c *change) bool     { return c.left && matchType(r.t, c.from) }
+
+func (r leaveReactor[T]) react(ctx context.Context, c *change) error {
+	return r.f(ctx, as[T](c.fromData))
+}
+
+// OnTransition describes a function that runs once the machine leaves a state
+// of type FromT for a state of type ToT. It is given the inputs that both
+// states were entered with.
+func OnTransition[FromT, ToT any](f func(ctx context.Context, from FromT, to ToT) error) AnyReactor {
+	return transitionReactor[FromT, ToT]{f, [2]reflect.Type{typeOf[FromT](), typeOf[ToT]()}}
+}
+
+type transitionReactor[FromT, ToT any] struct {
+	f func(ctx context.Context, from FromT, to ToT) error
+	t [2]reflect.Type
+}
+
+func (r transitionReactor[FromT, ToT]) hook() Hook                 { return HookTransition }
+func (r transitionReactor[FromT, ToT]) dataTypes() [2]reflect.Type { return r.t }
+
+func (r transitionReactor[FromT, ToT]) matches(c *change) bool {
+	return c.left && matchType(r.t[0], c.from) && matchType(r.t[1], c.to)
+}
+
+func (r transitionReactor[FromT, ToT]) react(ctx context.Context, c *change) error {
+	return r.f(ctx, as[FromT](c.fromData), as[ToT](c.toData))
+}
+
+// React describes a function that runs once the machine enters a state of
+// type PrevT, like OnEnter, but only if the state offers a next state of type
+// NextT. It is given the input that the state was entered with. Either type
+// may be any. React[any, EndReaction] runs once the machine ends.
+func React[PrevT, NextT any](f func(ctx context.Context, prev PrevT) error) AnyReactor {
+	return reactor[PrevT, NextT]{f, [2]reflect.Type{typeOf[PrevT](), typeOf[NextT]()}}
 }
 
 type reactor[PrevT, NextT any] struct {
@@ -84,10 +267,25 @@ type reactor[PrevT, NextT any] struct {
 	t [2]reflect.Type
 }
 
+func (r reactor[PrevT, NextT]) hook() Hook                 { return HookReact }
+func (r reactor[PrevT, NextT]) dataTypes() [2]reflect.Type { return r.t }
+
+func (r reactor[PrevT, NextT]) matches(c *change) bool {
+	return c.entered(r.t[0]) && c.offers(r.t[1])
+}
+
+func (r reactor[PrevT, NextT]) react(ctx context.Context, c *change) error {
+	return r.f(ctx, as[PrevT](c.toData))
+}
+
 // AnyReactor is an interface that any reactor will implement.
 type AnyReactor interface {
+	hook() Hook
+	// dataTypes returns the types of the states that the reactor reacts to
+	// leaving and entering. See Inspection.Reactors.
 	dataTypes() [2]reflect.Type
-	react(ctx context.Context, prev any, machine *Machine) error
+	matches(c *change) bool
+	react(ctx context.Context, c *change) error
 }
 
 // JoinReactors flattens the given reactors list (or reactors) into a single
@@ -107,19 +305,35 @@ func JoinReactors(reactors ...any) []AnyReactor {
 	return out
 }
 
-var _ AnyReactor = reactor[any, any]{}
+var (
+	_ AnyReactor = reactor[any, any]{}
+	_ AnyReactor = enterReactor[any]{}
+	_ AnyReactor = leaveReactor[any]{}
+	_ AnyReactor = transitionReactor[any, any]{}
+)
 
-func (r reactor[PrevT, NextT]) dataTypes() [2]reflect.Type {
-	return r.t
+// State creates a state in the FSM.
+func State[T any](f func(ctx context.Context, value T) (NextStates, error)) AnyState {
+	return stateFunc[T](f)
 }
 
-func (r reactor[PrevT, NextT]) react(ctx context.Context, prev any, _ *Machine) error {
-	return r.f(ctx, prev.(PrevT))
+// Guarded returns a state that can only be entered once all of the given
+// guards pass. The guards are checked in order, after the guards of the
+// transition.
+func Guarded(state AnyState, guards ...Guard) AnyState {
+	return guardedState{state, guards}
 }
 
-// State creates a state in the FSM.
-func State[T any](f func(ctx context.Context, value T) (NextStates, error)) AnyState {
-	return stateFunc[T](f)
+type guardedState struct {
+	AnyState
+	guards []Guard
+}
+
+func (s guardedState) enter(ctx context.Context, data any) (NextStates, error) {
+	if err := checkGuards(ctx, s.guards); err != nil {
+		return nil, err
+	}
+	return s.AnyState.enter(ctx, data)
 }
 
 // InitState describes the initial state of the FSM. Its sole job should be to
@@ -157,8 +371,19 @@ func (s InitState) enter(ctx context.Context, data any) (NextStates, error) {
 // MachineData is a struct that holds the data for creating a finite state
 // machine.
 type MachineData struct {
-	States       []AnyState
-	Reactors     []AnyReactor
+	States []AnyState
+	// Superstate holds the states of a superstate that contains every other
+	// state. Their transitions are always allowed, no matter which substate
+	// the machine is in, even once it has ended. A superstate's state either
+	// returns next states like any other state, or it returns Stay to keep
+	// the machine in its substate. In the latter case, no state is left, and
+	// only the enter and React reactors that name the superstate's input
+	// are run.
+	Superstate []AnyState
+	Reactors   []AnyReactor
+	// EnterMachine and LeaveMachine are called around every Start, Change and
+	// Inspect. They may be nil if the caller already serializes calls into
+	// the machine.
 	EnterMachine func(ctx context.Context) error
 	LeaveMachine func(ctx context.Context) error
 }
@@ -183,7 +408,13 @@ type Machine struct {
 	next        NextStates
 
 	state map[reflect.Type]AnyState
+	super map[reflect.Type]AnyState
 	data  MachineData
+
+	// offered records every transition that the machine has offered so far.
+	// See Inspection.Transitions.
+	offered     map[Transition]bool
+	transitions []Transition
 }
 
 // NewMachine creates a new FSM and returns it.
@@ -194,61 +425,64 @@ func NewMachine(data MachineData) *Machine {
 
 	mac := &Machine{
 		state: make(map[reflect.Type]AnyState, len(data.States)),
+		super: make(map[reflect.Type]AnyState, len(data.Superstate)),
 		data:  data,
+
+		offered: make(map[Transition]bool),
 	}
 
 	for _, state := range data.States {
 		mac.state[state.dataType()] = state
 	}
 
+	for _, state := range data.Superstate {
+		if _, ok := mac.state[state.dataType()]; ok {
+			panic(fmt.Sprintf("state %v is also in the superstate", state.dataType()))
+		}
+		mac.super[state.dataType()] = state
+	}
+
 	return mac
 }
 
-func (f *Machine) enter(ctx context.Context, do func() error) (err error) {
-	if err := f.data.EnterMachine(ctx); err != nil {
-		return err
-	}
-
-	if err = do(); err != nil {
-		f.data.LeaveMachine(ctx)
-		return err
+// enter runs do within the machine and then runs the reactors for the change
+// that do describes. See Hook for the order of the reactors.
+func (f *Machine) enter(ctx context.Context, do func() (change, error)) (err error) {
+	if f.data.EnterMachine != nil {
+		if err := f.data.EnterMachine(ctx); err != nil {
+			return err
+		}
 	}
 
-	if f.data.LeaveMachine != nil {
-		if innerErr := f.data.LeaveMachine(ctx); innerErr != nil && err == nil {
-			err = innerErr
+	// Reactors run before we leave the machine, so that they see the state
+	// that they are reacting to even if another input comes in concurrently.
+	defer func() {
+		if f.data.LeaveMachine != nil {
+			if innerErr := f.data.LeaveMachine(ctx); innerErr != nil && err == nil {
+				err = innerErr
+			}
 		}
-	}
+	}()
 
-	prev := f.current.dataType()
+	c, err := do()
+	if err != nil {
+		return err
+	}
 
-	nexts := make([]reflect.Type, len(f.next))
+	c.next = make([]reflect.Type, len(f.next))
 	for i, next := range f.next {
-		nexts[i] = next.nextType
+		c.next[i] = next.nextType
 	}
 
-reactorMatch:
-	for _, reactor := range f.data.Reactors {
-		types := reactor.dataTypes()
-
-		if types[0] != nil {
-			if prev == nil || !prev.AssignableTo(types[0]) {
-				continue reactorMatch
+	for _, phase := range hookPhases {
+		for _, reactor := range f.data.Reactors {
+			if reactor.hook().phase() != phase || !reactor.matches(&c) {
+				continue
 			}
-		}
-
-		if types[1] != nil {
-			for _, t := range nexts {
-				if !t.AssignableTo(types[1]) {
-					continue reactorMatch
-				}
-				break
+			if err := reactor.react(ctx, &c); err != nil {
+				return errors.Wrapf(err, "error reacting to %s %v", reactor.hook(), reactor.dataTypes())
 			}
 		}
-
-		if err := reactor.react(ctx, f.currentData, f); err != nil {
-			return errors.Wrapf(err, "error reacting to %v", types)
-		}
 	}
 
 	return
@@ -257,43 +491,74 @@ reactorMatch:
 // Start starts the FSM. It will call the EnterMachine function, and then
 // transition to the first state.
 func (f *Machine) Start(ctx context.Context) (err error) {
-	return f.enter(ctx, func() error {
+	return f.enter(ctx, func() (change, error) {
 		if f.current != nil {
-			return errors.New("machine already started")
+			return change{}, errors.New("machine already started")
 		}
 
 		f.current = f.data.States[0].(InitState)
 		f.next, _ = f.current.enter(ctx, nil)
+		f.offer(nil)
 
-		return nil
+		return change{}, nil
 	})
 }
 
+// ErrNotAllowed is returned by Change when the machine cannot go to the given
+// state from its current state.
+var ErrNotAllowed = errors.New("not allowed")
+
 // Change allows you to change the current, "main" State assigned to the FSM.
 // The caller must have called Start first, otherwise an error is returned.
 func (f *Machine) Change(ctx context.Context, data any) (err error) {
 	dataType := reflect.TypeOf(data)
 
-	return f.enter(ctx, func() error {
+	return f.enter(ctx, func() (change, error) {
 		if f.current == nil {
-			return errors.New("machine not started")
+			return change{}, errors.New("machine not started")
 		}
 
 		// Validate the transition.
 		var next AnyState
+		var guards []Guard
 		for _, acceptableNext := range f.next {
 			if acceptableNext.nextType == dataType {
 				next = f.state[acceptableNext.nextType]
+				guards = acceptableNext.guards
 				goto allowed
 			}
 		}
 
-		return fmt.Errorf("cannot change to state of type %T: not allowed", data)
+		if state, ok := f.super[dataType]; ok {
+			next = state
+			goto allowed
+		}
+
+		return change{}, errors.Wrapf(ErrNotAllowed, "cannot change to state of type %T", data)
 	allowed:
 
+		if err := checkGuards(ctx, guards); err != nil {
+			return change{}, err
+		}
+
 		nextNexts, err := next.enter(ctx, data)
 		if err != nil {
-			return err
+			return change{}, err
+		}
+
+		if isStay(nextNexts) {
+			if _, ok := f.super[dataType]; !ok {
+				return change{}, fmt.Errorf("state of type %T cannot stay: not in the superstate", data)
+			}
+			return change{to: dataType, toData: data, stayed: true}, nil
+		}
+
+		c := change{
+			left:     true,
+			from:     f.current.dataType(),
+			fromData: f.currentData,
+			to:       dataType,
+			toData:   data,
 		}
 
 		f.current = next
@@ -303,7 +568,8 @@ func (f *Machine) Change(ctx context.Context, data any) (err error) {
 		if f.next == nil {
 			f.next = []NextState{{nextType: reflect.TypeOf(EndReaction{})}}
 		}
+		f.offer(dataType)
 
-		return err
+		return c, nil
 	})
 }
@@ -0,0 +1,240 @@
+package cando
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+type (
+	ping  struct{}
+	pong  struct{}
+	pause struct{}
+)
+
+func TestGuards(t *testing.T) {
+	ctx := context.Background()
+
+	var pingOK, pongOK bool
+	var reacted []string
+
+	m := NewMachine(MachineData{
+		States: []AnyState{
+			InitState(func(ctx context.Context) NextStates {
+				return NextStates{
+					Next[ping]().When(func(ctx context.Context) error {
+						if !pingOK {
+							return errors.New("not yet")
+						}
+						return nil
+					}),
+				}
+			}),
+			State(func(ctx context.Context, _ ping) (NextStates, error) {
+				return NextStates{Next[pong]()}, nil
+			}),
+			Guarded(
+				State(func(ctx context.Context, _ pong) (NextStates, error) {
+					return nil, nil
+				}),
+				func(ctx context.Context) error {
+					if !pongOK {
+						return errors.New("not now")
+					}
+					return nil
+				},
+			),
+		},
+		Superstate: []AnyState{
+			State(func(ctx context.Context, _ pause) (NextStates, error) {
+				return Stay(), nil
+			}),
+		},
+		Reactors: []AnyReactor{
+			React[pause, any](func(ctx context.Context, _ pause) error {
+				reacted = append(reacted, "pause")
+				return nil
+			}),
+			React[any, pong](func(ctx context.Context, _ any) error {
+				reacted = append(reacted, "pong")
+				return nil
+			}),
+		},
+		EnterMachine: func(ctx context.Context) error { return nil },
+		LeaveMachine: func(ctx context.Context) error { return nil },
+	})
+
+	assert.NoError(t, m.Start(ctx))
+
+	err := m.Change(ctx, ping{})
+	assert.EqualError(t, err, "not yet")
+
+	pingOK = true
+	assert.NoError(t, m.Change(ctx, ping{}))
+	assert.Equal(t, []string{"pong"}, reacted)
+
+	// The superstate is always allowed, and it keeps the machine where it
+	// was. Reactors for the substate are not run again.
+	assert.NoError(t, m.Change(ctx, pause{}))
+	assert.Equal(t, []string{"pong", "pause"}, reacted)
+
+	err = m.Change(ctx, pong{})
+	assert.EqualError(t, err, "not now")
+
+	pongOK = true
+	assert.NoError(t, m.Change(ctx, pong{}))
+
+	err = m.Change(ctx, ping{})
+	assert.Error(t, err)
+
+	// Even an ended machine is in the superstate.
+	assert.NoError(t, m.Change(ctx, pause{}))
+}
+
+func TestStayOutsideSuperstate(t *testing.T) {
+	ctx := context.Background()
+
+	m := NewMachine(MachineData{
+		States: []AnyState{
+			InitState(func(ctx context.Context) NextStates {
+				return NextStates{Next[ping]()}
+			}),
+			State(func(ctx context.Context, _ ping) (NextStates, error) {
+				return Stay(), nil
+			}),
+		},
+		EnterMachine: func(ctx context.Context) error { return nil },
+		LeaveMachine: func(ctx context.Context) error { return nil },
+	})
+
+	assert.NoError(t, m.Start(ctx))
+
+	err := m.Change(ctx, ping{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not in the superstate")
+}
+
+func TestInspect(t *testing.T) {
+	ctx := context.Background()
+
+	m := NewMachine(MachineData{
+		States: []AnyState{
+			InitState(func(ctx context.Context) NextStates {
+				return NextStates{Next[ping]()}
+			}),
+			State(func(ctx context.Context, _ ping) (NextStates, error) {
+				return NextStates{Next[pong]()}, nil
+			}),
+			State(func(ctx context.Context, _ pong) (NextStates, error) {
+				return nil, nil
+			}),
+		},
+		EnterMachine: func(ctx context.Context) error { return nil },
+		LeaveMachine: func(ctx context.Context) error { return nil },
+	})
+
+	i, err := m.Inspect(ctx)
+	assert.NoError(t, err)
+	assert.False(t, i.Started)
+
+	assert.NoError(t, m.Start(ctx))
+	assert.NoError(t, m.Change(ctx, ping{}))
+
+	i, err = m.Inspect(ctx)
+	assert.NoError(t, err)
+	assert.True(t, i.Started)
+	assert.False(t, i.Ended())
+	assert.Equal(t, "cando.ping", TypeName(i.Current))
+	assert.Equal(t, []Transition{
+		{nil, reflect.TypeOf(ping{})},
+		{reflect.TypeOf(ping{}), reflect.TypeOf(pong{})},
+	}, i.Transitions)
+
+	assert.Equal(t, `digraph machine {
+	"init" [shape=point];
+	"end" [shape=doublecircle];
+	"cando.ping" [style=filled];
+	"cando.pong";
+	"init" -> "cando.ping";
+	"cando.ping" -> "cando.pong" [style=bold];
+}
+`, i.DOT())
+
+	assert.NoError(t, m.Change(ctx, pong{}))
+
+	i, err = m.Inspect(ctx)
+	assert.NoError(t, err)
+	assert.True(t, i.Ended())
+	assert.Equal(t, `stateDiagram-v2
+	classDef current font-weight:bold
+	state "cando.ping" as s0
+	state "cando.pong" as s1
+	[*] --> s0
+	s0 --> s1
+	s1 --> [*]
+	class s1 current
+`, i.Mermaid())
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+	record := func(call string) error {
+		calls = append(calls, call)
+		return nil
+	}
+
+	m := NewMachine(MachineData{
+		States: []AnyState{
+			InitState(func(ctx context.Context) NextStates {
+				return NextStates{Next[ping]()}
+			}),
+			State(func(ctx context.Context, _ ping) (NextStates, error) {
+				return NextStates{Next[ping](), Next[pong]()}, nil
+			}),
+			State(func(ctx context.Context, _ pong) (NextStates, error) {
+				return nil, nil
+			}),
+		},
+		Reactors: []AnyReactor{
+			React[ping, pong](func(ctx context.Context, _ ping) error {
+				return record("ping offers pong")
+			}),
+			OnEnter[ping](func(ctx context.Context, _ ping) error {
+				return record("enter ping")
+			}),
+			OnTransition[ping, ping](func(ctx context.Context, _, _ ping) error {
+				return record("ping to ping")
+			}),
+			OnTransition[ping, pong](func(ctx context.Context, _ ping, _ pong) error {
+				return record("ping to pong")
+			}),
+			OnLeave[ping](func(ctx context.Context, _ ping) error {
+				return record("leave ping")
+			}),
+			React[any, EndReaction](func(ctx context.Context, _ any) error {
+				return record("end")
+			}),
+		},
+		EnterMachine: func(ctx context.Context) error { return nil },
+		LeaveMachine: func(ctx context.Context) error { return nil },
+	})
+
+	assert.NoError(t, m.Start(ctx))
+
+	assert.NoError(t, m.Change(ctx, ping{}))
+	// pong is not the first next state, but it is still offered.
+	assert.Equal(t, []string{"ping offers pong", "enter ping"}, calls)
+
+	calls = nil
+	assert.NoError(t, m.Change(ctx, ping{}))
+	assert.Equal(t, []string{"leave ping", "ping to ping", "ping offers pong", "enter ping"}, calls)
+
+	calls = nil
+	assert.NoError(t, m.Change(ctx, pong{}))
+	assert.Equal(t, []string{"leave ping", "ping to pong", "end"}, calls)
+}
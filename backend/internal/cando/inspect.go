@@ -0,0 +1,221 @@
+package cando
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Transition is a transition from the state of one input type to the state of
+// another. A nil From is the initial state, and a To of EndReaction ends the
+// machine.
+type Transition struct {
+	From reflect.Type
+	To   reflect.Type
+}
+
+// ReactorInfo describes a reactor. From and To are the types of the states
+// that it reacts to leaving and entering; a nil type matches any state. For a
+// React reactor, To is instead the type of the next state that the entered
+// state must offer, and From the type of the entered state.
+type ReactorInfo struct {
+	Hook Hook
+	From reflect.Type
+	To   reflect.Type
+}
+
+// Inspection is a snapshot of a Machine. It is meant for debugging.
+type Inspection struct {
+	// Started is true if the machine has been started.
+	Started bool
+	// Current is the input type of the current state. It is nil while the
+	// machine is in its initial state.
+	Current reflect.Type
+	// Next lists the input types that the machine accepts next, not counting
+	// the superstate. An ended machine only lists EndReaction.
+	Next []reflect.Type
+	// States lists the input types of all states, not counting the initial
+	// state and the superstate.
+	States []reflect.Type
+	// Superstate lists the input types of the superstate's states.
+	Superstate []reflect.Type
+	// Reactors lists every reactor in the order that they were given.
+	Reactors []ReactorInfo
+	// Transitions lists every transition that the machine has offered so
+	// far, in the order that they were first offered. States only decide
+	// their next states once they are entered, so transitions that were
+	// never offered are not known.
+	Transitions []Transition
+}
+
+// Ended returns true if the machine has ended.
+func (i Inspection) Ended() bool {
+	return len(i.Next) == 1 && i.Next[0] == reflect.TypeOf(EndReaction{})
+}
+
+// Inspect returns a snapshot of the machine. Like Change, it must not be
+// called from within a state or a reactor.
+func (f *Machine) Inspect(ctx context.Context) (Inspection, error) {
+	if f.data.EnterMachine != nil {
+		if err := f.data.EnterMachine(ctx); err != nil {
+			return Inspection{}, err
+		}
+	}
+	if f.data.LeaveMachine != nil {
+		defer f.data.LeaveMachine(ctx)
+	}
+
+	var i Inspection
+	if f.current != nil {
+		i.Started = true
+		i.Current = f.current.dataType()
+	}
+
+	for _, next := range f.next {
+		i.Next = append(i.Next, next.nextType)
+	}
+	for _, state := range f.data.States[1:] {
+		i.States = append(i.States, state.dataType())
+	}
+	for _, state := range f.data.Superstate {
+		i.Superstate = append(i.Superstate, state.dataType())
+	}
+	for _, reactor := range f.data.Reactors {
+		types := reactor.dataTypes()
+		i.Reactors = append(i.Reactors, ReactorInfo{
+			Hook: reactor.hook(),
+			From: types[0],
+			To:   types[1],
+		})
+	}
+	i.Transitions = append(i.Transitions, f.transitions...)
+
+	return i, nil
+}
+
+// offer records the transitions from the state of the given input type to
+// the current next states.
+func (f *Machine) offer(from reflect.Type) {
+	for _, next := range f.next {
+		t := Transition{From: from, To: next.nextType}
+		if !f.offered[t] {
+			f.offered[t] = true
+			f.transitions = append(f.transitions, t)
+		}
+	}
+}
+
+// TypeName returns the name of an input type as it is shown in an
+// Inspection's graphs. The initial state is named "init", the end of the
+// machine "end" and a wildcard "any".
+func TypeName(t reflect.Type) string {
+	switch t {
+	case nil:
+		return "any"
+	case reflect.TypeOf(EndReaction{}):
+		return "end"
+	default:
+		return t.String()
+	}
+}
+
+func stateName(t reflect.Type) string {
+	if t == nil {
+		return "init"
+	}
+	return TypeName(t)
+}
+
+// DOT renders the states and known transitions of the machine in the
+// Graphviz DOT language. The current state is filled in, and the transitions
+// that the machine accepts next are bold.
+func (i Inspection) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph machine {\n")
+	b.WriteString("\t\"init\" [shape=point];\n")
+	b.WriteString("\t\"end\" [shape=doublecircle];\n")
+
+	for _, t := range i.States {
+		fmt.Fprintf(&b, "\t%q", stateName(t))
+		if i.Started && t == i.Current {
+			b.WriteString(" [style=filled]")
+		}
+		b.WriteString(";\n")
+	}
+
+	if len(i.Superstate) > 0 {
+		b.WriteString("\tsubgraph cluster_superstate {\n")
+		b.WriteString("\t\tlabel=\"superstate\";\n")
+		for _, t := range i.Superstate {
+			fmt.Fprintf(&b, "\t\t%q;\n", stateName(t))
+		}
+		b.WriteString("\t}\n")
+	}
+
+	for _, t := range i.Transitions {
+		fmt.Fprintf(&b, "\t%q -> %q", stateName(t.From), stateName(t.To))
+		if i.Started && t.From == i.Current && i.isNext(t.To) {
+			b.WriteString(" [style=bold]")
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the states and known transitions of the machine as a
+// Mermaid state diagram. The current state is marked with the current class.
+func (i Inspection) Mermaid() string {
+	ids := make(map[reflect.Type]string, len(i.States)+len(i.Superstate))
+	id := func(t reflect.Type) string {
+		switch t {
+		case nil:
+			return "[*]"
+		case reflect.TypeOf(EndReaction{}):
+			return "[*]"
+		}
+		s, ok := ids[t]
+		if !ok {
+			s = fmt.Sprintf("s%d", len(ids))
+			ids[t] = s
+		}
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	b.WriteString("\tclassDef current font-weight:bold\n")
+
+	for _, t := range i.States {
+		fmt.Fprintf(&b, "\tstate %q as %s\n", stateName(t), id(t))
+	}
+
+	if len(i.Superstate) > 0 {
+		b.WriteString("\tstate superstate {\n")
+		for _, t := range i.Superstate {
+			fmt.Fprintf(&b, "\t\tstate %q as %s\n", stateName(t), id(t))
+		}
+		b.WriteString("\t}\n")
+	}
+
+	for _, t := range i.Transitions {
+		fmt.Fprintf(&b, "\t%s --> %s\n", id(t.From), id(t.To))
+	}
+
+	if i.Started && i.Current != nil {
+		fmt.Fprintf(&b, "\tclass %s current\n", id(i.Current))
+	}
+
+	return b.String()
+}
+
+func (i Inspection) isNext(t reflect.Type) bool {
+	for _, next := range i.Next {
+		if next == t {
+			return true
+		}
+	}
+	return false
+}
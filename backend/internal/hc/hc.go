@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -93,7 +94,7 @@ func (c *Client) do(ctx context.Context, method string, u url.URL, reqBody, resp
 		if err := json.NewDecoder(resp.Body).Decode(&err); err != nil {
 			return errors.Errorf("unexpected status code %d (no error in body)", resp.StatusCode)
 		}
-		return errors.Errorf("server returned error status %d: %s", resp.StatusCode, err.Message)
+		return &Error{resp.StatusCode, err}
 	}
 
 	if resp.StatusCode == http.StatusNoContent {
@@ -106,3 +107,14 @@ func (c *Client) do(ctx context.Context, method string, u url.URL, reqBody, resp
 
 	return nil
 }
+
+// Error is an error that the server responded with.
+type Error struct {
+	Status int
+	Err    qg.Error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("server returned error status %d: %s", e.Status, e.Err.Message)
+}
@@ -2,14 +2,145 @@ package pubsub
 
 import (
 	"context"
+	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/pkg/errors"
 	"oss.acmcsuf.com/qg/backend/qg"
 )
 
+// ErrSlowSubscriber is the error that a subscriber is disconnected with once
+// it falls too far behind. It is also the last event that the subscriber is
+// sent.
+var ErrSlowSubscriber = qg.NewCodedError(qg.ErrorCodeSlowSubscriber, "too many events were not read in time")
+
+// OverflowPolicy decides what happens to an event that is published while a
+// subscriber's buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// BlockPolicy makes Publish wait for the subscriber to make room. If it
+	// does not within the timeout, the subscriber is disconnected.
+	BlockPolicy OverflowPolicy = iota
+	// DropOldestPolicy drops the oldest buffered event to make room for the
+	// new one. The subscriber is never disconnected.
+	DropOldestPolicy
+	// DisconnectPolicy disconnects the subscriber right away.
+	DisconnectPolicy
+)
+
+// SubscribeOpts are the options of a channel subscription.
+type SubscribeOpts struct {
+	// Policy is what happens once the buffer is full.
+	Policy OverflowPolicy
+	// BufferSize is the number of events that are buffered for the subscriber
+	// on top of its channel.
+	BufferSize int
+	// Timeout is how long BlockPolicy waits for room. It is also how long a
+	// disconnected subscriber is given to take its last event.
+	Timeout time.Duration
+	// OnDisconnect is called once the subscriber is disconnected, after it
+	// has been sent an EventError, or once the timeout expires.
+	OnDisconnect func(err error)
+	// Stats, if not nil, is updated with the subscriber's buffer metrics.
+	Stats *Stats
+}
+
+// DefaultSubscribeOpts are the options used by Subscribe when the context has
+// none. Subscribers that fall behind are disconnected rather than waited on,
+// so that one slow subscriber never holds up the publisher.
+var DefaultSubscribeOpts = SubscribeOpts{
+	Policy:     DisconnectPolicy,
+	BufferSize: 64,
+	Timeout:    2 * time.Second,
+}
+
+type ctxKey uint8
+
+const subscribeOptsCtxKey ctxKey = iota
+
+// WithSubscribeOpts returns a new context with the given subscribe options.
+// Code that subscribes on behalf of a connection, such as a game's command
+// handler, uses them.
+func WithSubscribeOpts(ctx context.Context, opts SubscribeOpts) context.Context {
+	return context.WithValue(ctx, subscribeOptsCtxKey, opts)
+}
+
+// SubscribeOptsFromContext returns the subscribe options from the context. The
+// DefaultSubscribeOpts are returned if there are none.
+func SubscribeOptsFromContext(ctx context.Context) SubscribeOpts {
+	opts, ok := ctx.Value(subscribeOptsCtxKey).(SubscribeOpts)
+	if !ok {
+		return DefaultSubscribeOpts
+	}
+	return opts
+}
+
+// Stats are the buffer metrics of a single subscriber. All fields are updated
+// atomically, so they may be read while events are being published.
+type Stats struct {
+	// Sent is the number of events that were sent to the channel.
+	Sent atomic.Uint64
+	// Dropped is the number of events that never made it to the channel.
+	Dropped atomic.Uint64
+	// Buffered is the number of events waiting in the buffer.
+	Buffered atomic.Int64
+	// MaxBuffered is the most events that ever waited in the buffer.
+	MaxBuffered atomic.Int64
+	// Disconnected is true once the subscriber has been disconnected.
+	Disconnected atomic.Bool
+}
+
+func (s *Stats) setBuffered(n int) {
+	s.Buffered.Store(int64(n))
+	for {
+		max := s.MaxBuffered.Load()
+		if int64(n) <= max || s.MaxBuffered.CompareAndSwap(max, int64(n)) {
+			return
+		}
+	}
+}
+
+// Topic names a group of subscribers, such as the admins of a game.
+type Topic string
+
+// Filter decides whether a subscribed publisher in the given topics gets an
+// event. A nil Filter lets every event through. Channels that are subscribed
+// directly have no topics.
+type Filter func(topics []Topic) bool
+
+// To returns a Filter that lets through publishers in any of the given topics.
+func To(topics ...Topic) Filter {
+	return func(has []Topic) bool {
+		return hasAnyTopic(has, topics)
+	}
+}
+
+func hasAnyTopic(has, want []Topic) bool {
+	for _, h := range has {
+		for _, w := range want {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Guard checks an event before a publisher passes it on to its subscribers.
+// If the guard returns an error, the event is dropped and the error is logged.
+// It is a last line of defense against publishing to the wrong audience.
+type Guard func(topics []Topic, msg qg.IEvent) error
+
 // Publisher implements an event publisher.
 type Publisher struct {
 	subs sync.Map
+
+	mu     sync.RWMutex
+	topics []Topic
+	guard  Guard
 }
 
 // NewPublisher creates a new publisher.
@@ -22,19 +153,48 @@ type subscribable struct {
 	channel   chan<- qg.IEvent
 }
 
-// Subscribe implements the Subscriber interface.
+// Subscribe implements the Subscriber interface. The DefaultSubscribeOpts are
+// used.
 func (p *Publisher) Subscribe(out chan<- qg.IEvent) {
-	p.subs.Store(subscribable{channel: out}, struct{}{})
+	p.SubscribeWithOpts(out, DefaultSubscribeOpts)
+}
+
+// SubscribeWithOpts subscribes the given channel to the publisher. Events are
+// buffered for the channel, and opts decides what happens once the buffer is
+// full.
+func (p *Publisher) SubscribeWithOpts(out chan<- qg.IEvent, opts SubscribeOpts) {
+	if opts.BufferSize < 1 {
+		opts.BufferSize = 1
+	}
+	if opts.Stats == nil {
+		opts.Stats = new(Stats)
+	}
+
+	sub := &subscriber{
+		out:  out,
+		opts: opts,
+		room: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		gone: make(chan struct{}),
+	}
+
+	if old, ok := p.subs.Swap(subscribable{channel: out}, sub); ok {
+		if old, ok := old.(*subscriber); ok {
+			old.close()
+		}
+	}
 }
 
 // Unsubscribe implements the Subscriber interface.
 func (p *Publisher) Unsubscribe(out chan<- qg.IEvent) {
-	p.subs.Delete(subscribable{channel: out})
+	if sub, ok := p.subs.LoadAndDelete(subscribable{channel: out}); ok {
+		sub.(*subscriber).close()
+	}
 }
 
 // SubscribePublisher subscribes the given publisher to the publisher.
 func (p *Publisher) SubscribePublisher(given *Publisher) {
-	p.subs.Store(subscribable{publisher: given}, struct{}{})
+	p.subs.Store(subscribable{publisher: given}, nil)
 }
 
 // UnsubscribePublisher unsubscribes the given publisher from the publisher.
@@ -42,25 +202,242 @@ func (p *Publisher) UnsubscribePublisher(given *Publisher) {
 	p.subs.Delete(subscribable{publisher: given})
 }
 
-// Publish implements the Publisher interface.
+// SetTopics replaces the topics of the publisher. Publishers that it is
+// subscribed to filter their events by these topics.
+func (p *Publisher) SetTopics(topics ...Topic) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.topics = topics
+}
+
+// Topics returns the topics of the publisher.
+func (p *Publisher) Topics() []Topic {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.topics
+}
+
+// SetGuard sets the guard that checks every event that the publisher passes on.
+func (p *Publisher) SetGuard(guard Guard) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.guard = guard
+}
+
+// Publish implements the Publisher interface. It only blocks if a subscriber
+// with BlockPolicy is full, and never for longer than that subscriber's
+// timeout or until ctx expires.
 func (p *Publisher) Publish(ctx context.Context, msg qg.IEvent) {
-	p.subs.Range(func(k, _ any) bool {
+	p.PublishTo(ctx, nil, msg)
+}
+
+// PublishTo publishes the event to the subscribed publishers that the filter
+// lets through. Like Publish, the subscribed publishers pass the event on to
+// all of their own subscribers.
+func (p *Publisher) PublishTo(ctx context.Context, filter Filter, msg qg.IEvent) {
+	p.mu.RLock()
+	guard, topics := p.guard, p.topics
+	p.mu.RUnlock()
+
+	if guard != nil {
+		if err := guard(topics, msg); err != nil {
+			log.Println("dropping event:", err)
+			return
+		}
+	}
+
+	p.subs.Range(func(k, v any) bool {
 		s := k.(subscribable)
 
+		if filter != nil {
+			var topics []Topic
+			if s.publisher != nil {
+				topics = s.publisher.Topics()
+			}
+			if !filter(topics) {
+				return true
+			}
+		}
+
 		switch {
 		case s.publisher != nil:
 			s.publisher.Publish(ctx, msg)
 		case s.channel != nil:
-			select {
-			case <-ctx.Done():
-				return false
-			case s.channel <- msg:
-				// ok
-			default:
-				p.subs.Delete(k)
+			sub := v.(*subscriber)
+			if err := sub.publish(ctx, msg); err != nil {
+				if errors.Is(err, ErrSlowSubscriber) {
+					p.subs.CompareAndDelete(k, sub)
+				}
+				return ctx.Err() == nil
 			}
 		}
 
 		return true
 	})
 }
+
+// subscriber buffers events for a channel. The buffer is emptied into the
+// channel by a goroutine that only runs while there is something to send.
+type subscriber struct {
+	out  chan<- qg.IEvent
+	opts SubscribeOpts
+	room chan struct{}
+	stop chan struct{} // closed once unsubscribed
+	gone chan struct{} // closed once disconnected
+
+	mu           sync.Mutex
+	buf          []qg.IEvent
+	sending      bool
+	closed       bool
+	disconnected bool
+}
+
+func (s *subscriber) publish(ctx context.Context, msg qg.IEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.closed && len(s.buf) >= s.opts.BufferSize {
+		switch s.opts.Policy {
+		case DropOldestPolicy:
+			s.buf[0] = nil
+			s.buf = s.buf[1:]
+			s.opts.Stats.Dropped.Add(1)
+
+		case BlockPolicy:
+			if err := s.waitForRoom(ctx); err != nil {
+				if errors.Is(err, ErrSlowSubscriber) {
+					s.disconnect()
+				}
+				s.opts.Stats.Dropped.Add(1)
+				return err
+			}
+
+		default:
+			s.disconnect()
+			s.opts.Stats.Dropped.Add(1)
+			return ErrSlowSubscriber
+		}
+	}
+
+	if s.closed {
+		s.opts.Stats.Dropped.Add(1)
+		return nil
+	}
+
+	s.buf = append(s.buf, msg)
+	s.opts.Stats.setBuffered(len(s.buf))
+	s.startSending()
+
+	return nil
+}
+
+// waitForRoom waits for the sending goroutine to take an event out of the
+// buffer. s.mu must be held, and it is released while waiting.
+func (s *subscriber) waitForRoom(ctx context.Context) error {
+	timer := time.NewTimer(s.opts.Timeout)
+	defer timer.Stop()
+
+	s.mu.Unlock()
+	defer s.mu.Lock()
+
+	select {
+	case <-s.room:
+		return nil
+	case <-timer.C:
+		return ErrSlowSubscriber
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// disconnect replaces everything that is still buffered with an EventError,
+// which is the last event that the subscriber gets. s.mu must be held.
+func (s *subscriber) disconnect() {
+	if s.closed {
+		return
+	}
+
+	s.opts.Stats.Dropped.Add(uint64(len(s.buf)))
+	s.opts.Stats.Disconnected.Store(true)
+
+	s.closed = true
+	s.disconnected = true
+	close(s.gone)
+
+	s.buf = []qg.IEvent{qg.EventError{
+		Error: qg.NewError(ErrSlowSubscriber),
+	}}
+	s.startSending()
+}
+
+// close stops sending to the channel without telling the subscriber.
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+	}
+}
+
+// startSending starts the sending goroutine if it is not running. s.mu must be
+// held.
+func (s *subscriber) startSending() {
+	if !s.sending {
+		s.sending = true
+		go s.send()
+	}
+}
+
+func (s *subscriber) send() {
+	for {
+		s.mu.Lock()
+		if len(s.buf) == 0 {
+			s.sending = false
+			disconnected := s.disconnected
+			s.mu.Unlock()
+
+			if disconnected && s.opts.OnDisconnect != nil {
+				s.opts.OnDisconnect(ErrSlowSubscriber)
+			}
+			return
+		}
+
+		msg := s.buf[0]
+		s.buf[0] = nil
+		s.buf = s.buf[1:]
+		s.opts.Stats.setBuffered(len(s.buf))
+
+		select {
+		case s.room <- struct{}{}:
+		default:
+		}
+
+		gone := s.gone
+		var timeout <-chan time.Time
+		if s.disconnected {
+			// The subscriber is not waited on forever, since it is already
+			// too slow.
+			gone = nil
+			timeout = time.After(s.opts.Timeout)
+		}
+		s.mu.Unlock()
+
+		select {
+		case s.out <- msg:
+			s.opts.Stats.Sent.Add(1)
+		case <-s.stop:
+			return
+		case <-gone:
+			// The subscriber was disconnected while we waited, so make way
+			// for the EventError.
+			s.opts.Stats.Dropped.Add(1)
+		case <-timeout:
+			s.opts.Stats.Dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package pubsub
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func publishN(p *Publisher, n int) {
+	for i := 0; i < n; i++ {
+		p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: qg.PlayerName(rune('a' + i))})
+	}
+}
+
+func receive(t *testing.T, evs <-chan qg.IEvent) qg.IEvent {
+	t.Helper()
+
+	select {
+	case ev := <-evs:
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestBlockPolicy(t *testing.T) {
+	p := NewPublisher()
+
+	var stats Stats
+	disconnected := make(chan error, 1)
+
+	evs := make(chan qg.IEvent)
+	p.SubscribeWithOpts(evs, SubscribeOpts{
+		Policy:       BlockPolicy,
+		BufferSize:   2,
+		Timeout:      100 * time.Millisecond,
+		OnDisconnect: func(err error) { disconnected <- err },
+		Stats:        &stats,
+	})
+
+	// Nobody reads, so one event is held by the sender and two are
+	// buffered, and the fourth one gets no room in time. Everything is
+	// dropped to make way for the EventError.
+	publishN(p, 4)
+
+	code := qg.ErrorCodeSlowSubscriber
+	want := qg.EventError{Error: qg.Error{Message: ErrSlowSubscriber.Error(), Code: &code}}
+	if ev := receive(t, evs); !reflect.DeepEqual(ev, want) {
+		t.Fatalf("got event %#v, want %#v", ev, want)
+	}
+
+	select {
+	case err := <-disconnected:
+		if err != ErrSlowSubscriber {
+			t.Fatalf("disconnected with %v, want %v", err, ErrSlowSubscriber)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber was not disconnected")
+	}
+
+	if !stats.Disconnected.Load() {
+		t.Error("stats do not say that the subscriber was disconnected")
+	}
+	if sent, dropped := stats.Sent.Load(), stats.Dropped.Load(); sent != 1 || dropped != 4 {
+		t.Errorf("got %d sent and %d dropped, want 1 and 4", sent, dropped)
+	}
+
+	publishN(p, 1)
+
+	select {
+	case ev := <-evs:
+		t.Fatalf("got event %#v after being disconnected", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBlockPolicyCatchUp(t *testing.T) {
+	p := NewPublisher()
+
+	var stats Stats
+	evs := make(chan qg.IEvent)
+	p.SubscribeWithOpts(evs, SubscribeOpts{
+		Policy:     BlockPolicy,
+		BufferSize: 1,
+		Timeout:    time.Second,
+		Stats:      &stats,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		publishN(p, 5)
+		close(done)
+	}()
+
+	// A subscriber that is only briefly slow gets every event.
+	for i := 0; i < 5; i++ {
+		time.Sleep(10 * time.Millisecond)
+		want := qg.EventPlayerJoined{PlayerName: qg.PlayerName(rune('a' + i))}
+		if ev := receive(t, evs); ev != want {
+			t.Fatalf("got event %#v, want %#v", ev, want)
+		}
+	}
+
+	<-done
+
+	if stats.Dropped.Load() != 0 || stats.Disconnected.Load() {
+		t.Errorf("got %d dropped events, want none", stats.Dropped.Load())
+	}
+	if max := stats.MaxBuffered.Load(); max != 1 {
+		t.Errorf("got %d max buffered events, want 1", max)
+	}
+}
+
+func TestDropOldestPolicy(t *testing.T) {
+	p := NewPublisher()
+
+	var stats Stats
+	evs := make(chan qg.IEvent, 1)
+	p.SubscribeWithOpts(evs, SubscribeOpts{
+		Policy:     DropOldestPolicy,
+		BufferSize: 2,
+		Stats:      &stats,
+	})
+
+	// Wait for the first event to be sent, so that we know which events are
+	// left in the buffer.
+	publishN(p, 1)
+	for stats.Sent.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The channel is full, so the sender holds the next event and the
+	// buffer fills up with the rest.
+	p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: "b"})
+	for stats.Buffered.Load() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for _, name := range []qg.PlayerName{"c", "d", "e", "f"} {
+		p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: name})
+	}
+
+	for _, name := range []qg.PlayerName{"a", "b", "e", "f"} {
+		want := qg.EventPlayerJoined{PlayerName: name}
+		if ev := receive(t, evs); ev != want {
+			t.Fatalf("got event %#v, want %#v", ev, want)
+		}
+	}
+
+	if dropped := stats.Dropped.Load(); dropped != 2 {
+		t.Errorf("got %d dropped events, want 2", dropped)
+	}
+	if stats.Disconnected.Load() {
+		t.Error("subscriber was disconnected")
+	}
+}
+
+func TestDisconnectPolicy(t *testing.T) {
+	p := NewPublisher()
+
+	var stats Stats
+	disconnected := make(chan error, 1)
+
+	evs := make(chan qg.IEvent, 1)
+	p.SubscribeWithOpts(evs, SubscribeOpts{
+		Policy:       DisconnectPolicy,
+		BufferSize:   1,
+		Timeout:      time.Second,
+		OnDisconnect: func(err error) { disconnected <- err },
+		Stats:        &stats,
+	})
+
+	// The first event fills the channel, and the second is held by the
+	// sender.
+	publishN(p, 1)
+	for stats.Sent.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: "b"})
+	for stats.Buffered.Load() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The third event fills the buffer, so the fourth one disconnects.
+	p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: "c"})
+	p.Publish(context.Background(), qg.EventPlayerJoined{PlayerName: "d"})
+
+	if ev := receive(t, evs); ev != (qg.EventPlayerJoined{PlayerName: "a"}) {
+		t.Fatalf("got event %#v, want the first one", ev)
+	}
+
+	code := qg.ErrorCodeSlowSubscriber
+	want := qg.EventError{Error: qg.Error{Message: ErrSlowSubscriber.Error(), Code: &code}}
+	if ev := receive(t, evs); !reflect.DeepEqual(ev, want) {
+		t.Fatalf("got event %#v, want %#v", ev, want)
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber was not disconnected")
+	}
+
+	if !stats.Disconnected.Load() {
+		t.Error("stats do not say that the subscriber was disconnected")
+	}
+	if dropped := stats.Dropped.Load(); dropped != 3 {
+		t.Errorf("got %d dropped events, want 3", dropped)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	p := NewPublisher()
+
+	evs := make(chan qg.IEvent)
+	p.Subscribe(evs)
+	p.Unsubscribe(evs)
+
+	publishN(p, 1)
+
+	select {
+	case ev := <-evs:
+		t.Fatalf("got event %#v after unsubscribing", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,152 @@
+// Package rtt implements round-trip time estimation for connections. It is
+// used to compensate for network latency when ordering time-sensitive
+// commands, such as buzzer presses.
+package rtt
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxSample is the largest round-trip time sample that is trusted. Larger
+// samples are clamped to it, so the one-way latency that can be compensated
+// for is at most half of it.
+const MaxSample = time.Second
+
+type ctxKey uint8
+
+const (
+	estimatorCtxKey ctxKey = iota
+	receivedCtxKey
+)
+
+// Estimator estimates the round-trip time of a single connection. It uses an
+// exponentially weighted moving average, similar to TCP's smoothed RTT. A nil
+// Estimator is valid and always estimates zero.
+type Estimator struct {
+	mu   sync.Mutex
+	srtt time.Duration
+	seen bool
+
+	// pending is the payload of the last ping that has not been answered
+	// yet, and pendingAt is when it was sent.
+	pending   []byte
+	pendingAt time.Time
+}
+
+// NewEstimator creates a new Estimator.
+func NewEstimator() *Estimator {
+	return &Estimator{}
+}
+
+// Ping returns the payload of a new ping that is sent at the given time. Only
+// a pong with this payload is accepted until the next ping, which replaces it.
+func (e *Estimator) Ping(now time.Time) []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pending = strconv.AppendInt(nil, now.UnixNano(), 10)
+	e.pendingAt = now
+	return e.pending
+}
+
+// Pong records the round-trip time of the last ping if the given payload is
+// the one that it was sent with. Each ping is only answered once, so pongs
+// that were not asked for, are answered late or are repeated are ignored, and
+// false is returned.
+func (e *Estimator) Pong(payload []byte, now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.pending == nil || !bytes.Equal(payload, e.pending) {
+		return false
+	}
+
+	sample := now.Sub(e.pendingAt)
+	e.pending = nil
+	e.pendingAt = time.Time{}
+
+	e.observe(sample)
+	return true
+}
+
+// Observe records a new round-trip time sample.
+func (e *Estimator) Observe(sample time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.observe(sample)
+}
+
+func (e *Estimator) observe(sample time.Duration) {
+	if sample < 0 {
+		return
+	}
+	if sample > MaxSample {
+		sample = MaxSample
+	}
+
+	if !e.seen {
+		e.srtt = sample
+		e.seen = true
+		return
+	}
+
+	// SRTT = 7/8 SRTT + 1/8 sample, as per RFC 6298.
+	e.srtt += (sample - e.srtt) / 8
+}
+
+// RTT returns the current smoothed round-trip time.
+func (e *Estimator) RTT() time.Duration {
+	if e == nil {
+		return 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.srtt
+}
+
+// OneWay returns the estimated one-way latency, which is half of the
+// round-trip time.
+func (e *Estimator) OneWay() time.Duration {
+	return e.RTT() / 2
+}
+
+// WithEstimator returns a new context with the given estimator.
+func WithEstimator(ctx context.Context, e *Estimator) context.Context {
+	return context.WithValue(ctx, estimatorCtxKey, e)
+}
+
+// EstimatorFromContext returns the estimator from the context. Nil is returned
+// if there is none, which is still a valid Estimator.
+func EstimatorFromContext(ctx context.Context) *Estimator {
+	e, _ := ctx.Value(estimatorCtxKey).(*Estimator)
+	return e
+}
+
+// WithReceived returns a new context with the time that a command was
+// received.
+func WithReceived(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, receivedCtxKey, t)
+}
+
+// Received returns the time that the current command was received. If the
+// context does not have one, the current time is returned.
+func Received(ctx context.Context) time.Time {
+	t, ok := ctx.Value(receivedCtxKey).(time.Time)
+	if !ok {
+		return time.Now()
+	}
+	return t
+}
+
+// SentAt estimates the time that the current command was sent by the client
+// using the received time and the connection's estimator.
+func SentAt(ctx context.Context) time.Time {
+	return Received(ctx).Add(-EstimatorFromContext(ctx).OneWay())
+}
@@ -6,12 +6,15 @@ import (
 	"net"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
 )
 
 // WebsocketTest is a test helper for Websockets.
@@ -22,6 +25,7 @@ type WebsocketTest struct {
 	closedq chan struct{}
 	expectq chan expectation
 	sendq   chan any
+	lastID  atomic.Uint64
 
 	ExpectTimeout time.Duration
 	LogReceived   func(json.RawMessage)
@@ -161,7 +165,7 @@ func (w *WebsocketTest) eventLoop(ctx context.Context) (err error) {
 				recv := recvq[j]
 
 				v, err := expect.unmarshalRecv(recv)
-				if err == nil {
+				if err == nil && expect.matches(v.Interface()) {
 					// Found! Dispatch and remove.
 					expect.res(v.Interface())
 					expectq = append(expectq[:i], expectq[i+1:]...)
@@ -186,8 +190,13 @@ func (w *WebsocketTest) Send(ctx context.Context, v any) error {
 }
 
 type expectation struct {
-	rtyp reflect.Type
-	resq chan any
+	rtyp  reflect.Type
+	match func(any) bool
+	resq  chan any
+}
+
+func (e expectation) matches(v any) bool {
+	return e.match == nil || e.match(v)
 }
 
 func (e expectation) expectType() reflect.Type {
@@ -222,15 +231,40 @@ func (e expectation) unmarshalRecv(b []byte) (reflect.Value, error) {
 // Expect expects a message on the websocket and a certain response given that
 // message.
 func Expect[T any](ctx context.Context, w *WebsocketTest) (*T, error) {
-	ctx, cancel := context.WithTimeout(ctx, w.ExpectTimeout)
-	defer cancel()
+	return ExpectFunc[T](ctx, w, nil)
+}
 
+// ExpectFunc is like Expect, but it only takes a message for which match
+// returns true. A nil match takes any message of the type.
+func ExpectFunc[T any](ctx context.Context, w *WebsocketTest, match func(T) bool) (*T, error) {
 	var zero T
 
 	expect := expectation{
 		rtyp: reflect.TypeOf(zero),
 		resq: make(chan any, 1),
 	}
+	if match != nil {
+		expect.match = func(v any) bool { return match(v.(T)) }
+	}
+
+	v, err := w.expect(ctx, expect)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case error:
+		return nil, v
+	case T:
+		return &v, nil
+	default:
+		return nil, errors.Errorf("unexpected type %T", v)
+	}
+}
+
+func (w *WebsocketTest) expect(ctx context.Context, expect expectation) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.ExpectTimeout)
+	defer cancel()
 
 	select {
 	case w.expectq <- expect:
@@ -243,17 +277,66 @@ func Expect[T any](ctx context.Context, w *WebsocketTest) (*T, error) {
 
 	select {
 	case val := <-expect.resq:
-		switch v := val.(type) {
-		case error:
-			return nil, v
-		case T:
-			return &v, nil
-		default:
-			return nil, errors.Errorf("unexpected type %T", v)
-		}
+		return val, nil
 	case <-w.closedq:
 		return nil, net.ErrClosed
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
+
+// CommandError is the error that the server replied to a command with.
+type CommandError struct {
+	Err qg.Error
+}
+
+// Error implements error.
+func (e *CommandError) Error() string {
+	return e.Err.Message
+}
+
+// commandReply is either an EventAck or an EventError.
+type commandReply struct {
+	Type  string    `json:"type"`
+	ID    *string   `json:"id"`
+	Error *qg.Error `json:"error"`
+}
+
+// Command sends the given command with a new ID and waits for the server to
+// acknowledge it. If the server replies with an EventError instead, the error
+// is returned as a *CommandError. Events that the command caused are left to
+// be expected.
+func (w *WebsocketTest) Command(ctx context.Context, cmd qg.ICommand) error {
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		return errors.Wrap(err, "marshaling command")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return errors.Wrap(err, "unmarshaling command")
+	}
+
+	id := strconv.FormatUint(w.lastID.Add(1), 10)
+	fields["id"], _ = json.Marshal(id)
+
+	if err := w.Send(ctx, fields); err != nil {
+		return err
+	}
+
+	reply, err := ExpectFunc(ctx, w, func(r commandReply) bool {
+		return r.ID != nil && *r.ID == id && (r.Type == "Ack" || r.Type == "Error")
+	})
+	if err != nil {
+		return errors.Wrap(err, "waiting for ack")
+	}
+
+	if reply.Type == "Error" {
+		if reply.Error == nil {
+			return errors.New("server replied with an empty error")
+		}
+		return &CommandError{*reply.Error}
+	}
+
+	return nil
+}
@@ -12,19 +12,27 @@ import (
 	"github.com/go-chi/chi/v5"
 	"oss.acmcsuf.com/qg/backend/qg"
 	"oss.acmcsuf.com/qg/backend/qg/games"
+	"oss.acmcsuf.com/qg/backend/qg/games/buzzer"
+	"oss.acmcsuf.com/qg/backend/qg/games/feud"
 	"oss.acmcsuf.com/qg/backend/qg/games/jeopardy"
+	"oss.acmcsuf.com/qg/backend/qg/games/poll"
+	"oss.acmcsuf.com/qg/backend/qg/games/quiz"
+	"oss.acmcsuf.com/qg/backend/qg/stores/filesystem"
 	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+	"oss.acmcsuf.com/qg/backend/qg/tournament"
 	"oss.acmcsuf.com/qg/backend/server"
 )
 
 var (
 	addr       = "localhost:8081"
 	sqlitePath = "/tmp/qg.sqlite"
+	mediaPath  = ""
 )
 
 func main() {
 	flag.StringVar(&addr, "addr", addr, "address to listen on")
 	flag.StringVar(&sqlitePath, "sqlite", sqlitePath, "path to SQLite database")
+	flag.StringVar(&mediaPath, "media", mediaPath, "directory to store media in instead of the SQLite database")
 	flag.Parse()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -36,7 +44,15 @@ func main() {
 	}
 	defer store.Close()
 
-	handler := newHandler(store)
+	var media qg.MediaStorer = store
+	if mediaPath != "" {
+		media, err = filesystem.NewMediaStore(mediaPath)
+		if err != nil {
+			log.Fatalln("failed to open media store:", err)
+		}
+	}
+
+	handler := newHandler(ctx, store, media)
 	defer handler.Close()
 
 	r := chi.NewRouter()
@@ -53,9 +69,19 @@ func main() {
 	}
 }
 
-func newHandler(store *sqlite.Store) server.HTTPHandlerCloser {
+func newHandler(ctx context.Context, store *sqlite.Store, media qg.MediaStorer) server.HTTPHandlerCloser {
 	gameManager := games.NewManager(store)
 	gameManager.AddGame(qg.GameTypeJeopardy, jeopardy.New(store))
+	gameManager.AddGame(qg.GameTypeFeud, feud.New(store))
+	gameManager.AddGame(qg.GameTypePoll, poll.New(store))
+	gameManager.AddGame(qg.GameTypeQuiz, quiz.New(store))
+	gameManager.AddGame(qg.GameTypeBuzzer, buzzer.New(store))
+
+	if err := gameManager.RestoreScheduledGames(ctx); err != nil {
+		log.Println("failed to restore scheduled games:", err)
+	}
+
+	tournamentManager := tournament.NewManager(ctx, gameManager, store)
 
-	return server.NewHandler(store, gameManager)
+	return server.NewHandler(store, media, gameManager, tournamentManager)
 }
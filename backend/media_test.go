@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestMedia(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	logo := []byte("\x89PNG\r\n\x1a\n")
+
+	upload := func(t *testing.T, contentType string, data []byte) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, "POST", srv.URL+"/media", bytes.NewReader(data))
+		must(t, err)
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err := srv.Client().Do(req)
+		must(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		return resp
+	}
+
+	var media qg.Media
+
+	t.Run("upload", func(t *testing.T) {
+		resp := upload(t, "image/png", logo)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body qg.ResponseUploadMedia
+		must(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, qg.Media{
+			ID:          qg.MediaIDFromData(logo),
+			ContentType: "image/png",
+			Size:        uint32(len(logo)),
+		}, body.Media)
+
+		media = body.Media
+
+		resp = upload(t, "text/html", []byte("<script></script>"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+		resp = upload(t, "audio/mpeg", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	get := func(t *testing.T, id qg.MediaID, header http.Header) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/media/"+id, nil)
+		must(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		resp, err := srv.Client().Do(req)
+		must(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("serve", func(t *testing.T) {
+		resp := get(t, media.ID, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "public, max-age=31536000, immutable", resp.Header.Get("Cache-Control"))
+
+		b, err := io.ReadAll(resp.Body)
+		must(t, err)
+		assert.Equal(t, logo, b)
+
+		etag := resp.Header.Get("ETag")
+		resp = get(t, media.ID, http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+		resp = get(t, qg.MediaIDFromData([]byte("nope")), nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		newGame := func(media ...qg.MediaID) qg.GameID {
+			content := make([]qg.QuestionContent, len(media))
+			for i, id := range media {
+				content[i] = qg.QuestionContent{Value: qg.QuestionContentImage{Media: id}}
+			}
+
+			r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game", qg.RequestNewGame{
+				AdminPassword: "admin",
+				Data: qg.GameData{Value: qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+					Categories: []qg.JeopardyCategory{{
+						Name: "Logos",
+						Questions: []qg.JeopardyQuestion{
+							{Question: "Name this logo.", Content: content},
+						},
+					}},
+				}}},
+			})
+			must(t, err)
+
+			return r.GameID
+		}
+
+		gameID := newGame(media.ID, media.ID)
+
+		_, err := hc.POST[qg.ResponsePreviewGameMedia](ctx, client, "/media/preview", qg.RequestPreviewGameMedia{
+			GameID:        gameID,
+			AdminPassword: "wrong",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+
+		preview, err := hc.POST[qg.ResponsePreviewGameMedia](ctx, client, "/media/preview", qg.RequestPreviewGameMedia{
+			GameID:        gameID,
+			AdminPassword: "admin",
+		})
+		must(t, err)
+		assert.Equal(t, []qg.Media{media}, preview.Media)
+
+		// The game uses media that was never uploaded.
+		gameID = newGame(media.ID, qg.MediaIDFromData([]byte("nope")))
+
+		_, err = hc.POST[qg.ResponsePreviewGameMedia](ctx, client, "/media/preview", qg.RequestPreviewGameMedia{
+			GameID:        gameID,
+			AdminPassword: "admin",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+var pollGameData = qg.PollGameData{
+	Questions: []qg.PollQuestion{
+		{
+			Question: "Next workshop?",
+			Choices:  []string{"Go", "Rust", "Zig"},
+		},
+		{
+			Question: "Which snacks?",
+			Choices:  []string{"Pizza", "Boba"},
+			Multiple: p(true),
+		},
+	},
+}
+
+func TestPollWebsocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataPoll{Data: pollGameData},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create new game:", err)
+	}
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	alice := startTestWebsocket(ctx, t, srv, "alice")
+	bob := startTestWebsocket(ctx, t, srv, "bob")
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	for name, ws := range map[string]*west.WebsocketTest{"Alice": alice, "Bob": bob} {
+		sendCommand(ctx, t, ws, qg.CommandJoinGame{
+			GameID:     r.GameID,
+			PlayerName: name,
+		})
+		expectEvent[qg.EventJoinedGame](ctx, t, ws)
+	}
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+
+	question := expectEvent[qg.EventPollBeginQuestion](ctx, t, alice)
+	assert.Equal(t, qg.EventPollBeginQuestion{
+		Index:    0,
+		Question: "Next workshop?",
+		Choices:  []string{"Go", "Rust", "Zig"},
+	}, question)
+	assert.Equal(t, question, expectEvent[qg.EventPollBeginQuestion](ctx, t, bob))
+
+	// expectResults expects the same live results on every connection.
+	expectResults := func(t *testing.T) qg.EventPollResults {
+		t.Helper()
+		results := expectEvent[qg.EventPollResults](ctx, t, admin)
+		assert.Equal(t, results, expectEvent[qg.EventPollResults](ctx, t, alice))
+		assert.Equal(t, results, expectEvent[qg.EventPollResults](ctx, t, bob))
+		return results
+	}
+
+	t.Run("single_choice", func(t *testing.T) {
+		err := expectCommandError(ctx, t, admin, qg.CommandPollVote{Choices: []int32{0}})
+		assert.Contains(t, err.Message, "admins cannot vote")
+
+		err = expectCommandError(ctx, t, bob, qg.CommandPollVote{Choices: []int32{0, 1}})
+		assert.Contains(t, err.Message, "only allows one choice")
+
+		sendCommand(ctx, t, alice, qg.CommandPollVote{Choices: []int32{0}})
+		results := expectResults(t)
+		assert.Equal(t, []int32{1, 0, 0}, results.Result.Votes)
+
+		sendCommand(ctx, t, bob, qg.CommandPollVote{Choices: []int32{1}})
+		results = expectResults(t)
+		assert.Equal(t, []int32{1, 1, 0}, results.Result.Votes)
+
+		// Voting again replaces the previous vote.
+		sendCommand(ctx, t, alice, qg.CommandPollVote{Choices: []int32{1}})
+		results = expectResults(t)
+		assert.Equal(t, qg.EventPollResults{
+			Index: 0,
+			Result: qg.PollResult{
+				Question: "Next workshop?",
+				Choices:  []string{"Go", "Rust", "Zig"},
+				Votes:    []int32{0, 2, 0},
+				Voters:   2,
+			},
+		}, results)
+	})
+
+	t.Run("multiple_choice", func(t *testing.T) {
+		err := expectCommandError(ctx, t, alice, qg.CommandPollNextQuestion{})
+		assert.Contains(t, err.Message, "only admins")
+
+		sendCommand(ctx, t, admin, qg.CommandPollNextQuestion{})
+		question := expectEvent[qg.EventPollBeginQuestion](ctx, t, bob)
+		assert.Equal(t, int32(1), question.Index)
+		assert.True(t, question.Multiple)
+
+		sendCommand(ctx, t, bob, qg.CommandPollVote{Choices: []int32{0, 1}})
+		results := expectResults(t)
+		assert.Equal(t, []int32{1, 1}, results.Result.Votes)
+		assert.Equal(t, int32(1), results.Result.Voters)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandPollNextQuestion{})
+		ended := expectEvent[qg.EventGameEnded](ctx, t, alice)
+		assert.Equal(t, qg.Leaderboard{}, ended.Leaderboard)
+
+		// Only admins may export the results.
+		_, err := hc.POST[qg.ResponseGetPollResults](ctx, client, "/game/poll/results", qg.RequestGetPollResults{
+			GameID:        r.GameID,
+			AdminPassword: "wrong",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+
+		r, err := hc.POST[qg.ResponseGetPollResults](ctx, client, "/game/poll/results", qg.RequestGetPollResults{
+			GameID:        r.GameID,
+			AdminPassword: "admin",
+		})
+		if err != nil {
+			t.Fatal("failed to get poll results:", err)
+		}
+
+		assert.Equal(t, []qg.PollResult{
+			{
+				Question: "Next workshop?",
+				Choices:  []string{"Go", "Rust", "Zig"},
+				Votes:    []int32{0, 2, 0},
+				Voters:   2,
+			},
+			{
+				Question: "Which snacks?",
+				Choices:  []string{"Pizza", "Boba"},
+				Votes:    []int32{1, 1},
+				Voters:   1,
+			},
+		}, r.Results)
+	})
+}
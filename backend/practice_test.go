@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestPractice(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	newGame := func(t *testing.T, data qg.IGameData) qg.GameID {
+		r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game", qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data:          qg.GameData{Value: data},
+		})
+		must(t, err)
+		return r.GameID
+	}
+
+	// practice starts practicing the given game as Alice.
+	practice := func(t *testing.T, id qg.GameID) *west.WebsocketTest {
+		ws := startTestWebsocket(ctx, t, srv, "alice")
+
+		sendCommand(ctx, t, ws, qg.CommandPracticeGame{
+			GameID:     id,
+			PlayerName: "Alice",
+		})
+		joined := expectEvent[qg.EventJoinedGame](ctx, t, ws)
+		assert.False(t, joined.IsAdmin)
+		assert.Zero(t, joined.GameData)
+
+		expectEvent[qg.EventPlayerJoined](ctx, t, ws)
+		expectEvent[qg.EventGameStarted](ctx, t, ws)
+
+		return ws
+	}
+
+	t.Run("jeopardy", func(t *testing.T) {
+		id := newGame(t, qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+			Categories: []qg.JeopardyCategory{{
+				Name: "Languages",
+				Questions: []qg.JeopardyQuestion{
+					{Question: "It has goroutines.", Answers: []string{"Go", "Golang"}},
+					{Question: "It has a borrow checker.", Answers: []string{"Rust"}},
+				},
+			}},
+		}})
+
+		alice := practice(t, id)
+
+		turn := expectEvent[qg.EventJeopardyTurnEnded](ctx, t, alice)
+		assert.Equal(t, "Alice", turn.Chooser)
+
+		// playQuestion plays a question and returns the judgment of the host.
+		playQuestion := func(t *testing.T, question int32, answer string) qg.EventJeopardyAnswerJudged {
+			sendCommand(ctx, t, alice, qg.CommandJeopardyChooseQuestion{Category: 0, Question: question})
+			expectEvent[qg.EventJeopardyBeginQuestion](ctx, t, alice)
+
+			// The host arms the buzzers right away.
+			expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, alice)
+
+			sendCommand(ctx, t, alice, qg.CommandJeopardyPressButton{})
+			pressed := expectEvent[qg.EventJeopardyButtonPressed](ctx, t, alice)
+			assert.Equal(t, "Alice", pressed.PlayerName)
+
+			sendCommand(ctx, t, alice, qg.CommandJeopardySubmitAnswer{Answer: answer})
+			return expectEvent[qg.EventJeopardyAnswerJudged](ctx, t, alice)
+		}
+
+		judged := playQuestion(t, 0, "What is Go?")
+		assert.Equal(t, qg.EventJeopardyAnswerJudged{
+			Answer:  "What is Go?",
+			Correct: true,
+			Answers: []string{"Go", "Golang"},
+		}, judged)
+
+		turn = expectEvent[qg.EventJeopardyTurnEnded](ctx, t, alice)
+		assert.Equal(t, qg.EventJeopardyTurnEnded{
+			Chooser:     "Alice",
+			Answered:    qg.JeopardyAnsweredQuestions{{Player: "Alice", Category: 0, Question: 0}},
+			Leaderboard: qg.Leaderboard{{PlayerName: "Alice", Score: 100}},
+		}, turn)
+
+		err := expectCommandError(ctx, t, alice, qg.CommandJeopardyChooseQuestion{Category: 0, Question: 0})
+		assert.Contains(t, err.Message, "already been answered")
+
+		// A missed question is used up, which ends the game.
+		judged = playQuestion(t, 1, "C++")
+		assert.False(t, judged.Correct)
+
+		ended := expectEvent[qg.EventGameEnded](ctx, t, alice)
+		assert.Equal(t, qg.Leaderboard{{PlayerName: "Alice", Score: 100}}, ended.Leaderboard)
+
+		result := expectEvent[qg.EventPracticeEnded](ctx, t, alice)
+		assert.Equal(t, qg.EventPracticeEnded{
+			Score:           100,
+			PersonalBest:    100,
+			NewPersonalBest: true,
+		}, result)
+	})
+
+	t.Run("jeopardy_self_judged", func(t *testing.T) {
+		// The questions of this set have no accepted answers.
+		id := newGame(t, qg.GameDataJeopardy{Data: jeopardyGameData})
+
+		alice := practice(t, id)
+		expectEvent[qg.EventJeopardyTurnEnded](ctx, t, alice)
+
+		sendCommand(ctx, t, alice, qg.CommandJeopardyChooseQuestion{Category: 0, Question: 0})
+		expectEvent[qg.EventJeopardyBuzzersArmed](ctx, t, alice)
+
+		sendCommand(ctx, t, alice, qg.CommandJeopardyPressButton{})
+		expectEvent[qg.EventJeopardyButtonPressed](ctx, t, alice)
+
+		// Alice may only judge what she has answered.
+		err := expectCommandError(ctx, t, alice, qg.CommandJeopardyPlayerJudgment{Correct: true})
+		assert.Equal(t, "only admins can judge players", err.Message)
+
+		sendCommand(ctx, t, alice, qg.CommandJeopardySubmitAnswer{Answer: "Lorem"})
+		assert.Equal(t,
+			qg.EventJeopardySelfJudge{Answer: "Lorem"},
+			expectEvent[qg.EventJeopardySelfJudge](ctx, t, alice))
+
+		sendCommand(ctx, t, alice, qg.CommandJeopardyPlayerJudgment{Correct: true})
+
+		// Nobody checked the answer, so it must not count towards her
+		// personal best.
+		turn := expectEvent[qg.EventJeopardyTurnEnded](ctx, t, alice)
+		assert.Equal(t, qg.EventJeopardyTurnEnded{
+			Chooser:     "Alice",
+			Answered:    qg.JeopardyAnsweredQuestions{{Player: "Alice", Category: 0, Question: 0}},
+			Leaderboard: qg.Leaderboard{{PlayerName: "Alice", Score: 0}},
+		}, turn)
+
+		// The judgment is over, so Alice cannot judge again.
+		err = expectCommandError(ctx, t, alice, qg.CommandJeopardyPlayerJudgment{Correct: true})
+		assert.Equal(t, p(qg.ErrorCodeInvalidState), err.Code)
+	})
+
+	t.Run("quiz", func(t *testing.T) {
+		id := newGame(t, qg.GameDataQuiz{Data: qg.QuizGameData{
+			Questions: []qg.QuizQuestion{
+				{Value: qg.QuizQuestionMultipleChoice{
+					Question: "Which language has goroutines?",
+					Choices:  []string{"Rust", "Go", "Zig"},
+					Answer:   1,
+				}},
+				{Value: qg.QuizQuestionTrueFalse{
+					Question: "Go has generics.",
+					Answer:   true,
+				}},
+			},
+		}})
+
+		// play plays the quiz with the given answers and returns the result.
+		play := func(t *testing.T, answers ...int32) qg.EventPracticeEnded {
+			alice := practice(t, id)
+
+			for i, choice := range answers {
+				question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, alice)
+				assert.Equal(t, int32(i), question.Index)
+
+				sendCommand(ctx, t, alice, qg.CommandQuizAnswer{
+					Answer: qg.QuizAnswer{Value: qg.QuizAnswerChoice{Choice: choice}},
+				})
+				expectEvent[qg.EventQuizPlayerAnswered](ctx, t, alice)
+				expectEvent[qg.EventQuizReveal](ctx, t, alice)
+
+				// Don't wait for the host.
+				sendCommand(ctx, t, alice, qg.CommandQuizNextQuestion{})
+			}
+
+			expectEvent[qg.EventGameEnded](ctx, t, alice)
+			return expectEvent[qg.EventPracticeEnded](ctx, t, alice)
+		}
+
+		assert.Equal(t, qg.EventPracticeEnded{
+			Score:           100,
+			PersonalBest:    100,
+			NewPersonalBest: true,
+		}, play(t, 1, 1))
+
+		assert.Equal(t, qg.EventPracticeEnded{
+			Score:           0,
+			PersonalBest:    100,
+			NewPersonalBest: false,
+		}, play(t, 0, 1))
+
+		assert.Equal(t, qg.EventPracticeEnded{
+			Score:           200,
+			PersonalBest:    200,
+			NewPersonalBest: true,
+		}, play(t, 1, 0))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, test := range []struct {
+			name string
+			data qg.IGameData
+			err  string
+		}{
+			{
+				name: "buzzer",
+				data: qg.GameDataBuzzer{Data: qg.BuzzerGameData{}},
+				err:  "cannot be practiced",
+			},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				id := newGame(t, test.data)
+
+				ws := startTestWebsocket(ctx, t, srv, "alice")
+				err := expectCommandError(ctx, t, ws, qg.CommandPracticeGame{
+					GameID:     id,
+					PlayerName: "Alice",
+				})
+				assert.Contains(t, err.Message, test.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package qg
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotFound is returned by stores when the thing that was asked for
+	// does not exist.
+	ErrNotFound = NewCodedError(ErrorCodeNotFound, "not found")
+	// ErrConflict is returned by stores when the thing that was created
+	// already exists.
+	ErrConflict = NewCodedError(ErrorCodeConflict, "already exists")
+)
+
+// CodedError is an error that carries an ErrorCode, which is sent to the
+// client along with the message. Over HTTP, the code also decides the status
+// of the response.
+type CodedError struct {
+	Code    ErrorCode
+	Err     error
+	Details []ErrorDetail
+}
+
+// NewCodedError returns a new error with the given code and message.
+func NewCodedError(code ErrorCode, message string) error {
+	return &CodedError{Code: code, Err: errors.New(message)}
+}
+
+// CodedErrorf is like NewCodedError, but it formats the message.
+func CodedErrorf(code ErrorCode, format string, args ...any) error {
+	return &CodedError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// WithErrorCode annotates err with the given code. It returns nil if err is
+// nil.
+func WithErrorCode(err error, code ErrorCode) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// Error implements error.
+func (e *CodedError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *CodedError) Unwrap() error { return e.Err }
+
+// HTTPStatus returns the HTTP status for the code of the error. It implements
+// hrt.HTTPError.
+func (e *CodedError) HTTPStatus() int {
+	switch e.Code {
+	case ErrorCodeInvalidRequest:
+		return http.StatusBadRequest
+	case ErrorCodeUnauthorized:
+		return http.StatusForbidden
+	case ErrorCodeNotFound:
+		return http.StatusNotFound
+	case ErrorCodeConflict, ErrorCodeInvalidState, ErrorCodeNotYourTurn:
+		return http.StatusConflict
+	case ErrorCodeSlowSubscriber:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorCodeOf returns the code of the first CodedError in the chain of err.
+func ErrorCodeOf(err error) (ErrorCode, bool) {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code, true
+	}
+	return "", false
+}
+
+// errorCodeForStatus returns the code that best describes an HTTP status.
+func errorCodeForStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrorCodeUnauthorized
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status == http.StatusConflict:
+		return ErrorCodeConflict
+	case status >= 400 && status < 500:
+		return ErrorCodeInvalidRequest
+	default:
+		return ErrorCodeInternal
+	}
+}
+
+// NewError returns the Error that describes err to the client. The code is
+// taken from the first CodedError in the chain of err. Failing that, it is
+// guessed from the HTTP status of err, if it has one. The details are taken
+// from the first CodedError that has any.
+func NewError(err error) Error {
+	e := Error{Message: err.Error()}
+
+	var coded *CodedError
+	var httpErr interface{ HTTPStatus() int }
+	switch {
+	case errors.As(err, &coded):
+		e.Code = &coded.Code
+	case errors.As(err, &httpErr):
+		code := errorCodeForStatus(httpErr.HTTPStatus())
+		e.Code = &code
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if coded, ok := err.(*CodedError); ok && len(coded.Details) > 0 {
+			e.Details = coded.Details
+			break
+		}
+	}
+
+	return e
+}
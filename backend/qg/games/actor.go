@@ -0,0 +1,105 @@
+package games
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// queue runs functions one at a time in the order that they were pushed. It
+// is the mailbox of a game's actor: the goroutine that runs the functions is
+// only started once something is pushed, and it exits once the queue is
+// empty, so games that are abandoned do not leak a goroutine.
+type queue struct {
+	mu      sync.Mutex
+	pending []func()
+	running bool
+}
+
+// push adds f to the queue. It never blocks.
+func (q *queue) push(f func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, f)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+func (q *queue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		f := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		f()
+	}
+}
+
+// do runs f in the machine's inbox and waits for it to return. Every input
+// into the state machine goes through here, so states and reactors never run
+// concurrently. If ctx expires before f gets to run, f is skipped and ctx's
+// error is returned. Once f is running, do waits for it to return, so the
+// error always tells whether f ran.
+func (m *Machine) do(ctx context.Context, f func() error) error {
+	done := make(chan error, 1)
+	var claimed atomic.Bool
+
+	m.inbox.push(func() {
+		if !claimed.CompareAndSwap(false, true) {
+			// do has given up on f already.
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			done <- err
+			return
+		}
+		done <- f()
+	})
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if claimed.CompareAndSwap(false, true) {
+			return ctx.Err()
+		}
+		// Too late, f is already running.
+		return <-done
+	}
+}
+
+// Publish publishes an event to all players. The event is handed to the
+// game's outbox and fanned out after the current input has been handled, so
+// slow subscribers never hold up the game.
+func (m *MachineState) Publish(ctx context.Context, ev qg.IEvent) {
+	m.outbox.push(func() {
+		// The input's context may be canceled by the time that the event is
+		// fanned out, which must not drop the event.
+		m.Publisher.Publish(context.Background(), ev)
+	})
+}
+
+// Publish publishes an event to the player only. It goes through the same
+// outbox as the game's own events, so that the player sees events in the
+// order that they were published.
+func (h *PlayerHandle) Publish(ctx context.Context, ev qg.IEvent) {
+	if h.outbox == nil {
+		h.Publisher.Publish(ctx, ev)
+		return
+	}
+	h.outbox.push(func() {
+		h.Publisher.Publish(context.Background(), ev)
+	})
+}
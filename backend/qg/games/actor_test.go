@@ -0,0 +1,330 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/internal/pubsub"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+type fakeGame struct{}
+
+func (fakeGame) ID() qg.GameID      { return "test" }
+func (fakeGame) Data() qg.IGameData { return qg.GameDataPoll{} }
+
+func (fakeGame) CompareGamePassword(ctx context.Context, password string) (bool, error) {
+	return password == "admin", nil
+}
+
+func (fakeGame) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	return cando.NextStates{cando.Next[cando.EndReaction]()}, nil
+}
+
+func (fakeGame) Leaderboard() qg.Leaderboard { return nil }
+func (fakeGame) Snapshot() qg.IGameSnapshot  { return nil }
+
+func startFakeGame(tb testing.TB) *Machine {
+	m, err := NewMachineState(context.Background()).StartMachine(context.Background(), fakeGame{})
+	if err != nil {
+		tb.Fatal("cannot start machine:", err)
+	}
+	return m
+}
+
+func joinFakeGame(tb testing.TB, m *Machine, name qg.PlayerName, isAdmin bool, evs chan qg.IEvent) qg.CommandHandler {
+	h, err := m.NewCommandHandler(context.Background(), evs)
+	if err != nil {
+		tb.Fatal("cannot create command handler:", err)
+	}
+
+	cmd := qg.CommandJoinGame{GameID: "test", PlayerName: name}
+	if isAdmin {
+		password := "admin"
+		cmd.AdminPassword = &password
+	}
+
+	if err := h.HandleCommand(context.Background(), cmd); err != nil {
+		tb.Fatal("cannot join game:", err)
+	}
+
+	return h
+}
+
+func TestActorEventOrder(t *testing.T) {
+	m := startFakeGame(t)
+
+	evs := make(chan qg.IEvent, 64)
+	admin := joinFakeGame(t, m, "Admin", true, evs)
+	defer admin.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := admin.HandleCommand(context.Background(), qg.CommandPauseGame{}); err != nil {
+			t.Fatal("cannot pause game:", err)
+		}
+		if err := admin.HandleCommand(context.Background(), qg.CommandResumeGame{}); err != nil {
+			t.Fatal("cannot resume game:", err)
+		}
+	}
+
+	// Events that are only for the player must not overtake or fall behind
+	// the events for everyone.
+	var got []string
+	timeout := time.After(time.Second)
+	for len(got) < 18 {
+		select {
+		case ev := <-evs:
+			got = append(got, fmt.Sprintf("%T", ev))
+		case <-timeout:
+			t.Fatalf("got %d events, want 18: %v", len(got), got)
+		}
+	}
+
+	want := []string{"qg.EventJoinedGame", "qg.EventPlayerJoined", "qg.EventGameSnapshot"}
+	for i := 0; i < 5; i++ {
+		want = append(want, "qg.EventGamePaused", "qg.EventGameResumed", "qg.EventGameSnapshot")
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got events %v, want %v", got, want)
+	}
+}
+
+func TestRejoinSlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := startFakeGame(t)
+
+	evs := make(chan qg.IEvent)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-evs:
+			}
+		}
+	}()
+
+	admin := joinFakeGame(t, m, "Admin", true, evs)
+	defer admin.Close()
+
+	disconnected := make(chan struct{})
+	slowEvs := make(chan qg.IEvent)
+	slowCtx := pubsub.WithSubscribeOpts(ctx, pubsub.SubscribeOpts{
+		Policy:       pubsub.DisconnectPolicy,
+		BufferSize:   8,
+		Timeout:      100 * time.Millisecond,
+		OnDisconnect: func(error) { close(disconnected) },
+	})
+
+	slow, err := m.NewCommandHandler(slowCtx, slowEvs)
+	if err != nil {
+		t.Fatal("cannot create command handler:", err)
+	}
+	if err := slow.HandleCommand(ctx, qg.CommandJoinGame{GameID: "test", PlayerName: "Slow"}); err != nil {
+		t.Fatal("cannot join game:", err)
+	}
+
+	// The player stops reading once they have joined. The admin joining may
+	// still be on its way to them.
+	var joined qg.EventJoinedGame
+	for joined.RejoinToken == "" {
+		switch ev := (<-slowEvs).(type) {
+		case qg.EventJoinedGame:
+			if ev.RejoinToken == "" {
+				t.Fatal("EventJoinedGame has no rejoin token")
+			}
+			joined = ev
+		case qg.EventError:
+			t.Fatal("player was disconnected while joining:", ev.Error.Message)
+		}
+	}
+
+	// Pausing and resuming publishes to everyone, so the player soon falls
+	// behind. The game must be left running.
+	deadline := time.Now().Add(time.Second)
+	for paused := false; paused || !isClosed(disconnected); paused = !paused {
+		if time.Now().After(deadline) {
+			t.Fatal("slow player was never disconnected")
+		}
+
+		var cmd qg.ICommand = qg.CommandPauseGame{}
+		if paused {
+			cmd = qg.CommandResumeGame{}
+		}
+		if err := admin.HandleCommand(ctx, cmd); err != nil {
+			t.Fatal("cannot pause or resume game:", err)
+		}
+	}
+	slow.Close()
+
+	// Nobody else may take the name.
+	thief, err := m.NewCommandHandler(ctx, make(chan qg.IEvent, 64))
+	if err != nil {
+		t.Fatal("cannot create command handler:", err)
+	}
+	defer thief.Close()
+
+	wrong := "wrong"
+	for _, token := range []*string{nil, &wrong} {
+		err := thief.HandleCommand(ctx, qg.CommandJoinGame{GameID: "test", PlayerName: "Slow", RejoinToken: token})
+		var coded *qg.CodedError
+		if !errors.As(err, &coded) || coded.Code != qg.ErrorCodeConflict {
+			t.Fatalf("expected a conflict error when rejoining with token %v, got %v", token, err)
+		}
+	}
+
+	rejoinEvs := make(chan qg.IEvent, 64)
+	rejoin, err := m.NewCommandHandler(ctx, rejoinEvs)
+	if err != nil {
+		t.Fatal("cannot create command handler:", err)
+	}
+	defer rejoin.Close()
+
+	cmd := qg.CommandJoinGame{GameID: "test", PlayerName: "Slow", RejoinToken: &joined.RejoinToken}
+	if err := rejoin.HandleCommand(ctx, cmd); err != nil {
+		t.Fatal("cannot rejoin game:", err)
+	}
+
+	var got []qg.IEvent
+	timeout := time.After(time.Second)
+	for len(got) < 3 {
+		select {
+		case ev := <-rejoinEvs:
+			got = append(got, ev)
+		case <-timeout:
+			t.Fatalf("got %d events, want 3: %v", len(got), got)
+		}
+	}
+
+	if _, ok := got[0].(qg.EventJoinedGame); !ok {
+		t.Fatalf("expected EventJoinedGame, got %#v", got[0])
+	}
+	if ev, ok := got[1].(qg.EventPlayerJoined); !ok || ev.PlayerName != "Admin" {
+		t.Fatalf("expected EventPlayerJoined for Admin, got %#v", got[1])
+	}
+	snapshot, ok := got[2].(qg.EventGameSnapshot)
+	if !ok {
+		t.Fatalf("expected EventGameSnapshot, got %#v", got[2])
+	}
+	if fmt.Sprint(snapshot.Players) != "[Slow]" {
+		t.Fatalf("expected the player to rejoin in their old place, got players %v", snapshot.Players)
+	}
+}
+
+func TestActorCanceledInput(t *testing.T) {
+	m := startFakeGame(t)
+
+	// Keep the actor busy so that the next input waits in the inbox.
+	release := make(chan struct{})
+	running := make(chan struct{})
+	go m.do(context.Background(), func() error {
+		close(running)
+		<-release
+		return nil
+	})
+	<-running
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	ran := make(chan struct{}, 1)
+	err := m.do(ctx, func() error {
+		ran <- struct{}{}
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The input was given up on, so it must never run.
+	close(release)
+	if err := m.do(context.Background(), func() error { return nil }); err != nil {
+		t.Fatal("cannot run input:", err)
+	}
+	select {
+	case <-ran:
+		t.Fatal("input ran after its context expired")
+	default:
+	}
+
+	// An input that is already running reports its own result.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	want := errors.New("done")
+	err = m.do(ctx, func() error {
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		return want
+	})
+	if err != want {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// BenchmarkCommandLatency measures how long a command takes in a game with 200
+// players, one of whom reads its events slowly. Every command publishes an
+// event to all players.
+func BenchmarkCommandLatency(b *testing.B) {
+	const players = 200
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := startFakeGame(b)
+
+	drain := func(evs <-chan qg.IEvent, delay time.Duration) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-evs:
+				time.Sleep(delay)
+			}
+		}
+	}
+
+	evs := make(chan qg.IEvent, 16)
+	go drain(evs, 0)
+
+	admin := joinFakeGame(b, m, "Admin", true, evs)
+	defer admin.Close()
+
+	for i := 1; i < players; i++ {
+		evs := make(chan qg.IEvent, 16)
+		if i == 1 {
+			go drain(evs, time.Millisecond)
+		} else {
+			go drain(evs, 0)
+		}
+		defer joinFakeGame(b, m, qg.PlayerName(fmt.Sprint("Player ", i)), false, evs).Close()
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cmd := qg.ICommand(qg.CommandPauseGame{})
+		if i%2 == 1 {
+			cmd = qg.CommandResumeGame{}
+		}
+		if err := admin.HandleCommand(ctx, cmd); err != nil {
+			b.Fatal("cannot send command:", err)
+		}
+	}
+}
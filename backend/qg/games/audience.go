@@ -0,0 +1,73 @@
+package games
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/pubsub"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// Audience is who gets an event that is published with
+// MachineState.PublishTo. A nil Audience is everyone.
+type Audience = pubsub.Filter
+
+const adminTopic pubsub.Topic = "admin"
+
+func nameTopic(name qg.PlayerName) pubsub.Topic { return pubsub.Topic("name:" + name) }
+
+// Admins is the audience of the admins of the game.
+func Admins() Audience { return pubsub.To(adminTopic) }
+
+// Player is the audience of the given player only. Unlike PlayerHandle.Publish,
+// it reaches the player from outside of their own commands, such as once a
+// practice game ends.
+func Player(name qg.PlayerName) Audience { return pubsub.To(nameTopic(name)) }
+
+// PublishTo publishes an event to the given audience only. Like Publish, the
+// event goes through the game's outbox.
+func (m *MachineState) PublishTo(ctx context.Context, audience Audience, ev qg.IEvent) {
+	m.outbox.push(func() {
+		m.Publisher.PublishTo(context.Background(), audience, ev)
+	})
+}
+
+// SetTeam puts a player into a team. A player is in at most one team.
+func (m *MachineState) SetTeam(name qg.PlayerName, team string) {
+	if p, ok := m.Players[name]; ok {
+		p.Team = team
+	}
+}
+
+// updateTopics puts the handle into the topics of its player. Handles that
+// have not joined are in no topic, so they only get events for everyone.
+func (h *PlayerHandle) updateTopics() {
+	if h.PlayerState == nil {
+		h.Publisher.SetTopics()
+		return
+	}
+
+	topics := []pubsub.Topic{nameTopic(h.Name)}
+	if h.IsAdmin {
+		topics = append(topics, adminTopic)
+	}
+
+	h.Publisher.SetTopics(topics...)
+}
+
+// guardAdminData keeps admin-only data from reaching a connection that is not
+// an admin's, no matter who the event was published to.
+func guardAdminData(topics []pubsub.Topic, ev qg.IEvent) error {
+	adminOnly, ok := ev.(qg.AdminOnlyEvent)
+	if !ok || !adminOnly.IsAdminOnly() {
+		return nil
+	}
+
+	for _, topic := range topics {
+		if topic == adminTopic {
+			return nil
+		}
+	}
+
+	return errors.Errorf("%T has admin-only data but was published to a non-admin", ev)
+}
@@ -0,0 +1,83 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestPublishTo(t *testing.T) {
+	ctx := context.Background()
+	m := startFakeGame(t)
+
+	conns := map[string]chan qg.IEvent{}
+	for _, name := range []string{"Admin", "Alice", "Bob", "Display"} {
+		conns[name] = make(chan qg.IEvent, 64)
+	}
+
+	defer joinFakeGame(t, m, "Admin", true, conns["Admin"]).Close()
+	defer joinFakeGame(t, m, "Alice", false, conns["Alice"]).Close()
+	defer joinFakeGame(t, m, "Bob", false, conns["Bob"]).Close()
+
+	display, err := m.NewCommandHandler(ctx, conns["Display"])
+	if err != nil {
+		t.Fatal("cannot create command handler:", err)
+	}
+	defer display.Close()
+
+	// Each audience gets an event with its own name, and everyone gets the
+	// last event so that we know when to stop reading.
+	audiences := []struct {
+		name     qg.PlayerName
+		audience Audience
+	}{
+		{"admins", Admins()},
+		{"bob", Player("Bob")},
+		{"everyone", nil},
+	}
+	for _, a := range audiences {
+		m.s.PublishTo(ctx, a.audience, qg.EventGamePaused{PlayerName: a.name})
+	}
+	m.s.PublishTo(ctx, Player("Alice"), qg.EventJoinedGame{GameData: &qg.GameData{}})
+	m.s.PublishTo(ctx, nil, qg.EventFeudAnswerKey{})
+	m.s.Publish(ctx, qg.EventGameEnded{})
+
+	want := map[string][]string{
+		"Admin":   {"admins", "everyone", "qg.EventFeudAnswerKey"},
+		"Alice":   {"everyone"},
+		"Bob":     {"bob", "everyone"},
+		"Display": {"everyone"},
+	}
+
+	for name, evs := range conns {
+		var got []string
+	read:
+		for {
+			select {
+			case ev := <-evs:
+				switch ev := ev.(type) {
+				case qg.EventGamePaused:
+					got = append(got, string(ev.PlayerName))
+				case qg.EventGameEnded:
+					break read
+				case qg.EventJoinedGame, qg.EventPlayerJoined, qg.EventGameSnapshot:
+					// Left over from joining, unless it has the game data.
+					if ev, ok := ev.(qg.EventJoinedGame); ok && ev.GameData != nil && name != "Admin" {
+						t.Errorf("%s got the game data", name)
+					}
+				default:
+					got = append(got, fmt.Sprintf("%T", ev))
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("%s timed out after getting %v", name, got)
+			}
+		}
+
+		if fmt.Sprint(got) != fmt.Sprint(want[name]) {
+			t.Errorf("%s got %v, want %v", name, got, want[name])
+		}
+	}
+}
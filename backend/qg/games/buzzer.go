@@ -0,0 +1,151 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/rtt"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// DefaultEarlyBuzzPenalty is the default lockout duration for players who
+// press their button before the buzzer is armed.
+const DefaultEarlyBuzzPenalty = 250 * time.Millisecond
+
+// ParseEarlyBuzzPenalty parses the early_buzz_penalty option of a game. The
+// default is returned if it is nil.
+func ParseEarlyBuzzPenalty(penalty *string) (time.Duration, error) {
+	if penalty == nil {
+		return DefaultEarlyBuzzPenalty, nil
+	}
+
+	d, err := time.ParseDuration(*penalty)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid early_buzz_penalty")
+	}
+	if d < 0 {
+		return 0, errors.New("early_buzz_penalty must not be negative")
+	}
+
+	return d, nil
+}
+
+// Buzz is a button press within a buzz round.
+type Buzz struct {
+	Player qg.PlayerName
+	// SentAt is the estimated time that the player pressed the button.
+	SentAt time.Time
+	// Latency is the estimated one-way latency that was compensated for.
+	Latency time.Duration
+}
+
+// BuzzerOptions configures a Buzzer.
+type BuzzerOptions struct {
+	// Penalty is how long players who press their button before the buzzer
+	// is armed are locked out.
+	Penalty time.Duration
+	// CompensateLatency orders buzzes by the time that players are estimated
+	// to have pressed their button instead of the time that the press
+	// arrived.
+	CompensateLatency bool
+	// MaxCompensation caps the latency that is compensated for each press.
+	// If it is 0, only rtt.MaxSample caps it.
+	MaxCompensation time.Duration
+}
+
+// Buzzer collects the button presses of players in rounds. A round begins
+// once the buzzer is armed, and players who press their button before that
+// are locked out for a short while. It is not safe for concurrent use, so it
+// should only be used from within the states of a machine.
+type Buzzer struct {
+	opts      BuzzerOptions
+	armed     bool
+	buzzes    []Buzz
+	lockedOut map[qg.PlayerName]time.Time
+}
+
+// NewBuzzer creates a new disarmed buzzer.
+func NewBuzzer(opts BuzzerOptions) *Buzzer {
+	return &Buzzer{
+		opts:      opts,
+		lockedOut: make(map[qg.PlayerName]time.Time),
+	}
+}
+
+// Options returns the options of the buzzer.
+func (b *Buzzer) Options() BuzzerOptions {
+	return b.opts
+}
+
+// Reset disarms the buzzer and forgets all button presses and lockouts, so
+// that a new round can begin.
+func (b *Buzzer) Reset() {
+	b.armed = false
+	b.buzzes = nil
+	b.lockedOut = make(map[qg.PlayerName]time.Time)
+}
+
+// Arm arms the buzzer, after which button presses count.
+func (b *Buzzer) Arm() {
+	b.armed = true
+}
+
+// Armed returns true if the buzzer is armed.
+func (b *Buzzer) Armed() bool {
+	return b.armed
+}
+
+// Press records a button press of the given player, which was received with
+// the given command context. If the buzzer isn't armed yet, the player is
+// locked out instead and early is true. An error is returned if the player is
+// locked out or already pressed their button in this round.
+func (b *Buzzer) Press(ctx context.Context, player qg.PlayerName) (early bool, err error) {
+	now := rtt.Received(ctx)
+
+	if !b.armed {
+		// Too early! Lock the player out.
+		b.lockedOut[player] = now.Add(b.opts.Penalty)
+		return true, nil
+	}
+
+	if until, ok := b.lockedOut[player]; ok && now.Before(until) {
+		return false, fmt.Errorf(
+			"you pressed too early and are locked out for another %v",
+			until.Sub(now).Round(time.Millisecond))
+	}
+
+	for _, buzz := range b.buzzes {
+		if buzz.Player == player {
+			return false, errors.New("you already pressed your button")
+		}
+	}
+
+	buzz := Buzz{Player: player, SentAt: now}
+	if b.opts.CompensateLatency {
+		buzz.Latency = rtt.EstimatorFromContext(ctx).OneWay()
+		if b.opts.MaxCompensation > 0 && buzz.Latency > b.opts.MaxCompensation {
+			buzz.Latency = b.opts.MaxCompensation
+		}
+		buzz.SentAt = now.Add(-buzz.Latency)
+	}
+
+	b.buzzes = append(b.buzzes, buzz)
+	return false, nil
+}
+
+// Buzzes returns the button presses of the current round in order, starting
+// with the earliest one. Presses that were sent at the same time stay in the
+// order that they arrived.
+func (b *Buzzer) Buzzes() []Buzz {
+	buzzes := make([]Buzz, len(b.buzzes))
+	copy(buzzes, b.buzzes)
+
+	sort.SliceStable(buzzes, func(i, j int) bool {
+		return buzzes[i].SentAt.Before(buzzes[j].SentAt)
+	})
+
+	return buzzes
+}
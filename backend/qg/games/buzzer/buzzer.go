@@ -0,0 +1,255 @@
+// Package buzzer implements a buzzer game, which is a fair buzzer and a
+// scoreboard for hosts who ask their own questions.
+package buzzer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// Game is in charge of creating and managing new buzzer games.
+type Game struct {
+	store qg.GameStorer
+}
+
+// New creates a new Game instance.
+func New(store qg.GameStorer) Game {
+	return Game{store}
+}
+
+// GameState is the current state of a buzzer game.
+type GameState struct {
+	// Round is the index of the current or last buzz round. It is -1 until
+	// the first round is opened.
+	Round int32
+	// Buzzer collects the button presses of the current round.
+	Buzzer *games.Buzzer
+	// Closed are the button presses of the round that was closed last.
+	Closed []games.Buzz
+	// Scores maps each player to their total score.
+	Scores map[qg.PlayerName]float32
+}
+
+type gameManager struct {
+	store   qg.GameStorer
+	state   *GameState
+	machine *games.MachineState
+
+	data qg.BuzzerGameData
+	id   qg.GameID
+}
+
+func newGameManager(store qg.GameStorer, id qg.GameID, data qg.BuzzerGameData, mstate *games.MachineState) *gameManager {
+	return &gameManager{
+		store: store,
+		state: &GameState{
+			Round:  -1,
+			Scores: make(map[qg.PlayerName]float32),
+		},
+		machine: mstate,
+		data:    data,
+		id:      id,
+	}
+}
+
+func (m *gameManager) ID() qg.GameID      { return m.id }
+func (m *gameManager) Data() qg.IGameData { return qg.GameDataBuzzer{Data: m.data} }
+
+func (m *gameManager) CompareGamePassword(ctx context.Context, input string) (bool, error) {
+	return m.store.CompareGamePassword(ctx, m.id, input)
+}
+
+// Snapshot implements games.GameManager.
+func (m *gameManager) Snapshot() qg.IGameSnapshot { return nil }
+
+func (m *gameManager) Leaderboard() qg.Leaderboard {
+	players := m.machine.Contestants()
+
+	leaderboard := make(qg.Leaderboard, len(players))
+	for i, name := range players {
+		leaderboard[i] = qg.LeaderboardEntry{
+			PlayerName: name,
+			Score:      m.state.Scores[name],
+		}
+	}
+
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		return leaderboard[i].Score > leaderboard[j].Score
+	})
+
+	return leaderboard
+}
+
+func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	return idleStates(), nil
+}
+
+// idleStates returns the next states while no round is open.
+func idleStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandBuzzerOpenRound](),
+		cando.Next[qg.CommandBuzzerPress](),
+		cando.Next[qg.CommandBuzzerAwardPoints](),
+		cando.Next[qg.CommandEndGame](),
+	}
+}
+
+// openStates returns the next states while a round is open.
+func openStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandBuzzerCloseRound](),
+		cando.Next[qg.CommandBuzzerPress](),
+		cando.Next[qg.CommandBuzzerAwardPoints](),
+		cando.Next[qg.CommandEndGame](),
+	}
+}
+
+// currentStates returns the next states that the game is already in.
+func (m *gameManager) currentStates() cando.NextStates {
+	if m.state.Buzzer.Armed() {
+		return openStates()
+	}
+	return idleStates()
+}
+
+func (m *gameManager) openRound(ctx context.Context, _ qg.CommandBuzzerOpenRound) (cando.NextStates, error) {
+	m.state.Round++
+	m.state.Buzzer.Arm()
+
+	return openStates(), nil
+}
+
+func (m *gameManager) closeRound(ctx context.Context, _ qg.CommandBuzzerCloseRound) (cando.NextStates, error) {
+	m.state.Closed = m.state.Buzzer.Buzzes()
+	// Presses after this are early presses for the next round.
+	m.state.Buzzer.Reset()
+
+	return idleStates(), nil
+}
+
+func (m *gameManager) press(ctx context.Context, _ qg.CommandBuzzerPress) (cando.NextStates, error) {
+	self := games.PlayerFromContext(ctx)
+	if _, err := m.state.Buzzer.Press(ctx, self.Name); err != nil {
+		return nil, err
+	}
+
+	return m.currentStates(), nil
+}
+
+func (m *gameManager) awardPoints(ctx context.Context, cmd qg.CommandBuzzerAwardPoints) (cando.NextStates, error) {
+	player, ok := m.machine.Players[cmd.PlayerName]
+	if !ok {
+		return nil, errors.Errorf("unknown player %q", cmd.PlayerName)
+	}
+	if player.IsAdmin {
+		return nil, errors.New("admins cannot get points")
+	}
+
+	m.state.Scores[cmd.PlayerName] += cmd.Points
+
+	return m.currentStates(), nil
+}
+
+func (m *gameManager) endGame(ctx context.Context, _ qg.CommandEndGame) (cando.NextStates, error) {
+	return nil, nil
+}
+
+// convertBuzzes converts button presses into their API representation.
+func convertBuzzes(presses []games.Buzz) []qg.BuzzerBuzz {
+	buzzes := make([]qg.BuzzerBuzz, len(presses))
+	for i, buzz := range presses {
+		buzzes[i] = qg.BuzzerBuzz{
+			PlayerName: buzz.Player,
+			Latency:    float32(buzz.Latency) / float32(time.Millisecond),
+		}
+	}
+	return buzzes
+}
+
+func parseBuzzerOptions(data qg.BuzzerGameData) (games.BuzzerOptions, error) {
+	penalty, err := games.ParseEarlyBuzzPenalty(data.EarlyBuzzPenalty)
+	if err != nil {
+		return games.BuzzerOptions{}, err
+	}
+
+	return games.BuzzerOptions{
+		Penalty:           penalty,
+		CompensateLatency: data.CompensateLatency != nil && *data.CompensateLatency,
+	}, nil
+}
+
+// CreateGame implements the games.GameCreator.
+func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error) {
+	buzzerData, ok := data.(qg.GameDataBuzzer)
+	if !ok {
+		return nil, errors.Errorf("invalid game data type: %T", data)
+	}
+
+	opts, err := parseBuzzerOptions(buzzerData.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid game data")
+	}
+
+	s := games.NewMachineState(ctx)
+	m := newGameManager(g.store, id, buzzerData.Data, s)
+	m.state.Buzzer = games.NewBuzzer(opts)
+
+	s.AddReactors(
+		cando.OnEnter[qg.CommandBuzzerOpenRound](func(ctx context.Context, _ qg.CommandBuzzerOpenRound) error {
+			s.Publish(ctx, qg.EventBuzzerRoundOpened{Round: m.state.Round})
+			return nil
+		}),
+		cando.React[qg.CommandBuzzerPress, qg.CommandBuzzerOpenRound](func(ctx context.Context, _ qg.CommandBuzzerPress) error {
+			// The button was pressed while no round was open.
+			self := games.PlayerFromContext(ctx)
+			s.Publish(ctx, qg.EventBuzzerLockedOut{
+				PlayerName: self.Name,
+				Penalty:    float32(opts.Penalty) / float32(time.Millisecond),
+			})
+			return nil
+		}),
+		cando.React[qg.CommandBuzzerPress, qg.CommandBuzzerCloseRound](func(ctx context.Context, _ qg.CommandBuzzerPress) error {
+			s.Publish(ctx, qg.EventBuzzerBuzzes{
+				Round:  m.state.Round,
+				Buzzes: convertBuzzes(m.state.Buzzer.Buzzes()),
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandBuzzerCloseRound](func(ctx context.Context, _ qg.CommandBuzzerCloseRound) error {
+			s.Publish(ctx, qg.EventBuzzerRoundClosed{
+				Round:  m.state.Round,
+				Buzzes: convertBuzzes(m.state.Closed),
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandBuzzerAwardPoints](func(ctx context.Context, cmd qg.CommandBuzzerAwardPoints) error {
+			s.Publish(ctx, qg.EventBuzzerPointsAwarded{
+				PlayerName:  cmd.PlayerName,
+				Points:      cmd.Points,
+				Leaderboard: m.Leaderboard(),
+			})
+			return nil
+		}),
+	)
+
+	s.AddState(
+		cando.Guarded(cando.State(m.openRound), games.OnlyAdmins("only admins can open a round")),
+		cando.Guarded(cando.State(m.closeRound), games.OnlyAdmins("only admins can close a round")),
+		cando.Guarded(cando.State(m.press), games.NoAdmins("admins cannot buzz in")),
+		cando.Guarded(cando.State(m.awardPoints), games.OnlyAdmins("only admins can award points")),
+		cando.Guarded(cando.State(m.endGame), games.OnlyAdmins("only admins can end the game")),
+	)
+
+	running, err := s.StartMachine(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return running, nil
+}
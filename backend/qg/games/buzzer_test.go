@@ -0,0 +1,72 @@
+package games
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/internal/rtt"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestBuzzerForgedPong(t *testing.T) {
+	start := time.Now()
+
+	// Both players have an honest 40ms round trip.
+	honest := rtt.NewEstimator()
+	cheater := rtt.NewEstimator()
+	for _, e := range []*rtt.Estimator{honest, cheater} {
+		payload := e.Ping(start)
+		if !e.Pong(payload, start.Add(40*time.Millisecond)) {
+			t.Fatal("honest pong was rejected")
+		}
+	}
+
+	// The cheater makes up a pong for a ping that was sent long ago.
+	forged := strconv.AppendInt(nil, start.Add(-time.Hour).UnixNano(), 10)
+	if cheater.Pong(forged, start.Add(50*time.Millisecond)) {
+		t.Fatal("unsolicited pong was accepted")
+	}
+
+	// They also sit on the pong of a real ping, and then send it twice.
+	sentAt := start.Add(time.Second)
+	payload := cheater.Ping(sentAt)
+	if !cheater.Pong(payload, sentAt.Add(time.Hour)) {
+		t.Fatal("late pong was rejected")
+	}
+	if cheater.Pong(payload, sentAt.Add(time.Hour)) {
+		t.Fatal("repeated pong was accepted")
+	}
+
+	if got := cheater.RTT(); got > 40*time.Millisecond+rtt.MaxSample/8 {
+		t.Fatalf("late pong was not capped, RTT is %v", got)
+	}
+
+	buzzer := NewBuzzer(BuzzerOptions{
+		CompensateLatency: true,
+		MaxCompensation:   30 * time.Millisecond,
+	})
+	buzzer.Arm()
+
+	press := func(player qg.PlayerName, e *rtt.Estimator, receivedAt time.Time) {
+		ctx := rtt.WithEstimator(context.Background(), e)
+		ctx = rtt.WithReceived(ctx, receivedAt)
+		if _, err := buzzer.Press(ctx, player); err != nil {
+			t.Fatalf("%s cannot press: %v", player, err)
+		}
+	}
+
+	// The honest player presses 15ms before the cheater does.
+	pressedAt := start.Add(2 * time.Hour)
+	press("honest", honest, pressedAt)
+	press("cheater", cheater, pressedAt.Add(15*time.Millisecond))
+
+	buzzes := buzzer.Buzzes()
+	if buzzes[0].Player != "honest" {
+		t.Fatalf("cheater won the buzz with %v of compensation", buzzes[0].Latency)
+	}
+	if buzzes[1].Latency > 30*time.Millisecond {
+		t.Fatalf("compensation was not capped: %v", buzzes[1].Latency)
+	}
+}
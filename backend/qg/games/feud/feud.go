@@ -0,0 +1,462 @@
+// Package feud implements a Family Feud game. Two teams play one round per
+// survey question: a face-off decides which team is in control, the team in
+// control guesses answers until it either clears the board or strikes out, and
+// the other team then gets one guess to steal the round.
+package feud
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// Game is in charge of creating and managing new Feud games.
+type Game struct {
+	store qg.GameStorer
+}
+
+// New creates a new Game instance.
+func New(store qg.GameStorer) Game {
+	return Game{store}
+}
+
+// Team is a team in a Feud game.
+type Team struct {
+	Name    string
+	Players []qg.PlayerName
+	Score   float32
+}
+
+const (
+	// faceOffPending is the face-off rank of a player who hasn't answered.
+	faceOffPending = -1
+	// faceOffMissed is the face-off rank of a player whose answer was not on
+	// the board. It ranks below every answer.
+	faceOffMissed = math.MaxInt
+)
+
+// GameState is the current state of a Feud game that's used within the Feud
+// state machine.
+type GameState struct {
+	Teams [2]Team
+	Round int32
+	Phase qg.FeudPhase
+	// Revealed marks the answers of the current question that are revealed.
+	Revealed []bool
+	// Bank is the total points of the revealed answers in this round.
+	Bank float32
+	// Strikes is the number of strikes of the team in control.
+	Strikes int
+	// Control is the team in control of the round. It is -1 during the
+	// face-off.
+	Control int
+	// Answering is the player who is expected to answer next.
+	Answering     qg.PlayerName
+	AnsweringTeam int
+	// FaceOff holds the face-off player of each team.
+	FaceOff [2]qg.PlayerName
+	// FaceOffRanks holds the rank of each face-off player's answer.
+	FaceOffRanks [2]int
+	// FirstBuzz is the team whose face-off player pressed their button
+	// first.
+	FirstBuzz int
+	// Turns is the number of answers that the team in control gave.
+	Turns int
+	// LastAnswer is the answer that was last revealed and LastAnswerTeam is
+	// the team that gave it.
+	LastAnswer     int32
+	LastAnswerTeam int
+	// StruckTeam is the team that got the last strike.
+	StruckTeam int
+	// Winner is the team that won the last round.
+	Winner int
+}
+
+type gameManager struct {
+	store   qg.GameStorer
+	state   *GameState
+	machine *games.MachineState
+
+	data qg.FeudGameData
+	id   qg.GameID
+}
+
+func newGameManager(store qg.GameStorer, id qg.GameID, data qg.FeudGameData, mstate *games.MachineState) *gameManager {
+	state := &GameState{Control: -1}
+	for i, name := range data.Teams() {
+		state.Teams[i].Name = name
+	}
+
+	return &gameManager{
+		store:   store,
+		state:   state,
+		machine: mstate,
+		data:    data,
+		id:      id,
+	}
+}
+
+func (m *gameManager) ID() qg.GameID      { return m.id }
+func (m *gameManager) Data() qg.IGameData { return qg.GameDataFeud{Data: m.data} }
+
+func (m *gameManager) CompareGamePassword(ctx context.Context, input string) (bool, error) {
+	return m.store.CompareGamePassword(ctx, m.id, input)
+}
+
+// Snapshot implements games.GameManager.
+func (m *gameManager) Snapshot() qg.IGameSnapshot { return nil }
+
+// Leaderboard ranks every player by the score of their team.
+func (m *gameManager) Leaderboard() qg.Leaderboard {
+	var leaderboard qg.Leaderboard
+	for _, team := range m.state.Teams {
+		for _, player := range team.Players {
+			leaderboard = append(leaderboard, qg.LeaderboardEntry{
+				PlayerName: player,
+				Score:      team.Score,
+			})
+		}
+	}
+
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		return leaderboard[i].Score > leaderboard[j].Score
+	})
+
+	return leaderboard
+}
+
+func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	// Split the players into the two teams in the order that they joined.
+	var teams [2][]qg.PlayerName
+	for i, player := range m.machine.Contestants() {
+		teams[i%2] = append(teams[i%2], player)
+	}
+
+	if len(teams[0]) == 0 || len(teams[1]) == 0 {
+		return nil, errors.New("a Feud game needs at least one player on each team")
+	}
+
+	for i := range m.state.Teams {
+		m.state.Teams[i].Players = teams[i]
+		for _, player := range teams[i] {
+			// Team names may repeat, so the team's index identifies it.
+			m.machine.SetTeam(player, strconv.Itoa(i))
+		}
+	}
+
+	return m.startRound(0), nil
+}
+
+func (m *gameManager) question() qg.FeudQuestion {
+	return m.data.Questions[m.state.Round]
+}
+
+func (m *gameManager) startRound(round int32) cando.NextStates {
+	m.state.Round = round
+	m.state.Phase = qg.FeudPhaseFaceOff
+	m.state.Revealed = make([]bool, len(m.question().Answers))
+	m.state.Bank = 0
+	m.state.Strikes = 0
+	m.state.Control = -1
+	m.state.Answering = ""
+	m.state.FaceOffRanks = [2]int{faceOffPending, faceOffPending}
+	m.state.Turns = 0
+
+	// Every team member takes their turn at the face-off.
+	for i, team := range m.state.Teams {
+		m.state.FaceOff[i] = team.Players[int(round)%len(team.Players)]
+	}
+
+	return cando.NextStates{
+		cando.Next[qg.CommandFeudPressButton](),
+	}
+}
+
+// judgingStates returns the next states of the game while it waits for an
+// admin to judge the answering player.
+func judgingStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandFeudRevealAnswer](),
+		cando.Next[qg.CommandFeudStrike](),
+	}
+}
+
+func (m *gameManager) pressButton(ctx context.Context, _ qg.CommandFeudPressButton) (cando.NextStates, error) {
+	self := games.PlayerFromContext(ctx)
+
+	team := -1
+	for i, player := range m.state.FaceOff {
+		if player == self.Name {
+			team = i
+		}
+	}
+	if team == -1 {
+		if self.Team == "" && !self.IsAdmin {
+			// The teams are picked once the game begins, so players who
+			// join later can only watch.
+			return nil, qg.NewCodedError(qg.ErrorCodeUnauthorized, "you joined after the teams were picked")
+		}
+		return nil, qg.NewCodedError(qg.ErrorCodeNotYourTurn, "only the face-off players can press their button")
+	}
+
+	m.state.FirstBuzz = team
+	m.state.Answering = self.Name
+	m.state.AnsweringTeam = team
+
+	return judgingStates(), nil
+}
+
+func (m *gameManager) revealAnswer(ctx context.Context, cmd qg.CommandFeudRevealAnswer) (cando.NextStates, error) {
+	answers := m.question().Answers
+	if cmd.Answer < 0 || int(cmd.Answer) >= len(answers) {
+		return nil, fmt.Errorf("invalid answer index: %d", cmd.Answer)
+	}
+	if m.state.Revealed[cmd.Answer] {
+		return nil, fmt.Errorf("answer %d is already revealed", cmd.Answer)
+	}
+
+	m.state.Revealed[cmd.Answer] = true
+	m.state.Bank += answers[cmd.Answer].Points
+	m.state.LastAnswer = cmd.Answer
+	m.state.LastAnswerTeam = m.state.AnsweringTeam
+
+	switch m.state.Phase {
+	case qg.FeudPhaseFaceOff:
+		m.state.FaceOffRanks[m.state.AnsweringTeam] = int(cmd.Answer)
+		return m.afterFaceOffAnswer(), nil
+	case qg.FeudPhasePlay:
+		if m.allRevealed() {
+			return m.endRound(m.state.Control), nil
+		}
+		return m.nextPlayer(), nil
+	default: // qg.FeudPhaseSteal
+		return m.endRound(m.state.AnsweringTeam), nil
+	}
+}
+
+func (m *gameManager) strike(ctx context.Context, _ qg.CommandFeudStrike) (cando.NextStates, error) {
+	m.state.StruckTeam = m.state.AnsweringTeam
+
+	switch m.state.Phase {
+	case qg.FeudPhaseFaceOff:
+		m.state.FaceOffRanks[m.state.AnsweringTeam] = faceOffMissed
+		return m.afterFaceOffAnswer(), nil
+	case qg.FeudPhasePlay:
+		m.state.Strikes++
+		if m.state.Strikes < m.data.Strikes() {
+			return m.nextPlayer(), nil
+		}
+
+		// Struck out, so the other team gets to steal. Their face-off
+		// player answers for them.
+		other := 1 - m.state.Control
+		m.state.Phase = qg.FeudPhaseSteal
+		m.state.Answering = m.state.FaceOff[other]
+		m.state.AnsweringTeam = other
+		return judgingStates(), nil
+	default: // qg.FeudPhaseSteal
+		return m.endRound(m.state.Control), nil
+	}
+}
+
+// afterFaceOffAnswer decides what happens after a face-off player's answer
+// was judged.
+func (m *gameManager) afterFaceOffAnswer() cando.NextStates {
+	team := m.state.AnsweringTeam
+	other := 1 - team
+
+	if m.state.FaceOffRanks[team] == 0 {
+		// The top answer always wins the face-off.
+		return m.takeControl(team)
+	}
+
+	if m.state.FaceOffRanks[other] == faceOffPending {
+		// Let the other face-off player try to beat this answer.
+		m.state.Answering = m.state.FaceOff[other]
+		m.state.AnsweringTeam = other
+		return judgingStates()
+	}
+
+	// Both have answered, so the better answer wins. If both missed, the
+	// team that buzzed in first wins.
+	winner := m.state.FirstBuzz
+	if m.state.FaceOffRanks[1-winner] < m.state.FaceOffRanks[winner] {
+		winner = 1 - winner
+	}
+
+	return m.takeControl(winner)
+}
+
+func (m *gameManager) takeControl(team int) cando.NextStates {
+	m.state.Control = team
+	m.state.Phase = qg.FeudPhasePlay
+
+	if m.allRevealed() {
+		return m.endRound(team)
+	}
+
+	return m.nextPlayer()
+}
+
+// nextPlayer hands the turn to the next player of the team in control, going
+// around the team starting after its face-off player.
+func (m *gameManager) nextPlayer() cando.NextStates {
+	players := m.state.Teams[m.state.Control].Players
+
+	m.state.Answering = players[(int(m.state.Round)+1+m.state.Turns)%len(players)]
+	m.state.AnsweringTeam = m.state.Control
+	m.state.Turns++
+
+	return judgingStates()
+}
+
+func (m *gameManager) allRevealed() bool {
+	for _, revealed := range m.state.Revealed {
+		if !revealed {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *gameManager) endRound(winner int) cando.NextStates {
+	m.state.Winner = winner
+	m.state.Teams[winner].Score += m.state.Bank
+
+	return cando.NextStates{
+		cando.Next[qg.CommandFeudNextRound](),
+	}
+}
+
+func (m *gameManager) nextRound(ctx context.Context, _ qg.CommandFeudNextRound) (cando.NextStates, error) {
+	if int(m.state.Round)+1 >= len(m.data.Questions) {
+		// That was the last question, so end the game.
+		return nil, nil
+	}
+
+	return m.startRound(m.state.Round + 1), nil
+}
+
+func (m *gameManager) teams() []qg.FeudTeam {
+	teams := make([]qg.FeudTeam, len(m.state.Teams))
+	for i, team := range m.state.Teams {
+		teams[i] = qg.FeudTeam{
+			Name:    team.Name,
+			Players: team.Players,
+			Score:   team.Score,
+		}
+	}
+	return teams
+}
+
+func validateData(data qg.FeudGameData) error {
+	if len(data.Questions) == 0 {
+		return errors.New("no questions found, must have at least one")
+	}
+
+	for i, q := range data.Questions {
+		if len(q.Answers) == 0 {
+			return fmt.Errorf("question %d has no answers, must have at least one", i+1)
+		}
+	}
+
+	if data.TeamNames != nil && len(data.TeamNames) != 2 {
+		return fmt.Errorf("got %d team names, expected 2", len(data.TeamNames))
+	}
+
+	if data.MaxStrikes != nil && *data.MaxStrikes == 0 {
+		return errors.New("max_strikes must be at least 1")
+	}
+
+	return nil
+}
+
+// CreateGame implements the games.GameCreator.
+func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error) {
+	feudData, ok := data.(qg.GameDataFeud)
+	if !ok {
+		return nil, errors.Errorf("invalid game data type: %T", data)
+	}
+
+	if err := validateData(feudData.Data); err != nil {
+		return nil, errors.Wrap(err, "invalid game data")
+	}
+
+	s := games.NewMachineState(ctx)
+	m := newGameManager(g.store, id, feudData.Data, s)
+
+	s.AddReactors(
+		cando.OnEnter[qg.CommandFeudPressButton](func(ctx context.Context, _ qg.CommandFeudPressButton) error {
+			s.Publish(ctx, qg.EventFeudButtonPressed{
+				PlayerName: m.state.Answering,
+				Team:       int32(m.state.AnsweringTeam),
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandFeudRevealAnswer](func(ctx context.Context, _ qg.CommandFeudRevealAnswer) error {
+			answer := m.question().Answers[m.state.LastAnswer]
+			s.Publish(ctx, qg.EventFeudAnswerRevealed{
+				Answer: m.state.LastAnswer,
+				Text:   answer.Answer,
+				Points: answer.Points,
+				Team:   int32(m.state.LastAnswerTeam),
+				Bank:   m.state.Bank,
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandFeudStrike](func(ctx context.Context, _ qg.CommandFeudStrike) error {
+			s.Publish(ctx, qg.EventFeudStrike{
+				Team:    int32(m.state.StruckTeam),
+				Strikes: int32(m.state.Strikes),
+			})
+			return nil
+		}),
+		cando.React[any, qg.CommandFeudPressButton](func(ctx context.Context, _ any) error {
+			s.Publish(ctx, qg.EventFeudBeginRound{
+				Round:      m.state.Round,
+				Question:   m.question().Question,
+				NumAnswers: int32(len(m.question().Answers)),
+				FaceOff:    append([]qg.PlayerName(nil), m.state.FaceOff[:]...),
+				Teams:      m.teams(),
+			})
+			s.PublishTo(ctx, games.Admins(), qg.EventFeudAnswerKey{
+				Round:   m.state.Round,
+				Answers: m.question().Answers,
+			})
+			return nil
+		}),
+		cando.React[any, qg.CommandFeudRevealAnswer](func(ctx context.Context, _ any) error {
+			s.Publish(ctx, qg.EventFeudTurn{
+				Phase:      m.state.Phase,
+				Team:       int32(m.state.AnsweringTeam),
+				PlayerName: m.state.Answering,
+			})
+			return nil
+		}),
+		cando.React[any, qg.CommandFeudNextRound](func(ctx context.Context, _ any) error {
+			s.Publish(ctx, qg.EventFeudRoundEnded{
+				Winner:  int32(m.state.Winner),
+				Points:  m.state.Bank,
+				Answers: m.question().Answers,
+				Teams:   m.teams(),
+			})
+			return nil
+		}),
+	)
+
+	s.AddState(
+		cando.Guarded(cando.State(m.pressButton), games.Joined),
+		cando.Guarded(cando.State(m.revealAnswer), games.OnlyAdmins("only admins can reveal answers")),
+		cando.Guarded(cando.State(m.strike), games.OnlyAdmins("only admins can give strikes")),
+		cando.Guarded(cando.State(m.nextRound), games.OnlyAdmins("only admins can begin the next round")),
+	)
+
+	return s.StartMachine(ctx, m)
+}
@@ -3,9 +3,12 @@ package games
 import (
 	"context"
 	"fmt"
+	"log"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
 	"oss.acmcsuf.com/qg/backend/qg"
 )
 
@@ -15,11 +18,16 @@ type GameCreator interface {
 	CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error)
 }
 
+// ScheduleExpiry is how long a scheduled game is kept after its lobby opens.
+// Older scheduled games are not restored on startup.
+const ScheduleExpiry = 24 * time.Hour
+
 // Manager manages games and the creation of new games.
 type Manager struct {
 	gamesMut     sync.RWMutex
 	gameCreators map[qg.GameType]GameCreator
 	games        map[qg.GameID]qg.CommandHandlerFactory
+	opensAt      map[qg.GameID]time.Time
 	store        qg.GameStorer
 }
 
@@ -30,6 +38,7 @@ func NewManager(store qg.GameStorer) *Manager {
 	return &Manager{
 		gameCreators: make(map[qg.GameType]GameCreator),
 		games:        make(map[qg.GameID]qg.CommandHandlerFactory),
+		opensAt:      make(map[qg.GameID]time.Time),
 		store:        store,
 	}
 }
@@ -50,13 +59,41 @@ func (g *Manager) AddGame(t qg.GameType, game GameCreator) {
 // CreateGame creates a new game. The game will be created in the database and
 // the game ID will be returned.
 func (g *Manager) CreateGame(ctx context.Context, data qg.IGameData) (qg.GameID, error) {
-	gameType := qg.GameTypeFromData(data)
+	gameCreator, err := g.gameCreator(data)
+	if err != nil {
+		return "", err
+	}
 
-	g.gamesMut.RLock()
-	gameCreator, ok := g.gameCreators[gameType]
-	g.gamesMut.RUnlock()
+	id, err := g.store.CreateGame(ctx, data)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot create game")
+	}
+
+	if err := g.addGame(ctx, gameCreator, id, data, nil, false); err != nil {
+		// The game refused its own data.
+		return "", qg.WithErrorCode(errors.Wrap(err, "cannot create game"), qg.ErrorCodeInvalidRequest)
+	}
+
+	return id, nil
+}
+
+// ScheduleGame creates a new game ahead of time. The game ID is returned right
+// away so that it can be shared, but only admins can join the game until its
+// lobby opens. The schedule is saved in the store, so it survives restarts as
+// long as RestoreScheduledGames is called on startup.
+func (g *Manager) ScheduleGame(ctx context.Context, data qg.IGameData, schedule qg.GameSchedule) (qg.GameID, error) {
+	scheduleStore, ok := g.store.(qg.GameScheduleStorer)
 	if !ok {
-		return "", fmt.Errorf("unknown game type %q", gameType)
+		return "", errors.New("store does not support scheduled games")
+	}
+
+	if schedule.AutoBegin != nil && *schedule.AutoBegin == 0 {
+		return "", qg.NewCodedError(qg.ErrorCodeInvalidRequest, "autoBegin must be at least 1")
+	}
+
+	gameCreator, err := g.gameCreator(data)
+	if err != nil {
+		return "", err
 	}
 
 	id, err := g.store.CreateGame(ctx, data)
@@ -64,17 +101,152 @@ func (g *Manager) CreateGame(ctx context.Context, data qg.IGameData) (qg.GameID,
 		return "", errors.Wrap(err, "cannot create game")
 	}
 
+	// Save the schedule before the game goes live, so that every live game
+	// comes back after a restart.
+	if err := scheduleStore.ScheduleGame(ctx, id, schedule); err != nil {
+		return "", errors.Wrap(err, "cannot save game schedule")
+	}
+
+	if err := g.addGame(ctx, gameCreator, id, data, &schedule, false); err != nil {
+		// Don't try to restore a game that never went live.
+		if err := scheduleStore.UnscheduleGame(ctx, id); err != nil {
+			log.Printf("cannot unschedule game %q: %v", id, err)
+		}
+		return "", qg.WithErrorCode(errors.Wrap(err, "cannot create game"), qg.ErrorCodeInvalidRequest)
+	}
+
+	return id, nil
+}
+
+// RestoreScheduledGames restores the scheduled games from the store. It should
+// be called once on startup. Games that were scheduled more than
+// ScheduleExpiry ago are removed instead. Restored games start over with an
+// empty lobby, even if they had begun before the restart. Games whose lobby
+// had already opened are paused until an admin resumes them, since players
+// may have been in the middle of them. Games whose lobby has yet to open are
+// left as they were scheduled, so that they still begin on their own.
+func (g *Manager) RestoreScheduledGames(ctx context.Context) error {
+	scheduleStore, ok := g.store.(qg.GameScheduleStorer)
+	if !ok {
+		return nil
+	}
+
+	scheduled, err := scheduleStore.ScheduledGames(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot get scheduled games")
+	}
+
+	for _, game := range scheduled {
+		if time.Since(game.Schedule.OpensAt) > ScheduleExpiry {
+			if err := scheduleStore.UnscheduleGame(ctx, game.ID); err != nil {
+				return errors.Wrapf(err, "cannot remove expired game %q", game.ID)
+			}
+			continue
+		}
+
+		gameCreator, err := g.gameCreator(game.Data)
+		if err == nil {
+			schedule := game.Schedule
+			opened := !time.Now().Before(schedule.OpensAt)
+			err = g.addGame(ctx, gameCreator, game.ID, game.Data, &schedule, opened)
+		}
+		if err != nil {
+			// Don't let one broken game keep every other one from being
+			// restored.
+			log.Printf("cannot restore scheduled game %q: %v", game.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *Manager) gameCreator(data qg.IGameData) (GameCreator, error) {
+	gameType := qg.GameTypeFromData(data)
+
+	g.gamesMut.RLock()
+	gameCreator, ok := g.gameCreators[gameType]
+	g.gamesMut.RUnlock()
+	if !ok {
+		return nil, qg.CodedErrorf(qg.ErrorCodeInvalidRequest, "unknown game type %q", gameType)
+	}
+
+	return gameCreator, nil
+}
+
+// addGame creates the game handler for a game that's already in the store.
+// The schedule is optional. If paused is true, the game is paused before
+// anyone can join it.
+func (g *Manager) addGame(ctx context.Context, gameCreator GameCreator, id qg.GameID, data qg.IGameData, schedule *qg.GameSchedule, paused bool) error {
 	// TODO: use singleflight instead of write mutex
 	g.gamesMut.Lock()
 	defer g.gamesMut.Unlock()
 
 	game, err := gameCreator.CreateGame(ctx, id, data)
 	if err != nil {
-		return "", errors.Wrap(err, "cannot create game")
+		return err
+	}
+
+	if schedule != nil {
+		if schedule.AutoBegin != nil {
+			autoBeginner, ok := game.(AutoBeginner)
+			if !ok {
+				return fmt.Errorf("game type %q cannot begin on its own", qg.GameTypeFromData(data))
+			}
+			autoBeginner.AutoBeginAt(int(*schedule.AutoBegin))
+		}
+
+		g.opensAt[id] = schedule.OpensAt
+	}
+
+	if paused {
+		pauser, ok := game.(Pauser)
+		if !ok {
+			return fmt.Errorf("game type %q cannot be paused", qg.GameTypeFromData(data))
+		}
+		pauser.Pause()
 	}
 
 	g.games[id] = game
-	return id, nil
+	return nil
+}
+
+// WatchGame subscribes evs to the events of a running game without joining
+// it. Watching stops once stop is called.
+func (g *Manager) WatchGame(ctx context.Context, id qg.GameID, evs chan<- qg.IEvent) (stop func() error, err error) {
+	g.gamesMut.RLock()
+	game, ok := g.games[id]
+	g.gamesMut.RUnlock()
+
+	if !ok {
+		return nil, qg.CodedErrorf(qg.ErrorCodeNotFound, "unknown game with ID %q", id)
+	}
+
+	// A command handler is subscribed to the game's events right away, but it
+	// is only a player once it joins.
+	h, err := game.NewCommandHandler(ctx, evs)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot watch game")
+	}
+
+	return h.Close, nil
+}
+
+// InspectGame returns a snapshot of the state machine of a running game.
+func (g *Manager) InspectGame(ctx context.Context, id qg.GameID) (cando.Inspection, error) {
+	g.gamesMut.RLock()
+	game, ok := g.games[id]
+	g.gamesMut.RUnlock()
+
+	if !ok {
+		return cando.Inspection{}, qg.CodedErrorf(qg.ErrorCodeNotFound, "unknown game with ID %q", id)
+	}
+
+	inspector, ok := game.(Inspector)
+	if !ok {
+		return cando.Inspection{}, qg.CodedErrorf(qg.ErrorCodeNotFound, "game with ID %q cannot be inspected", id)
+	}
+
+	return inspector.Inspect(ctx)
 }
 
 // NewCommandHandler creates a new command handler.
@@ -97,10 +269,17 @@ func (h *gameHandler) HandleCommand(ctx context.Context, cmd qg.ICommand) (err e
 	case qg.CommandJoinGame:
 		h.gm.gamesMut.RLock()
 		game, ok := h.gm.games[data.GameID]
+		opensAt := h.gm.opensAt[data.GameID]
 		h.gm.gamesMut.RUnlock()
 
 		if !ok {
-			return fmt.Errorf("unknown game with ID %q", data.GameID)
+			return qg.CodedErrorf(qg.ErrorCodeNotFound, "unknown game with ID %q", data.GameID)
+		}
+
+		// Admins may join early to get ready. Their password is checked
+		// by the game itself.
+		if data.AdminPassword == nil && time.Now().Before(opensAt) {
+			return qg.CodedErrorf(qg.ErrorCodeInvalidState, "the lobby opens at %s", opensAt.UTC().Format(time.RFC3339))
 		}
 
 		h.gg, err = game.NewCommandHandler(ctx, h.evs)
@@ -109,8 +288,23 @@ func (h *gameHandler) HandleCommand(ctx context.Context, cmd qg.ICommand) (err e
 		}
 
 		return h.gg.HandleCommand(ctx, cmd)
+	case qg.CommandPracticeGame:
+		game, err := h.gm.practiceGame(ctx, data.GameID)
+		if err != nil {
+			return err
+		}
+
+		h.gg, err = game.NewCommandHandler(ctx, h.evs)
+		if err != nil {
+			return errors.Wrap(err, "cannot create command handler")
+		}
+
+		return h.gg.HandleCommand(ctx, qg.CommandJoinGame{
+			GameID:     data.GameID,
+			PlayerName: data.PlayerName,
+		})
 	default:
-		return errors.New("expect join game command")
+		return qg.NewCodedError(qg.ErrorCodeInvalidState, "expect join game or practice game command")
 	}
 }
 
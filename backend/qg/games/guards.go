@@ -0,0 +1,44 @@
+package games
+
+import (
+	"context"
+
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// OnlyAdmins returns a guard that only lets admins through. Everyone else gets
+// an unauthorized error with the given message.
+func OnlyAdmins(message string) cando.Guard {
+	return func(ctx context.Context) error {
+		self := PlayerFromContext(ctx)
+		if self.PlayerState == nil || !self.IsAdmin {
+			return qg.NewCodedError(qg.ErrorCodeUnauthorized, message)
+		}
+		return nil
+	}
+}
+
+// NoAdmins returns a guard that only lets players who are not admins through.
+// Admins get an unauthorized error with the given message.
+func NoAdmins(message string) cando.Guard {
+	return func(ctx context.Context) error {
+		if err := Joined(ctx); err != nil {
+			return err
+		}
+		if PlayerFromContext(ctx).IsAdmin {
+			return qg.NewCodedError(qg.ErrorCodeUnauthorized, message)
+		}
+		return nil
+	}
+}
+
+// Joined is a guard that only lets players who have joined the game through.
+// Connections that have yet to join, or that failed to, get an invalid state
+// error.
+func Joined(ctx context.Context) error {
+	if PlayerFromContext(ctx).PlayerState == nil {
+		return qg.NewCodedError(qg.ErrorCodeInvalidState, "you have not joined the game")
+	}
+	return nil
+}
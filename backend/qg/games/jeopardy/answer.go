@@ -0,0 +1,49 @@
+package jeopardy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// questionWords and linkingVerbs make up the "what is" that Jeopardy answers
+// are traditionally phrased with.
+var (
+	questionWords = map[string]bool{"what": true, "who": true, "where": true, "when": true}
+	linkingVerbs  = map[string]bool{"is": true, "are": true, "was": true, "were": true}
+	articles      = map[string]bool{"a": true, "an": true, "the": true}
+)
+
+// normalizeAnswer normalizes an answer for comparison. Case and punctuation
+// are ignored, as are a leading "what is" or "who is" and any leading
+// article.
+func normalizeAnswer(answer string) string {
+	words := strings.FieldsFunc(strings.ToLower(answer), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	if len(words) > 2 && questionWords[words[0]] && linkingVerbs[words[1]] {
+		words = words[2:]
+	}
+
+	if len(words) > 1 && articles[words[0]] {
+		words = words[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// matchAnswer returns true if the answer matches any of the accepted answers.
+func matchAnswer(accepted []string, answer string) bool {
+	answer = normalizeAnswer(answer)
+	if answer == "" {
+		return false
+	}
+
+	for _, a := range accepted {
+		if normalizeAnswer(a) == answer {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package jeopardy
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestMatchAnswer(t *testing.T) {
+	tests := []struct {
+		accepted []string
+		answer   string
+		expect   bool
+	}{
+		{[]string{"Go"}, "go", true},
+		{[]string{"Go"}, "  GO! ", true},
+		{[]string{"Go"}, "What is Go?", true},
+		{[]string{"Go"}, "what is the Go", true},
+		{[]string{"The Beatles"}, "Who are the Beatles?", true},
+		{[]string{"The Beatles"}, "beatles", true},
+		{[]string{"Ken Thompson", "Thompson"}, "who is thompson", true},
+		{[]string{"Rust"}, "What is Go?", false},
+		{[]string{"Go"}, "", false},
+		{[]string{"Go"}, "?!", false},
+		// A lone "what is" is not a question phrase.
+		{[]string{"What is"}, "what is", true},
+		{[]string{"A"}, "a", true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expect, matchAnswer(test.accepted, test.answer),
+			"matching %q against %q", test.answer, test.accepted)
+	}
+}
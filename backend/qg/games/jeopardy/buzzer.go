@@ -0,0 +1,165 @@
+package jeopardy
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// buzzWindowClosed is an internal input that is fed into the machine once the
+// fair buzzer has collected button presses for long enough.
+type buzzWindowClosed struct{}
+
+// buzzerOptions is the parsed buzzer configuration of a game.
+type buzzerOptions struct {
+	games.BuzzerOptions
+	// window is the fair buzzer's collection window. It is 0 if the game
+	// does not use a fair buzzer.
+	window time.Duration
+}
+
+func parseBuzzerOptions(data qg.JeopardyGameData) (buzzerOptions, error) {
+	var opts buzzerOptions
+
+	penalty, err := games.ParseEarlyBuzzPenalty(data.EarlyBuzzPenalty)
+	if err != nil {
+		return opts, err
+	}
+	opts.Penalty = penalty
+
+	if data.FairBuzzer != nil {
+		window, err := time.ParseDuration(data.FairBuzzer.Window)
+		if err != nil {
+			return opts, errors.Wrap(err, "invalid fair_buzzer.window")
+		}
+		if window <= 0 {
+			return opts, errors.New("fair_buzzer.window must be positive")
+		}
+		opts.window = window
+		opts.CompensateLatency = true
+		// Nobody can make up more than a whole window on anyone else.
+		opts.MaxCompensation = window
+	}
+
+	return opts, nil
+}
+
+func (m *gameManager) hasFairBuzzer() bool {
+	return m.buzzer.window > 0
+}
+
+// readingStates returns the next states of a question that is still in its
+// reading phase.
+func readingStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandJeopardyArmBuzzers](),
+		cando.Next[qg.CommandJeopardyPressButton](),
+	}
+}
+
+func (m *gameManager) armBuzzers(ctx context.Context, _ qg.CommandJeopardyArmBuzzers) (cando.NextStates, error) {
+	m.state.Buzzer.Arm()
+	m.state.Phase = qg.JeopardyPhaseBuzzing
+
+	if m.practice {
+		m.startAnswerTimer()
+		return cando.NextStates{
+			cando.Next[qg.CommandJeopardyPressButton](),
+			cando.Next[practiceTimedOut](),
+		}, nil
+	}
+
+	return cando.NextStates{
+		cando.Next[qg.CommandJeopardyPressButton](),
+	}, nil
+}
+
+func (m *gameManager) pressButton(ctx context.Context, player qg.PlayerName) (cando.NextStates, error) {
+	if m.state.PlayerAlreadyPressed[player] {
+		return nil, errors.New("you already pressed your button")
+	}
+
+	early, err := m.state.Buzzer.Press(ctx, player)
+	if err != nil {
+		return nil, err
+	}
+
+	if early {
+		// The player is locked out, but we stay in the reading phase.
+		return readingStates(), nil
+	}
+
+	if !m.hasFairBuzzer() {
+		m.state.PlayerAlreadyPressed[player] = true
+		m.state.AnsweringPlayer = player
+		m.state.Phase = qg.JeopardyPhaseAnswering
+
+		if m.practice {
+			return cando.NextStates{
+				cando.Next[qg.CommandJeopardyPlayerJudgment](),
+				cando.Next[qg.CommandJeopardySubmitAnswer]().When(m.isAnswering),
+				cando.Next[practiceTimedOut](),
+			}, nil
+		}
+
+		return cando.NextStates{
+			cando.Next[qg.CommandJeopardyPlayerJudgment](),
+		}, nil
+	}
+
+	if len(m.state.Buzzer.Buzzes()) == 1 {
+		// This is the first press, so open the buzz window.
+		m.machine.AfterFunc(m.buzzer.window, func() {
+			if err := m.running.Input(context.Background(), buzzWindowClosed{}); err != nil {
+				log.Println("jeopardy: cannot close buzz window:", err)
+			}
+		})
+	}
+
+	return cando.NextStates{
+		cando.Next[buzzWindowClosed](),
+		cando.Next[qg.CommandJeopardyPressButton](),
+	}, nil
+}
+
+func (m *gameManager) closeBuzzWindow(ctx context.Context, _ buzzWindowClosed) (cando.NextStates, error) {
+	// Only the winner is marked as pressed, since they are the only one who
+	// gets to answer.
+	winner := m.state.Buzzer.Buzzes()[0].Player
+	m.state.PlayerAlreadyPressed[winner] = true
+	m.state.AnsweringPlayer = winner
+	m.state.Phase = qg.JeopardyPhaseAnswering
+
+	return cando.NextStates{
+		cando.Next[qg.CommandJeopardyPlayerJudgment](),
+	}, nil
+}
+
+func (m *gameManager) lockedOutEvent(player qg.PlayerName) qg.EventJeopardyBuzzerLockedOut {
+	return qg.EventJeopardyBuzzerLockedOut{
+		PlayerName: player,
+		Penalty:    float32(m.buzzer.Penalty) / float32(time.Millisecond),
+	}
+}
+
+func (m *gameManager) buttonPressedEvent() qg.EventJeopardyButtonPressed {
+	presses := m.state.Buzzer.Buzzes()
+
+	buzzes := make([]qg.JeopardyBuzz, len(presses))
+	for i, buzz := range presses {
+		buzzes[i] = qg.JeopardyBuzz{
+			PlayerName: buzz.Player,
+			Latency:    float32(buzz.Latency) / float32(time.Millisecond),
+		}
+	}
+
+	return qg.EventJeopardyButtonPressed{
+		PlayerName: m.state.AnsweringPlayer,
+		Buzzes:     buzzes,
+	}
+}
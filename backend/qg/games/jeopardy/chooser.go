@@ -0,0 +1,99 @@
+package jeopardy
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// DefaultChooserPolicy is the chooser policy used when the game data does not
+// specify one.
+const DefaultChooserPolicy = qg.JeopardyChooserPolicyLastCorrect
+
+// chooser picks the player who chooses the next question.
+type chooser struct {
+	policy qg.JeopardyChooserPolicy
+	rand   *rand.Rand
+}
+
+func newChooser(data qg.JeopardyGameData) (chooser, error) {
+	c := chooser{policy: DefaultChooserPolicy}
+	if data.ChooserPolicy != nil {
+		c.policy = *data.ChooserPolicy
+	}
+
+	switch c.policy {
+	case qg.JeopardyChooserPolicyRandom:
+		seed := time.Now().UnixNano()
+		if data.ChooserSeed != nil {
+			seed = int64(*data.ChooserSeed)
+		}
+		c.rand = rand.New(rand.NewSource(seed))
+	case
+		qg.JeopardyChooserPolicyLastCorrect,
+		qg.JeopardyChooserPolicyRoundRobin,
+		qg.JeopardyChooserPolicyLowestScore:
+		// ok
+	default:
+		return c, fmt.Errorf("unknown chooser_policy %q", c.policy)
+	}
+
+	return c, nil
+}
+
+// chooserTurn describes the turn that just ended.
+type chooserTurn struct {
+	// players is the list of players who may choose, in join order.
+	players []qg.PlayerName
+	// current is the current chooser. It is empty if the game just began.
+	current qg.PlayerName
+	// correct is the player who answered correctly in the turn that just
+	// ended. It is empty if nobody did.
+	correct qg.PlayerName
+	// scores is the current score of each player.
+	scores map[qg.PlayerName]float32
+}
+
+// next picks the next chooser. An empty name is returned if there are no
+// players.
+func (c chooser) next(turn chooserTurn) qg.PlayerName {
+	if len(turn.players) == 0 {
+		return ""
+	}
+
+	switch c.policy {
+	case qg.JeopardyChooserPolicyRandom:
+		return turn.players[c.rand.Intn(len(turn.players))]
+
+	case qg.JeopardyChooserPolicyRoundRobin:
+		if turn.current == "" {
+			return turn.players[0]
+		}
+		for i, player := range turn.players {
+			if player == turn.current {
+				return turn.players[(i+1)%len(turn.players)]
+			}
+		}
+		return turn.players[0]
+
+	case qg.JeopardyChooserPolicyLowestScore:
+		lowest := turn.players[0]
+		for _, player := range turn.players[1:] {
+			if turn.scores[player] < turn.scores[lowest] {
+				lowest = player
+			}
+		}
+		return lowest
+
+	default: // qg.JeopardyChooserPolicyLastCorrect
+		if turn.correct != "" {
+			return turn.correct
+		}
+		if turn.current != "" {
+			return turn.current
+		}
+		return turn.players[0]
+	}
+}
@@ -0,0 +1,132 @@
+package jeopardy
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestChooser(t *testing.T) {
+	players := []qg.PlayerName{"Alice", "Bob", "Carol"}
+
+	// turn describes a turn that ended with the given correct player (or
+	// none) and the chooser that is expected to be picked afterwards.
+	type turn struct {
+		correct qg.PlayerName
+		scores  map[qg.PlayerName]float32
+		expect  qg.PlayerName
+	}
+
+	tests := []struct {
+		name   string
+		policy qg.JeopardyChooserPolicy
+		first  qg.PlayerName
+		turns  []turn
+	}{
+		{
+			name:   "last_correct",
+			policy: qg.JeopardyChooserPolicyLastCorrect,
+			first:  "Alice",
+			turns: []turn{
+				{correct: "Bob", expect: "Bob"},
+				{correct: "", expect: "Bob"},
+				{correct: "Carol", expect: "Carol"},
+				{correct: "Carol", expect: "Carol"},
+			},
+		},
+		{
+			name:   "round_robin",
+			policy: qg.JeopardyChooserPolicyRoundRobin,
+			first:  "Alice",
+			turns: []turn{
+				{correct: "Carol", expect: "Bob"},
+				{correct: "", expect: "Carol"},
+				{correct: "Bob", expect: "Alice"},
+			},
+		},
+		{
+			name:   "lowest_score",
+			policy: qg.JeopardyChooserPolicyLowestScore,
+			first:  "Alice",
+			turns: []turn{
+				{
+					correct: "Alice",
+					scores:  map[qg.PlayerName]float32{"Alice": 100},
+					expect:  "Bob",
+				},
+				{
+					correct: "Bob",
+					scores:  map[qg.PlayerName]float32{"Alice": 100, "Bob": 200},
+					expect:  "Carol",
+				},
+				{
+					correct: "",
+					scores:  map[qg.PlayerName]float32{"Alice": 100, "Bob": 200, "Carol": -100},
+					expect:  "Carol",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newChooser(qg.JeopardyGameData{ChooserPolicy: &test.policy})
+			assert.NoError(t, err)
+
+			current := c.next(chooserTurn{players: players})
+			assert.Equal(t, test.first, current)
+
+			for i, turn := range test.turns {
+				current = c.next(chooserTurn{
+					players: players,
+					current: current,
+					correct: turn.correct,
+					scores:  turn.scores,
+				})
+				assert.Equal(t, turn.expect, current, "turn %d", i)
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		policy := qg.JeopardyChooserPolicyRandom
+		seed := uint32(42)
+
+		pick := func() []qg.PlayerName {
+			c, err := newChooser(qg.JeopardyGameData{
+				ChooserPolicy: &policy,
+				ChooserSeed:   &seed,
+			})
+			assert.NoError(t, err)
+
+			var current qg.PlayerName
+			picks := make([]qg.PlayerName, 20)
+			for i := range picks {
+				current = c.next(chooserTurn{players: players, current: current})
+				picks[i] = current
+			}
+			return picks
+		}
+
+		// The same seed must always pick the same players.
+		picks := pick()
+		assert.Equal(t, picks, pick())
+
+		for _, player := range picks {
+			assert.True(t, player == "Alice" || player == "Bob" || player == "Carol", "unknown player %q", player)
+		}
+	})
+
+	t.Run("no_players", func(t *testing.T) {
+		c, err := newChooser(qg.JeopardyGameData{})
+		assert.NoError(t, err)
+		assert.Equal(t, "", c.next(chooserTurn{}))
+	})
+
+	t.Run("unknown_policy", func(t *testing.T) {
+		policy := qg.JeopardyChooserPolicy("best_dressed")
+		_, err := newChooser(qg.JeopardyGameData{ChooserPolicy: &policy})
+		assert.Error(t, err)
+	})
+}
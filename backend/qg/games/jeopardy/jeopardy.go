@@ -38,6 +38,12 @@ type GameState struct {
 	AnsweringPlayer      qg.PlayerName
 	CurrentCategory      int32
 	CurrentQuestion      int32
+	// Phase is the phase of the current turn. CurrentCategory and
+	// CurrentQuestion are only meaningful outside of the choosing phase.
+	Phase qg.JeopardyPhase
+	// Buzzer collects the button presses for the current question. It is
+	// armed once the admin has finished reading the question.
+	Buzzer *games.Buzzer
 }
 
 // PlayerState is the state of a Jeopardy player.
@@ -53,6 +59,7 @@ func newGameState(data qg.JeopardyGameData) *GameState {
 		AnsweredQuestions:    qg.JeopardyAnsweredQuestions{},
 		CurrentCategory:      -1,
 		CurrentQuestion:      -1,
+		Phase:                qg.JeopardyPhaseChoosing,
 	}
 }
 
@@ -62,9 +69,19 @@ type gameManager struct {
 
 	state   *GameState
 	machine *games.MachineState
-
-	data qg.JeopardyGameData
-	id   qg.GameID
+	running *games.Machine
+
+	data    qg.JeopardyGameData
+	id      qg.GameID
+	buzzer  buzzerOptions
+	chooser chooser
+
+	// practice is true if the game is hosted by a bot. See CreatePractice.
+	practice    bool
+	answerTimer *games.Timer
+	// selfJudging is true once the player of a practice game has answered a
+	// question that the host cannot judge, until they judge it themselves.
+	selfJudging bool
 }
 
 func newGameManager(store Storer, id qg.GameID, data qg.JeopardyGameData, mstate *games.MachineState) *gameManager {
@@ -85,35 +102,93 @@ func (m *gameManager) CompareGamePassword(ctx context.Context, input string) (bo
 	return m.storer.CompareGamePassword(ctx, m.id, input)
 }
 
+// Leaderboard ranks the players by their score. Players with the same score
+// are ranked in the order that they joined.
 func (m *gameManager) Leaderboard() qg.Leaderboard {
-	leaderboard := make(qg.Leaderboard, 0, len(m.machine.Players))
-	for name := range m.machine.Players {
-		leaderboard = append(leaderboard, qg.LeaderboardEntry{
+	players := m.machine.Contestants()
+
+	leaderboard := make(qg.Leaderboard, len(players))
+	for i, name := range players {
+		leaderboard[i] = qg.LeaderboardEntry{
 			PlayerName: name,
 			Score:      m.state.PlayerScores[name],
-		})
+		}
 	}
 
-	sort.Slice(leaderboard, func(i, j int) bool {
+	sort.SliceStable(leaderboard, func(i, j int) bool {
 		return leaderboard[i].Score > leaderboard[j].Score
 	})
 
 	return leaderboard
 }
 
-func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
-	// Pick a random player to start.
-	for _, player := range m.machine.Players {
-		if player.IsAdmin {
-			continue
+// Snapshot implements games.GameManager.
+func (m *gameManager) Snapshot() qg.IGameSnapshot {
+	snapshot := qg.JeopardySnapshot{
+		Phase:    m.state.Phase,
+		Chooser:  m.state.ChoosingPlayer,
+		Answered: append(qg.JeopardyAnsweredQuestions{}, m.state.AnsweredQuestions...),
+		Pressed:  []qg.PlayerName{},
+	}
+
+	for _, name := range m.machine.Contestants() {
+		if m.state.PlayerAlreadyPressed[name] {
+			snapshot.Pressed = append(snapshot.Pressed, name)
 		}
-		m.state.ChoosingPlayer = player.Name
-		break
 	}
 
+	if m.state.Phase == qg.JeopardyPhaseChoosing {
+		return qg.GameSnapshotJeopardy{Data: snapshot}
+	}
+
+	question := m.data.Categories[m.state.CurrentCategory].Questions[m.state.CurrentQuestion]
+
+	content := question.Content
+	if content == nil {
+		content = []qg.QuestionContent{}
+	}
+
+	snapshot.Question = &qg.JeopardyCurrentQuestion{
+		Category: m.state.CurrentCategory,
+		Index:    m.state.CurrentQuestion,
+		Question: question.Question,
+		Content:  content,
+		Points:   m.data.QuestionPoints(m.state.CurrentQuestion),
+	}
+
+	if m.state.Phase == qg.JeopardyPhaseAnswering {
+		snapshot.Answering = m.state.AnsweringPlayer
+	}
+
+	return qg.GameSnapshotJeopardy{Data: snapshot}
+}
+
+func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	m.handOffChooser("")
 	return m.moveToNextTurn(ctx, false)
 }
 
+// isChooser is a guard that only lets the choosing player through.
+func (m *gameManager) isChooser(ctx context.Context) error {
+	self := games.PlayerFromContext(ctx)
+	if self.PlayerState == nil || self.Name != m.state.ChoosingPlayer {
+		return qg.NewCodedError(qg.ErrorCodeNotYourTurn, "not your turn")
+	}
+	return nil
+}
+
+// handOffChooser picks the next chooser using the game's chooser policy.
+// correct is the player who answered correctly in the turn that just ended,
+// if any.
+func (m *gameManager) handOffChooser(correct qg.PlayerName) {
+	m.state.ChoosingPlayer = m.chooser.next(chooserTurn{
+		players: m.machine.Contestants(),
+		current: m.state.ChoosingPlayer,
+		correct: correct,
+		scores:  m.state.PlayerScores,
+	})
+}
+
 func (m *gameManager) alreadyAnsweredPlayers() []qg.PlayerName {
 	players := make([]qg.PlayerName, 0, len(m.machine.Players))
 	for name, player := range m.machine.Players {
@@ -132,11 +207,15 @@ func (m *gameManager) canContinueQuestion() bool {
 
 func (m *gameManager) moveToNextTurn(ctx context.Context, stillAnswering bool) (cando.NextStates, error) {
 	if stillAnswering && m.canContinueQuestion() {
+		m.state.Phase = qg.JeopardyPhaseBuzzing
 		return cando.NextStates{
 			cando.Next[qg.CommandJeopardyPressButton](),
 		}, nil
 	}
 
+	// The turn is over, so there is no current question anymore.
+	m.state.Phase = qg.JeopardyPhaseChoosing
+
 	// Check that we still have questions that aren't yet answered.
 	if len(m.state.AnsweredQuestions) < m.data.TotalQuestions() {
 		return cando.NextStates{
@@ -151,13 +230,60 @@ func (m *gameManager) moveToNextTurn(ctx context.Context, stillAnswering bool) (
 
 // CreateGame implements the games.GameCreator.
 func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error) {
+	running, err := g.createGame(ctx, id, data, false)
+	if err != nil {
+		return nil, err
+	}
+	return running, nil
+}
+
+func (g Game) createGame(ctx context.Context, id qg.GameID, data qg.IGameData, practice bool) (*games.Machine, error) {
+	m, err := g.setUpGame(ctx, id, data, practice)
+	if err != nil {
+		return nil, err
+	}
+
+	m.running, err = m.machine.StartMachine(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.running, nil
+}
+
+// setUpGame sets up the states and reactors of a game without starting its
+// machine.
+func (g Game) setUpGame(ctx context.Context, id qg.GameID, data qg.IGameData, practice bool) (*gameManager, error) {
 	jeopardyData, ok := data.(qg.GameDataJeopardy)
 	if !ok {
 		return nil, errors.Errorf("invalid game data type: %T", data)
 	}
 
+	if err := jeopardyData.Data.Validate(); err != nil {
+		return nil, err
+	}
+
+	buzzer, err := parseBuzzerOptions(jeopardyData.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if practice {
+		// There is nobody to be fair to.
+		buzzer = buzzerOptions{BuzzerOptions: games.BuzzerOptions{Penalty: buzzer.Penalty}}
+	}
+
+	chooser, err := newChooser(jeopardyData.Data)
+	if err != nil {
+		return nil, err
+	}
+
 	s := games.NewMachineState(ctx)
 	m := newGameManager(g.store, id, jeopardyData.Data, s)
+	m.buzzer = buzzer
+	m.state.Buzzer = games.NewBuzzer(buzzer.BuzzerOptions)
+	m.chooser = chooser
+	m.practice = practice
 
 	s.AddReactors(
 		cando.React[any, qg.CommandJeopardyChooseQuestion](func(ctx context.Context, _ any) error {
@@ -168,27 +294,46 @@ func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (
 			})
 			return nil
 		}),
-		cando.React[any, qg.CommandJeopardyPressButton](func(ctx context.Context, _ any) error {
+		cando.React[qg.CommandJeopardyPlayerJudgment, qg.CommandJeopardyPressButton](func(ctx context.Context, _ qg.CommandJeopardyPlayerJudgment) error {
 			// We can still accept answers, so don't end the turn yet.
 			s.Publish(ctx, qg.EventJeopardyResumeButton{
 				AlreadyAnsweredPlayers: m.alreadyAnsweredPlayers(),
 			})
 			return nil
 		}),
-		cando.React[qg.CommandJeopardyChooseQuestion, any](func(ctx context.Context, prev qg.CommandJeopardyChooseQuestion) error {
+		cando.OnEnter[qg.CommandJeopardyChooseQuestion](func(ctx context.Context, prev qg.CommandJeopardyChooseQuestion) error {
+			question := m.data.Categories[prev.Category].Questions[prev.Question]
+
+			content := question.Content
+			if content == nil {
+				content = []qg.QuestionContent{}
+			}
+
 			s.Publish(ctx, qg.EventJeopardyBeginQuestion{
 				Chooser:  m.state.ChoosingPlayer,
 				Category: prev.Category,
-				Question: m.data.Categories[prev.Category].Questions[prev.Question].Question,
+				Question: question.Question,
+				Content:  content,
 				Points:   m.data.QuestionPoints(prev.Question),
 			})
 			return nil
 		}),
-		cando.React[qg.CommandJeopardyPressButton, any](func(ctx context.Context, prev qg.CommandJeopardyPressButton) error {
+		cando.OnEnter[qg.CommandJeopardyArmBuzzers](func(ctx context.Context, _ qg.CommandJeopardyArmBuzzers) error {
+			s.Publish(ctx, qg.EventJeopardyBuzzersArmed{})
+			return nil
+		}),
+		cando.React[qg.CommandJeopardyPressButton, qg.CommandJeopardyArmBuzzers](func(ctx context.Context, _ qg.CommandJeopardyPressButton) error {
+			// The button was pressed during the reading phase.
 			self := games.PlayerFromContext(ctx)
-			s.Publish(ctx, qg.EventJeopardyButtonPressed{
-				PlayerName: self.Name,
-			})
+			s.Publish(ctx, m.lockedOutEvent(self.Name))
+			return nil
+		}),
+		cando.React[qg.CommandJeopardyPressButton, qg.CommandJeopardyPlayerJudgment](func(ctx context.Context, _ qg.CommandJeopardyPressButton) error {
+			s.Publish(ctx, m.buttonPressedEvent())
+			return nil
+		}),
+		cando.OnEnter[buzzWindowClosed](func(ctx context.Context, _ buzzWindowClosed) error {
+			s.Publish(ctx, m.buttonPressedEvent())
 			return nil
 		}),
 		cando.React[any, qg.CommandJeopardyChooseQuestion](func(ctx context.Context, _ any) error {
@@ -200,49 +345,41 @@ func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (
 	)
 
 	s.AddState(
-		cando.State(func(ctx context.Context, cmd qg.CommandJeopardyChooseQuestion) (cando.NextStates, error) {
-			self := games.PlayerFromContext(ctx)
-			if self.Name != m.state.ChoosingPlayer {
-				return nil, errors.New("not your turn")
-			}
-
+		cando.Guarded(cando.State(func(ctx context.Context, cmd qg.CommandJeopardyChooseQuestion) (cando.NextStates, error) {
 			_, _, err := m.data.QuestionAt(cmd.Category, cmd.Question)
 			if err != nil {
 				return nil, errors.Wrap(err, "invalid question")
 			}
 
-			m.state.CurrentCategory = cmd.Category
-			m.state.CurrentQuestion = cmd.Question
-
-			return cando.NextStates{
-				cando.Next[qg.CommandJeopardyPressButton](),
-			}, nil
-		}),
-		cando.State(func(ctx context.Context, cmd qg.CommandJeopardyPressButton) (cando.NextStates, error) {
-			self := games.PlayerFromContext(ctx)
-
-			if m.state.PlayerAlreadyPressed[self.Name] {
-				return nil, errors.New("you already pressed your button")
+			for _, answered := range m.state.AnsweredQuestions {
+				if answered.Category == cmd.Category && answered.Question == cmd.Question {
+					return nil, errors.New("question has already been answered")
+				}
 			}
 
-			m.state.PlayerAlreadyPressed[self.Name] = true
-			m.state.AnsweringPlayer = self.Name
+			m.state.CurrentCategory = cmd.Category
+			m.state.CurrentQuestion = cmd.Question
+			m.state.Phase = qg.JeopardyPhaseReading
+			m.state.Buzzer.Reset()
 
-			return cando.NextStates{
-				cando.Next[qg.CommandJeopardyPlayerJudgment](),
-			}, nil
-		}),
-		cando.State(func(ctx context.Context, cmd qg.CommandJeopardyPlayerJudgment) (cando.NextStates, error) {
+			return readingStates(), nil
+		}), m.isChooser),
+		cando.Guarded(cando.State(m.armBuzzers), games.OnlyAdmins("only admins can arm the buzzers")),
+		cando.Guarded(cando.State(func(ctx context.Context, cmd qg.CommandJeopardyPressButton) (cando.NextStates, error) {
 			self := games.PlayerFromContext(ctx)
-			if !self.IsAdmin {
-				return nil, errors.New("only admins can judge players")
-			}
-
+			return m.pressButton(ctx, self.Name)
+		}), games.Joined),
+		cando.State(m.closeBuzzWindow),
+		cando.Guarded(cando.State(func(ctx context.Context, cmd qg.CommandJeopardyPlayerJudgment) (cando.NextStates, error) {
+			var correct qg.PlayerName
 			if cmd.Correct {
 				// Correct, so reward points and move on to the next
-				// question.
-				pts := m.data.QuestionPoints(m.state.CurrentQuestion)
-				m.state.PlayerScores[m.state.AnsweringPlayer] += pts
+				// question. Players who judge themselves get no points,
+				// which would otherwise count towards their personal best.
+				if !m.selfJudging {
+					pts := m.data.QuestionPoints(m.state.CurrentQuestion)
+					m.state.PlayerScores[m.state.AnsweringPlayer] += pts
+				}
 
 				// Mark the question as answered.
 				m.state.AnsweredQuestions = append(m.state.AnsweredQuestions, qg.JeopardyAnsweredQuestion{
@@ -250,11 +387,25 @@ func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (
 					Question: m.state.CurrentQuestion,
 					Category: m.state.CurrentCategory,
 				})
+
+				correct = m.state.AnsweringPlayer
+			} else if m.practice {
+				// There is nobody else to answer, so the question is used
+				// up.
+				m.state.AnsweredQuestions = append(m.state.AnsweredQuestions, qg.JeopardyAnsweredQuestion{
+					Question: m.state.CurrentQuestion,
+					Category: m.state.CurrentCategory,
+				})
 			}
 
+			m.handOffChooser(correct)
 			return m.moveToNextTurn(ctx, false)
-		}),
+		}), m.canJudge),
 	)
 
-	return s.StartMachine(ctx, m)
+	if practice {
+		m.addPracticeStates(s)
+	}
+
+	return m, nil
 }
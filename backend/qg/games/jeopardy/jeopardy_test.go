@@ -0,0 +1,494 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+type fakeStore struct{ Storer }
+
+func (fakeStore) CompareGamePassword(ctx context.Context, id qg.GameID, password string) (bool, error) {
+	return password == "admin", nil
+}
+
+// hookCall is a reactor that ran. For react reactors, to is the offered
+// state.
+type hookCall struct {
+	hook     cando.Hook
+	from, to reflect.Type
+}
+
+func (c hookCall) String() string {
+	return fmt.Sprintf("%s(%s -> %s)", c.hook, cando.TypeName(c.from), cando.TypeName(c.to))
+}
+
+var (
+	typeChoose = reflect.TypeOf(qg.CommandJeopardyChooseQuestion{})
+	typePress  = reflect.TypeOf(qg.CommandJeopardyPressButton{})
+	typeJoin   = reflect.TypeOf(qg.CommandJoinGame{})
+	typePause  = reflect.TypeOf(qg.CommandPauseGame{})
+	typeResume = reflect.TypeOf(qg.CommandResumeGame{})
+)
+
+// TestMachineHooks plays random commands against a Jeopardy game and checks
+// that its reactors run as documented by cando.Hook:
+//
+//   - A rejected command runs no reactors.
+//   - A join, pause or resume stays in the current state and only runs the
+//     reactors that name it.
+//   - Any other accepted command from state X to state Y runs the leave
+//     reactors of X, then the transition reactors of X to Y, then the enter
+//     and React reactors of Y in the order that they were added.
+//   - A React reactor runs exactly when the entered state offers its next
+//     state.
+//   - The same commands always run the same reactors.
+func TestMachineHooks(t *testing.T) {
+	property := func(seed int64) bool {
+		calls1, err := playRandomGame(t, seed)
+		if err != nil {
+			t.Logf("seed %d: %v", seed, err)
+			return false
+		}
+
+		calls2, err := playRandomGame(t, seed)
+		if err != nil {
+			t.Logf("seed %d: %v", seed, err)
+			return false
+		}
+
+		if !reflect.DeepEqual(calls1, calls2) {
+			t.Logf("seed %d: reactors ran differently for the same commands:\n%v\n%v", seed, calls1, calls2)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// playRandomGame plays random commands from the given seed and returns the
+// reactors that ran. An error is returned once a reactor runs when it should
+// not.
+func playRandomGame(t *testing.T, seed int64) ([]hookCall, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rng := rand.New(rand.NewSource(seed))
+
+	roundRobin := qg.JeopardyChooserPolicyRoundRobin
+	penalty := "1h" // lockouts never expire mid-test
+	data := qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+		Categories: []qg.JeopardyCategory{
+			{Name: "A", Questions: []qg.JeopardyQuestion{{Question: "A1"}, {Question: "A2"}}},
+			{Name: "B", Questions: []qg.JeopardyQuestion{{Question: "B1"}, {Question: "B2"}}},
+		},
+		ChooserPolicy:    &roundRobin,
+		EarlyBuzzPenalty: &penalty,
+	}}
+
+	m, err := Game{fakeStore{}}.setUpGame(ctx, "test", data, false)
+	if err != nil {
+		t.Fatal("cannot set up game:", err)
+	}
+
+	var calls []hookCall
+	record := func(hook cando.Hook, from, to reflect.Type) {
+		calls = append(calls, hookCall{hook, from, to})
+	}
+
+	// The reactors are added out of order on purpose, since their phase
+	// decides when they run.
+	m.machine.AddReactors(
+		cando.OnEnter[any](func(ctx context.Context, to any) error {
+			record(cando.HookEnter, nil, reflect.TypeOf(to))
+			return nil
+		}),
+		cando.OnTransition[any, any](func(ctx context.Context, from, to any) error {
+			record(cando.HookTransition, reflect.TypeOf(from), reflect.TypeOf(to))
+			return nil
+		}),
+		cando.OnLeave[any](func(ctx context.Context, from any) error {
+			record(cando.HookLeave, reflect.TypeOf(from), nil)
+			return nil
+		}),
+		cando.React[any, qg.CommandJeopardyChooseQuestion](func(ctx context.Context, prev any) error {
+			record(cando.HookReact, reflect.TypeOf(prev), typeChoose)
+			return nil
+		}),
+		cando.React[any, qg.CommandJeopardyPressButton](func(ctx context.Context, prev any) error {
+			record(cando.HookReact, reflect.TypeOf(prev), typePress)
+			return nil
+		}),
+		cando.OnEnter[qg.CommandJoinGame](func(ctx context.Context, _ qg.CommandJoinGame) error {
+			record(cando.HookEnter, nil, typeJoin)
+			return nil
+		}),
+		cando.OnEnter[qg.CommandPauseGame](func(ctx context.Context, _ qg.CommandPauseGame) error {
+			record(cando.HookEnter, nil, typePause)
+			return nil
+		}),
+		cando.OnEnter[qg.CommandResumeGame](func(ctx context.Context, _ qg.CommandResumeGame) error {
+			record(cando.HookEnter, nil, typeResume)
+			return nil
+		}),
+	)
+
+	m.running, err = m.machine.StartMachine(ctx, m)
+	if err != nil {
+		t.Fatal("cannot start machine:", err)
+	}
+	// Starting the machine enters its initial state.
+	calls = calls[:0]
+
+	names := []qg.PlayerName{"Admin", "Alice", "Bob"}
+	players := make([]qg.CommandHandler, len(names))
+	for i := range players {
+		evs := make(chan qg.IEvent)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-evs:
+				}
+			}
+		}()
+
+		players[i], err = m.running.NewCommandHandler(ctx, evs)
+		if err != nil {
+			t.Fatal("cannot create command handler:", err)
+		}
+		defer players[i].Close()
+	}
+
+	join := func(i int) qg.ICommand {
+		cmd := qg.CommandJoinGame{GameID: "test", PlayerName: names[i]}
+		if i == 0 {
+			cmd.AdminPassword = ptr("admin")
+		}
+		return cmd
+	}
+
+	randomCommand := func() qg.ICommand {
+		switch rng.Intn(8) {
+		case 0:
+			return qg.CommandBeginGame{}
+		case 1:
+			return qg.CommandJeopardyChooseQuestion{
+				Category: rng.Int31n(3),
+				Question: rng.Int31n(3),
+			}
+		case 2:
+			return qg.CommandJeopardyArmBuzzers{}
+		case 3, 4:
+			return qg.CommandJeopardyPressButton{}
+		case 5:
+			return qg.CommandJeopardyPlayerJudgment{Correct: rng.Intn(2) == 0}
+		case 6:
+			return qg.CommandPauseGame{}
+		default:
+			return qg.CommandResumeGame{}
+		}
+	}
+
+	for step := 0; step < 100; step++ {
+		player := rng.Intn(len(players))
+
+		var cmd qg.ICommand
+		if step < len(players) {
+			player = step
+			cmd = join(step)
+		} else {
+			cmd = randomCommand()
+		}
+
+		before, err := m.running.Inspect(ctx)
+		if err != nil {
+			t.Fatal("cannot inspect machine:", err)
+		}
+
+		start := len(calls)
+		cmdErr := players[player].HandleCommand(ctx, cmd)
+		got := calls[start:]
+
+		after, err := m.running.Inspect(ctx)
+		if err != nil {
+			t.Fatal("cannot inspect machine:", err)
+		}
+
+		var want []hookCall
+		to := reflect.TypeOf(cmd)
+
+		switch {
+		case cmdErr != nil:
+			// Nothing changed, so nothing runs.
+		case to == typeJoin || to == typePause || to == typeResume:
+			want = []hookCall{{cando.HookEnter, nil, to}}
+		default:
+			want = []hookCall{
+				{cando.HookLeave, before.Current, nil},
+				{cando.HookTransition, before.Current, to},
+				{cando.HookEnter, nil, to},
+			}
+			for _, offered := range []reflect.Type{typeChoose, typePress} {
+				for _, next := range after.Next {
+					if next == offered {
+						want = append(want, hookCall{cando.HookReact, to, offered})
+					}
+				}
+			}
+		}
+
+		if !reflect.DeepEqual(want, append([]hookCall(nil), got...)) {
+			return nil, fmt.Errorf("step %d: %s sent %T (error: %v): got reactors %v, want %v",
+				step, names[player], cmd, cmdErr, got, want)
+		}
+
+		if len(want) < 3 {
+			// The machine must not have moved.
+			if before.Current != after.Current || !reflect.DeepEqual(before.Next, after.Next) {
+				return nil, fmt.Errorf("step %d: %T changed the state without running reactors", step, cmd)
+			}
+		} else if after.Current != to {
+			return nil, fmt.Errorf("step %d: current state is %v, want %v", step, after.Current, to)
+		}
+	}
+
+	return calls, nil
+}
+
+// TestChooserHandoff plays a few turns of a game with each chooser policy and
+// checks who gets to choose after right and wrong answers.
+func TestChooserHandoff(t *testing.T) {
+	type turn struct {
+		// answerer presses their button and is judged.
+		answerer qg.PlayerName
+		correct  bool
+		// chooser is who should choose next.
+		chooser qg.PlayerName
+	}
+
+	const seed = 42
+	randomChoosers := rand.New(rand.NewSource(seed))
+	randomChooser := func() qg.PlayerName {
+		return []qg.PlayerName{"Alice", "Bob", "Carol"}[randomChoosers.Intn(3)]
+	}
+
+	tests := []struct {
+		policy qg.JeopardyChooserPolicy
+		// first is who chooses once the game begins.
+		first qg.PlayerName
+		turns []turn
+	}{
+		{
+			policy: qg.JeopardyChooserPolicyLastCorrect,
+			first:  "Alice",
+			turns: []turn{
+				{answerer: "Bob", correct: true, chooser: "Bob"},
+				{answerer: "Carol", correct: false, chooser: "Bob"},
+				{answerer: "Carol", correct: true, chooser: "Carol"},
+			},
+		},
+		{
+			policy: qg.JeopardyChooserPolicyRoundRobin,
+			first:  "Alice",
+			turns: []turn{
+				{answerer: "Carol", correct: true, chooser: "Bob"},
+				{answerer: "Alice", correct: false, chooser: "Carol"},
+				{answerer: "Carol", correct: true, chooser: "Alice"},
+			},
+		},
+		{
+			policy: qg.JeopardyChooserPolicyLowestScore,
+			first:  "Alice",
+			turns: []turn{
+				{answerer: "Alice", correct: true, chooser: "Bob"},
+				{answerer: "Bob", correct: false, chooser: "Bob"},
+				{answerer: "Bob", correct: true, chooser: "Carol"},
+			},
+		},
+		{
+			policy: qg.JeopardyChooserPolicyRandom,
+			first:  randomChooser(),
+			turns: []turn{
+				{answerer: "Alice", correct: true, chooser: randomChooser()},
+				{answerer: "Bob", correct: false, chooser: randomChooser()},
+				{answerer: "Carol", correct: true, chooser: randomChooser()},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.policy), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			policy := test.policy
+			data := qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+				Categories: []qg.JeopardyCategory{
+					{Name: "A", Questions: []qg.JeopardyQuestion{{Question: "A1"}, {Question: "A2"}}},
+					{Name: "B", Questions: []qg.JeopardyQuestion{{Question: "B1"}, {Question: "B2"}}},
+				},
+				ChooserPolicy: &policy,
+				ChooserSeed:   ptr(uint32(seed)),
+			}}
+
+			running, err := Game{fakeStore{}}.createGame(ctx, "test", data, false)
+			if err != nil {
+				t.Fatal("cannot create game:", err)
+			}
+
+			turnsEnded := make(chan qg.EventJeopardyTurnEnded, 16)
+
+			players := make(map[qg.PlayerName]qg.CommandHandler)
+			for _, name := range []qg.PlayerName{"Admin", "Alice", "Bob", "Carol"} {
+				evs := make(chan qg.IEvent)
+				go func(name qg.PlayerName) {
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						case ev := <-evs:
+							if ev, ok := ev.(qg.EventJeopardyTurnEnded); ok && name == "Admin" {
+								turnsEnded <- ev
+							}
+						}
+					}
+				}(name)
+
+				h, err := running.NewCommandHandler(ctx, evs)
+				if err != nil {
+					t.Fatal("cannot create command handler:", err)
+				}
+				t.Cleanup(func() { h.Close() })
+				players[name] = h
+
+				join := qg.CommandJoinGame{GameID: "test", PlayerName: name}
+				if name == "Admin" {
+					join.AdminPassword = ptr("admin")
+				}
+				send(ctx, t, h, join)
+			}
+
+			expectChooser := func(want qg.PlayerName) {
+				t.Helper()
+				select {
+				case ev := <-turnsEnded:
+					if ev.Chooser != want {
+						t.Fatalf("chooser is %q, want %q", ev.Chooser, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("timed out waiting for the turn to end")
+				}
+			}
+
+			send(ctx, t, players["Admin"], qg.CommandBeginGame{})
+			expectChooser(test.first)
+
+			chooser := test.first
+			for i, turn := range test.turns {
+				send(ctx, t, players[chooser], qg.CommandJeopardyChooseQuestion{
+					Category: int32(i % 2),
+					Question: int32(i / 2),
+				})
+				send(ctx, t, players["Admin"], qg.CommandJeopardyArmBuzzers{})
+				send(ctx, t, players[turn.answerer], qg.CommandJeopardyPressButton{})
+				send(ctx, t, players["Admin"], qg.CommandJeopardyPlayerJudgment{Correct: turn.correct})
+
+				expectChooser(turn.chooser)
+				chooser = turn.chooser
+			}
+		})
+	}
+}
+
+// TestNotJoined checks that commands from connections that have not joined the
+// game, such as one whose join failed, are refused instead of crashing the
+// game.
+func TestNotJoined(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	data := qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+		Categories: []qg.JeopardyCategory{
+			{Name: "A", Questions: []qg.JeopardyQuestion{{Question: "A1"}}},
+		},
+	}}
+
+	running, err := Game{fakeStore{}}.createGame(ctx, "test", data, false)
+	if err != nil {
+		t.Fatal("cannot create game:", err)
+	}
+
+	connect := func() qg.CommandHandler {
+		evs := make(chan qg.IEvent)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-evs:
+				}
+			}
+		}()
+
+		h, err := running.NewCommandHandler(ctx, evs)
+		if err != nil {
+			t.Fatal("cannot create command handler:", err)
+		}
+		t.Cleanup(func() { h.Close() })
+		return h
+	}
+
+	admin := connect()
+	send(ctx, t, admin, qg.CommandJoinGame{GameID: "test", PlayerName: "Admin", AdminPassword: ptr("admin")})
+
+	alice := connect()
+	send(ctx, t, alice, qg.CommandJoinGame{GameID: "test", PlayerName: "Alice"})
+
+	// The name is taken, so this one never joins.
+	impostor := connect()
+	if err := impostor.HandleCommand(ctx, qg.CommandJoinGame{GameID: "test", PlayerName: "Alice"}); err == nil {
+		t.Fatal("joined with a name that is taken")
+	}
+
+	expectCode := func(cmd qg.ICommand, want qg.ErrorCode) {
+		t.Helper()
+		err := impostor.HandleCommand(ctx, cmd)
+		if code, _ := qg.ErrorCodeOf(err); code != want {
+			t.Fatalf("%T: got error %v with code %q, want code %q", cmd, err, code, want)
+		}
+	}
+
+	send(ctx, t, admin, qg.CommandBeginGame{})
+	expectCode(qg.CommandJeopardyChooseQuestion{}, qg.ErrorCodeNotYourTurn)
+
+	send(ctx, t, alice, qg.CommandJeopardyChooseQuestion{})
+	send(ctx, t, admin, qg.CommandJeopardyArmBuzzers{})
+	expectCode(qg.CommandJeopardyPressButton{}, qg.ErrorCodeInvalidState)
+
+	// The game carries on as if nothing happened.
+	send(ctx, t, alice, qg.CommandJeopardyPressButton{})
+	send(ctx, t, admin, qg.CommandJeopardyPlayerJudgment{Correct: true})
+}
+
+func send(ctx context.Context, t *testing.T, h qg.CommandHandler, cmd qg.ICommand) {
+	t.Helper()
+	if err := h.HandleCommand(ctx, cmd); err != nil {
+		t.Fatalf("%T failed: %v", cmd, err)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,169 @@
+package jeopardy
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// PracticeAnswerTime is how long the player of a practice game has to press
+// their button and answer once the buzzers are armed.
+const PracticeAnswerTime = 30 * time.Second
+
+// practiceTimedOut is an internal input that is fed into the machine once the
+// player of a practice game ran out of time to answer the given question.
+type practiceTimedOut struct {
+	category int32
+	question int32
+}
+
+// CreatePractice implements games.PracticeCreator. The host of a practice game
+// arms the buzzers as soon as a question is chosen and judges answers using
+// the accepted answers of the question. Questions without any, such as those
+// of sets that were saved before answers could be stored, are judged by the
+// player themselves.
+func (g Game) CreatePractice(ctx context.Context, id qg.GameID, data qg.IGameData) (*games.Machine, error) {
+	return g.createGame(ctx, id, data, true)
+}
+
+// hostInput makes the host send the given command once the machine is free.
+func (m *gameManager) hostInput(cmd qg.ICommand) {
+	m.machine.AfterFunc(0, func() {
+		if err := m.running.HostInput(context.Background(), cmd); err != nil {
+			log.Println("jeopardy: host cannot send command:", err)
+		}
+	})
+}
+
+// startAnswerTimer gives the player of a practice game PracticeAnswerTime to
+// answer the current question.
+func (m *gameManager) startAnswerTimer() {
+	input := practiceTimedOut{
+		category: m.state.CurrentCategory,
+		question: m.state.CurrentQuestion,
+	}
+
+	m.answerTimer = m.machine.AfterFunc(PracticeAnswerTime, func() {
+		if err := m.running.Input(context.Background(), input); err != nil {
+			log.Println("jeopardy: cannot time out question:", err)
+		}
+	})
+}
+
+func (m *gameManager) submitAnswer(ctx context.Context, _ qg.CommandJeopardySubmitAnswer) (cando.NextStates, error) {
+	m.answerTimer.Stop()
+
+	if len(m.acceptedAnswers()) == 0 {
+		// The host cannot judge this one, so the player gets another go of
+		// the timer to judge it themselves.
+		m.selfJudging = true
+		m.startAnswerTimer()
+		return cando.NextStates{
+			cando.Next[qg.CommandJeopardyPlayerJudgment](),
+			cando.Next[practiceTimedOut](),
+		}, nil
+	}
+
+	return cando.NextStates{
+		cando.Next[qg.CommandJeopardyPlayerJudgment](),
+	}, nil
+}
+
+// acceptedAnswers returns the accepted answers of the current question. It is
+// never nil.
+func (m *gameManager) acceptedAnswers() []string {
+	answers := m.data.Categories[m.state.CurrentCategory].Questions[m.state.CurrentQuestion].Answers
+	if answers == nil {
+		answers = []string{}
+	}
+	return answers
+}
+
+// canJudge is a guard that only lets admins judge answers, except for the
+// player of a practice game who is judging their own answer.
+func (m *gameManager) canJudge(ctx context.Context) error {
+	if self := games.PlayerFromContext(ctx); m.selfJudging && self.PlayerState != nil && self.Name == m.state.AnsweringPlayer {
+		return nil
+	}
+	return games.OnlyAdmins("only admins can judge players")(ctx)
+}
+
+// isAnswering is a guard that only lets the player who pressed their button
+// through.
+func (m *gameManager) isAnswering(ctx context.Context) error {
+	self := games.PlayerFromContext(ctx)
+	if self.PlayerState == nil || self.Name != m.state.AnsweringPlayer {
+		return qg.NewCodedError(qg.ErrorCodeNotYourTurn, "only the player who pressed their button can answer")
+	}
+	return nil
+}
+
+func (m *gameManager) timeOut(ctx context.Context, input practiceTimedOut) (cando.NextStates, error) {
+	if input.category != m.state.CurrentCategory || input.question != m.state.CurrentQuestion {
+		return nil, errors.New("question has already ended")
+	}
+
+	// Nobody gets to answer, so the host judges nobody.
+	m.state.AnsweringPlayer = ""
+
+	return cando.NextStates{
+		cando.Next[qg.CommandJeopardyPlayerJudgment](),
+	}, nil
+}
+
+// addPracticeStates adds the states and reactors of the host to the machine.
+func (m *gameManager) addPracticeStates(s *games.MachineState) {
+	accepted := m.acceptedAnswers
+
+	s.AddReactors(
+		cando.OnEnter[qg.CommandJeopardyChooseQuestion](func(ctx context.Context, _ qg.CommandJeopardyChooseQuestion) error {
+			// The player reads the question themselves.
+			m.hostInput(qg.CommandJeopardyArmBuzzers{})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandJeopardySubmitAnswer](func(ctx context.Context, cmd qg.CommandJeopardySubmitAnswer) error {
+			// Only the player who answered gets to see how they did.
+			player := games.Player(m.state.AnsweringPlayer)
+
+			if m.selfJudging {
+				s.PublishTo(ctx, player, qg.EventJeopardySelfJudge{Answer: cmd.Answer})
+				return nil
+			}
+
+			correct := matchAnswer(accepted(), cmd.Answer)
+			s.PublishTo(ctx, player, qg.EventJeopardyAnswerJudged{
+				Answer:  cmd.Answer,
+				Correct: correct,
+				Answers: accepted(),
+			})
+			m.hostInput(qg.CommandJeopardyPlayerJudgment{Correct: correct})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandJeopardyPlayerJudgment](func(ctx context.Context, _ qg.CommandJeopardyPlayerJudgment) error {
+			if m.selfJudging {
+				m.answerTimer.Stop()
+				m.selfJudging = false
+			}
+			return nil
+		}),
+		cando.OnEnter[practiceTimedOut](func(ctx context.Context, _ practiceTimedOut) error {
+			m.selfJudging = false
+			s.Publish(ctx, qg.EventJeopardyAnswerJudged{
+				Correct: false,
+				Answers: accepted(),
+			})
+			m.hostInput(qg.CommandJeopardyPlayerJudgment{Correct: false})
+			return nil
+		}),
+	)
+
+	s.AddState(
+		cando.State(m.submitAnswer),
+		cando.State(m.timeOut),
+	)
+}
@@ -2,8 +2,12 @@ package games
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"log"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"oss.acmcsuf.com/qg/backend/internal/cando"
@@ -23,12 +27,33 @@ const (
 type PlayerHandle struct {
 	*pubsub.Publisher
 	*PlayerState
+
+	outbox *queue
 }
 
 // PlayerState is the state of a player.
 type PlayerState struct {
 	Name    qg.PlayerName
 	IsAdmin bool
+	// Eliminated is true once the player has been knocked out of a game that
+	// eliminates players. Eliminated players stay connected as spectators.
+	Eliminated bool
+	// Team is the team of the player in games with teams. See
+	// MachineState.SetTeam.
+	Team string
+
+	// rejoinToken lets the player take their place back from a new
+	// connection. See EventJoinedGame.
+	rejoinToken string
+}
+
+// newRejoinToken returns a new random rejoin token.
+func newRejoinToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", errors.Wrap(err, "cannot generate rejoin token")
+	}
+	return hex.EncodeToString(b[:]), nil
 }
 
 func injectPlayerHandler(ctx context.Context, h *PlayerHandle) context.Context {
@@ -52,6 +77,9 @@ type GameManager interface {
 	BeginGame(ctx context.Context) (cando.NextStates, error)
 	// Leaderboard builds a leaderboard for the game.
 	Leaderboard() qg.Leaderboard
+	// Snapshot returns the state of the game that is specific to its type
+	// for an EventGameSnapshot. It returns nil if there is none.
+	Snapshot() qg.IGameSnapshot
 }
 
 // MachineState controls a game using a state machine.
@@ -59,19 +87,150 @@ type MachineState struct {
 	*pubsub.Publisher
 	Players map[string]*PlayerState
 
-	states   []cando.AnyState
-	reactors []cando.AnyReactor
+	handles map[qg.PlayerName]*PlayerHandle
+	outbox  queue
+
+	joinOrder  []qg.PlayerName
+	states     []cando.AnyState
+	superstate []cando.AnyState
+	reactors   []cando.AnyReactor
+
+	pauseMu  sync.Mutex
+	paused   bool
+	pausedBy qg.PlayerName
+	timers   map[*Timer]struct{}
+
+	autoBegin    int
+	autoBeginDue atomic.Bool
+
+	started bool
+	ended   bool
+
+	// rejoining is true while a player is taking their place back. See
+	// MachineState.rejoin.
+	rejoining bool
+
+	// onEnd is called with the final leaderboard once the game ends, after
+	// EventGameEnded is published. It is used by practice games.
+	onEnd func(ctx context.Context, leaderboard qg.Leaderboard) error
 }
 
+// autoBeginGame is an internal input that begins the game once enough players
+// have joined. See AutoBeginner.
+type autoBeginGame struct{}
+
 // NewMachineState creates a new default machine controller for a game. It
 // handles all the basic commands.
 func NewMachineState(ctx context.Context) *MachineState {
 	return &MachineState{
 		Publisher: pubsub.NewPublisher(),
 		Players:   make(map[string]*PlayerState),
+		handles:   make(map[qg.PlayerName]*PlayerHandle),
+		timers:    make(map[*Timer]struct{}),
 	}
 }
 
+// IsPaused returns true if the game is paused.
+func (m *MachineState) IsPaused() bool {
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+
+	return m.paused
+}
+
+// Pause pauses the game and freezes all of its timers. It does not publish
+// any event. It is used to pause games that are restored after a restart,
+// so that nothing happens until an admin resumes the game.
+func (m *MachineState) Pause() {
+	m.setPaused(true, "")
+}
+
+// setPaused pauses or resumes the game. False is returned if the game is
+// already in the wanted state.
+func (m *MachineState) setPaused(paused bool, by qg.PlayerName) bool {
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+
+	if m.paused == paused {
+		return false
+	}
+
+	m.paused = paused
+	m.pausedBy = by
+
+	for t := range m.timers {
+		if paused {
+			t.pause()
+		} else {
+			t.resume()
+		}
+	}
+
+	return true
+}
+
+// snapshot returns the current state of the game.
+func (m *MachineState) snapshot(game GameManager) qg.EventGameSnapshot {
+	ev := qg.EventGameSnapshot{
+		Started:     m.started,
+		Paused:      m.IsPaused(),
+		Ended:       m.ended,
+		Players:     m.Contestants(),
+		Leaderboard: game.Leaderboard(),
+	}
+	if snapshot := game.Snapshot(); snapshot != nil {
+		ev.Game = &qg.GameSnapshot{Value: snapshot}
+	}
+	return ev
+}
+
+// Contestants returns the names of all non-admin players in the order that
+// they joined.
+func (m *MachineState) Contestants() []qg.PlayerName {
+	names := make([]qg.PlayerName, 0, len(m.joinOrder))
+	for _, name := range m.joinOrder {
+		if !m.Players[name].IsAdmin {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Survivors returns the names of all non-admin players that have not been
+// eliminated in the order that they joined.
+func (m *MachineState) Survivors() []qg.PlayerName {
+	names := make([]qg.PlayerName, 0, len(m.joinOrder))
+	for _, name := range m.joinOrder {
+		if p := m.Players[name]; !p.IsAdmin && !p.Eliminated {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Eliminate marks the given players as eliminated.
+func (m *MachineState) Eliminate(names ...qg.PlayerName) {
+	for _, name := range names {
+		if p, ok := m.Players[name]; ok {
+			p.Eliminated = true
+		}
+	}
+}
+
+// rejoin hands the state of an existing player over to a new connection. The
+// old connection, if it is still around, is left watching as a display.
+func (m *MachineState) rejoin(self *PlayerHandle, player *PlayerState) {
+	if old := m.handles[player.Name]; old != nil && old != self {
+		old.PlayerState = nil
+		old.updateTopics()
+	}
+
+	self.PlayerState = player
+	self.updateTopics()
+	m.handles[player.Name] = self
+	m.rejoining = true
+}
+
 // AddReactors adds the given reactors to the machine.
 func (m *MachineState) AddReactors(reactors ...cando.AnyReactor) {
 	m.reactors = append(m.reactors, reactors...)
@@ -82,28 +241,46 @@ func (m *MachineState) AddState(states ...cando.AnyState) {
 	m.states = append(m.states, states...)
 }
 
+// AddSuperstate adds new states to the superstate of the machine. Their inputs
+// are accepted no matter which state the game is in. See
+// cando.MachineData.Superstate.
+func (m *MachineState) AddSuperstate(states ...cando.AnyState) {
+	m.superstate = append(m.superstate, states...)
+}
+
 // StartMachine starts a new machine from the current state. Only one machine
 // can use the state at a time.
+//
+// The running machine is an actor: commands, inputs and timer ticks are queued
+// into its inbox and handled one at a time, so states and reactors need no
+// locking of their own. Events are published through a separate outbox once
+// they are handed off, so fanning them out to players never blocks the game.
 func (s *MachineState) StartMachine(ctx context.Context, game GameManager) (*Machine, error) {
 	var mdata cando.MachineData
-	var mutex sync.Mutex
-
-	mdata.EnterMachine = func(context.Context) error {
-		mutex.Lock()
-		return nil
-	}
-
-	mdata.LeaveMachine = func(context.Context) error {
-		mutex.Unlock()
-		return nil
-	}
 
 	mdata.States = []cando.AnyState{
 		cando.InitState(func(ctx context.Context) cando.NextStates {
+			// Players join through the superstate, so the lobby only waits
+			// for the game to begin.
 			return cando.NextStates{
-				cando.Next[qg.CommandJoinGame](),
+				cando.Next[qg.CommandBeginGame](),
+				cando.Next[autoBeginGame](),
 			}
 		}),
+		cando.Guarded(
+			cando.State(func(ctx context.Context, cmd qg.CommandBeginGame) (cando.NextStates, error) {
+				return game.BeginGame(ctx)
+			}),
+			OnlyAdmins("only admins can begin the game"),
+		),
+		cando.State(func(ctx context.Context, _ autoBeginGame) (cando.NextStates, error) {
+			return game.BeginGame(ctx)
+		}),
+	}
+
+	mdata.Superstate = []cando.AnyState{
+		// Players may join at any time, even once the game has begun, in
+		// which case they catch up with a snapshot.
 		cando.State(func(ctx context.Context, cmd qg.CommandJoinGame) (cando.NextStates, error) {
 			var isAdmin bool
 			if cmd.AdminPassword != nil {
@@ -112,43 +289,75 @@ func (s *MachineState) StartMachine(ctx context.Context, game GameManager) (*Mac
 					return nil, errors.Wrap(err, "failed to compare game password")
 				}
 				if !ok {
-					return nil, errors.New("invalid admin password")
+					return nil, qg.NewCodedError(qg.ErrorCodeUnauthorized, "invalid admin password")
 				}
 				isAdmin = true
 			}
 
-			_, ok := s.Players[cmd.PlayerName]
-			if ok {
-				return nil, errors.New("player already exists")
+			s.rejoining = false
+
+			if player, ok := s.Players[cmd.PlayerName]; ok {
+				if cmd.RejoinToken == nil ||
+					subtle.ConstantTimeCompare([]byte(*cmd.RejoinToken), []byte(player.rejoinToken)) != 1 {
+					return nil, qg.NewCodedError(qg.ErrorCodeConflict, "player already exists")
+				}
+
+				s.rejoin(PlayerFromContext(ctx), player)
+				return cando.Stay(), nil
+			}
+
+			token, err := newRejoinToken()
+			if err != nil {
+				return nil, err
 			}
 
 			player := &PlayerState{
-				Name:    cmd.PlayerName,
-				IsAdmin: isAdmin,
+				Name:        cmd.PlayerName,
+				IsAdmin:     isAdmin,
+				rejoinToken: token,
 			}
 
 			s.Players[cmd.PlayerName] = player
+			s.joinOrder = append(s.joinOrder, cmd.PlayerName)
 
 			self := PlayerFromContext(ctx)
 			self.PlayerState = player
+			self.updateTopics()
+			s.handles[cmd.PlayerName] = self
 
-			return cando.NextStates{
-				cando.Next[qg.CommandJoinGame](),
-				cando.Next[qg.CommandBeginGame](),
-			}, nil
-		}),
-		cando.State(func(ctx context.Context, cmd qg.CommandBeginGame) (cando.NextStates, error) {
-			self := PlayerFromContext(ctx)
-			if !self.IsAdmin {
-				return nil, errors.New("only admins can begin the game")
+			if s.autoBegin > 0 && !s.started && len(s.Contestants()) >= s.autoBegin {
+				// The command handler feeds the input once we're done.
+				s.autoBeginDue.Store(true)
 			}
 
-			return game.BeginGame(ctx)
+			return cando.Stay(), nil
+		}),
+		cando.Guarded(
+			cando.State(func(ctx context.Context, _ qg.CommandPauseGame) (cando.NextStates, error) {
+				if !s.setPaused(true, PlayerFromContext(ctx).Name) {
+					return nil, qg.NewCodedError(qg.ErrorCodeInvalidState, "the game is already paused")
+				}
+				return cando.Stay(), nil
+			}),
+			OnlyAdmins("only admins can pause or resume the game"),
+		),
+		cando.Guarded(
+			cando.State(func(ctx context.Context, _ qg.CommandResumeGame) (cando.NextStates, error) {
+				if !s.setPaused(false, PlayerFromContext(ctx).Name) {
+					return nil, qg.NewCodedError(qg.ErrorCodeInvalidState, "the game is not paused")
+				}
+				return cando.Stay(), nil
+			}),
+			OnlyAdmins("only admins can pause or resume the game"),
+		),
+		cando.State(func(ctx context.Context, _ qg.CommandRequestSnapshot) (cando.NextStates, error) {
+			// Displays may ask too, so that they can draw the game as it is.
+			return cando.Stay(), nil
 		}),
 	}
 
 	mdata.Reactors = cando.JoinReactors(
-		cando.React[qg.CommandJoinGame, any](func(ctx context.Context, prev qg.CommandJoinGame) error {
+		cando.OnEnter[qg.CommandJoinGame](func(ctx context.Context, prev qg.CommandJoinGame) error {
 			self := PlayerFromContext(ctx)
 
 			var gameData *qg.GameData
@@ -162,15 +371,33 @@ func (s *MachineState) StartMachine(ctx context.Context, game GameManager) (*Mac
 				GameInfo: qg.GameInfo{Value: qg.GameInfoFromData(game.Data())},
 				GameData: gameData,
 				IsAdmin:  gameData != nil,
+				// Only this player ever sees their token.
+				RejoinToken: self.rejoinToken,
 			})
 
 			return nil
 		}),
-		cando.React[qg.CommandJoinGame, any](func(ctx context.Context, prev qg.CommandJoinGame) error {
-			// Broadcast to all players that this player has joined.
-			s.Publish(ctx, qg.EventPlayerJoined{
-				PlayerName: prev.PlayerName,
-			})
+		cando.OnEnter[qg.CommandJoinGame](func(ctx context.Context, prev qg.CommandJoinGame) error {
+			s.pauseMu.Lock()
+			paused, pausedBy := s.paused, s.pausedBy
+			s.pauseMu.Unlock()
+
+			if paused {
+				// Let the player know why nothing is happening.
+				self := PlayerFromContext(ctx)
+				self.Publish(ctx, qg.EventGamePaused{PlayerName: pausedBy})
+			}
+
+			return nil
+		}),
+		cando.OnEnter[qg.CommandJoinGame](func(ctx context.Context, prev qg.CommandJoinGame) error {
+			// Broadcast to all players that this player has joined. Everyone
+			// already knows about a player that is rejoining.
+			if !s.rejoining {
+				s.Publish(ctx, qg.EventPlayerJoined{
+					PlayerName: prev.PlayerName,
+				})
+			}
 
 			// Broadcast to this player the names of all other players.
 			for player := range s.Players {
@@ -184,19 +411,52 @@ func (s *MachineState) StartMachine(ctx context.Context, game GameManager) (*Mac
 
 			return nil
 		}),
-		cando.React[qg.CommandBeginGame, any](func(ctx context.Context, _ qg.CommandBeginGame) error {
+		cando.OnEnter[qg.CommandJoinGame](func(ctx context.Context, _ qg.CommandJoinGame) error {
+			// Catch the player up in case the game is already underway.
+			self := PlayerFromContext(ctx)
+			self.Publish(ctx, s.snapshot(game))
+			return nil
+		}),
+		cando.OnEnter[qg.CommandRequestSnapshot](func(ctx context.Context, _ qg.CommandRequestSnapshot) error {
+			self := PlayerFromContext(ctx)
+			self.Publish(ctx, s.snapshot(game))
+			return nil
+		}),
+		cando.OnEnter[qg.CommandBeginGame](func(ctx context.Context, _ qg.CommandBeginGame) error {
+			s.started = true
 			s.Publish(ctx, qg.EventGameStarted{})
 			return nil
 		}),
+		cando.OnEnter[autoBeginGame](func(ctx context.Context, _ autoBeginGame) error {
+			s.started = true
+			s.Publish(ctx, qg.EventGameStarted{})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandPauseGame](func(ctx context.Context, _ qg.CommandPauseGame) error {
+			s.Publish(ctx, qg.EventGamePaused{PlayerName: PlayerFromContext(ctx).Name})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandResumeGame](func(ctx context.Context, _ qg.CommandResumeGame) error {
+			s.Publish(ctx, qg.EventGameResumed{PlayerName: PlayerFromContext(ctx).Name})
+			// Players may have missed events while they waited.
+			s.Publish(ctx, s.snapshot(game))
+			return nil
+		}),
 		cando.React[any, cando.EndReaction](func(ctx context.Context, _ any) error {
+			s.ended = true
+			leaderboard := game.Leaderboard()
 			s.Publish(ctx, qg.EventGameEnded{
-				Leaderboard: game.Leaderboard(),
+				Leaderboard: leaderboard,
 			})
+			if s.onEnd != nil {
+				return s.onEnd(ctx, leaderboard)
+			}
 			return nil
 		}),
 	)
 
 	mdata.States = append(mdata.States, s.states...)
+	mdata.Superstate = append(mdata.Superstate, s.superstate...)
 	mdata.Reactors = append(mdata.Reactors, s.reactors...)
 
 	m := cando.NewMachine(mdata)
@@ -204,23 +464,131 @@ func (s *MachineState) StartMachine(ctx context.Context, game GameManager) (*Mac
 		return nil, err
 	}
 
-	return &Machine{s, m}, nil
+	host := &PlayerHandle{
+		Publisher: pubsub.NewPublisher(),
+		PlayerState: &PlayerState{
+			Name:    hostName,
+			IsAdmin: true,
+		},
+		outbox: &s.outbox,
+	}
+	host.updateTopics()
+
+	return &Machine{s: s, m: m, host: host}, nil
 }
 
+// hostName is the name of the bot that hosts practice games.
+const hostName qg.PlayerName = "Host"
+
 // Machine is a running game state machine.
 type Machine struct {
-	s *MachineState
-	m *cando.Machine
+	s     *MachineState
+	m     *cando.Machine
+	host  *PlayerHandle
+	inbox queue
+}
+
+// AutoBeginner is a game that can begin on its own, without an admin sending
+// CommandBeginGame.
+type AutoBeginner interface {
+	// AutoBeginAt makes the game begin once n players, excluding admins, have
+	// joined. It must be called before any player joins.
+	AutoBeginAt(n int)
+}
+
+var _ AutoBeginner = (*Machine)(nil)
+
+// AutoBeginAt implements AutoBeginner.
+func (m *Machine) AutoBeginAt(n int) {
+	m.s.autoBegin = n
+}
+
+// Pauser is a game that can be paused from outside of its machine.
+type Pauser interface {
+	// Pause pauses the game without publishing any event. An admin has to
+	// send CommandResumeGame before players can do anything but join.
+	Pause()
+}
+
+var _ Pauser = (*Machine)(nil)
+
+// Pause implements Pauser.
+func (m *Machine) Pause() {
+	m.s.Pause()
+}
+
+// Input feeds an input into the machine outside of any player's command. It
+// is used by games to drive the machine on their own, e.g. once a timer fires.
+// The context will not contain a player handle. The input waits in the inbox
+// behind any other command, so Input must not be called from within a state or
+// a reactor, which would wait on itself. While the game is paused, the input
+// is held back until the game is resumed.
+func (m *Machine) Input(ctx context.Context, data any) error {
+	return m.input(ctx, data)
+}
+
+// HostInput feeds a command into the machine on behalf of the host of a
+// practice game. The host is a bot that acts as an admin, but it never joins
+// the game, so it is not a player and gets no events. Like Input, it must not
+// be called from within a state or a reactor.
+func (m *Machine) HostInput(ctx context.Context, cmd qg.ICommand) error {
+	ctx = injectPlayerHandler(ctx, m.host)
+	return m.input(ctx, cmd)
+}
+
+// input feeds data into the machine once it is free. Timers may fire just as
+// the game is paused, so the paused state is checked again once the input is
+// about to be handled. If the game is paused by then, the input is held back
+// in a frozen timer until the game is resumed.
+func (m *Machine) input(ctx context.Context, data any) error {
+	return m.do(ctx, func() error {
+		if m.s.IsPaused() {
+			m.s.AfterFunc(0, func() {
+				if err := m.input(ctx, data); err != nil {
+					log.Printf("cannot feed held back input %T: %v", data, err)
+				}
+			})
+			return nil
+		}
+		return m.m.Change(ctx, data)
+	})
+}
+
+// Inspector is a running game whose state machine can be inspected.
+type Inspector interface {
+	// Inspect returns a snapshot of the game's state machine.
+	Inspect(ctx context.Context) (cando.Inspection, error)
+}
+
+var _ Inspector = (*Machine)(nil)
+
+// Inspect implements Inspector.
+func (m *Machine) Inspect(ctx context.Context) (cando.Inspection, error) {
+	var i cando.Inspection
+	err := m.do(ctx, func() (err error) {
+		i, err = m.m.Inspect(ctx)
+		return
+	})
+	return i, err
 }
 
 // NewCommandHandler creates a new command handler for a player.
 func (m *Machine) NewCommandHandler(ctx context.Context, evs chan<- qg.IEvent) (qg.CommandHandler, error) {
+	// The connection may have its own idea of what to do once it falls
+	// behind.
+	opts := pubsub.SubscribeOptsFromContext(ctx)
+
 	pubsub := pubsub.NewPublisher()
-	pubsub.Subscribe(evs)
+	pubsub.SetGuard(guardAdminData)
+	pubsub.SubscribeWithOpts(evs, opts)
+
+	handle := &PlayerHandle{Publisher: pubsub, outbox: &m.s.outbox}
+	handle.updateTopics()
+
 	m.s.Publisher.SubscribePublisher(pubsub)
 
 	return &playerCommandHandler{
-		handle:  &PlayerHandle{Publisher: pubsub},
+		handle:  handle,
 		machine: m,
 	}, nil
 }
@@ -232,7 +600,42 @@ type playerCommandHandler struct {
 
 func (h *playerCommandHandler) HandleCommand(ctx context.Context, cmd qg.ICommand) error {
 	ctx = injectPlayerHandler(ctx, h.handle)
-	return h.machine.m.Change(ctx, cmd)
+
+	return h.machine.do(ctx, func() error {
+		switch cmd.(type) {
+		case qg.CommandJoinGame, qg.CommandPauseGame, qg.CommandResumeGame, qg.CommandRequestSnapshot:
+			// Players may always join or catch up, even while the game is
+			// paused, and only admins may pause or resume it, which the
+			// machine checks.
+		default:
+			if h.machine.s.IsPaused() && !h.isAdmin() {
+				return qg.NewCodedError(qg.ErrorCodeInvalidState, "the game is paused")
+			}
+		}
+
+		if err := h.machine.m.Change(ctx, cmd); err != nil {
+			if errors.Is(err, cando.ErrNotAllowed) {
+				return qg.WithErrorCode(err, qg.ErrorCodeInvalidState)
+			}
+			return err
+		}
+
+		if !h.machine.s.IsPaused() && h.machine.s.autoBeginDue.CompareAndSwap(true, false) {
+			// This player was the last one that we were waiting for, or an
+			// admin resumed the game after they joined. The game begins
+			// within the same message, so no other command can sneak in
+			// before it.
+			if err := h.machine.m.Change(ctx, autoBeginGame{}); err != nil {
+				return errors.Wrap(err, "cannot begin the game")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (h *playerCommandHandler) isAdmin() bool {
+	return h.handle.PlayerState != nil && h.handle.IsAdmin
 }
 
 func (h *playerCommandHandler) Close() error {
@@ -0,0 +1,222 @@
+package poll
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// Storer is a storage interface for polls.
+type Storer interface {
+	qg.GameStorer
+	// SetPollResults sets the results of the given poll, replacing the
+	// previous ones.
+	SetPollResults(ctx context.Context, id qg.GameID, results []qg.PollResult) error
+	// PollResults returns the results of the given poll. Only the questions
+	// that were closed are included.
+	PollResults(ctx context.Context, id qg.GameID) ([]qg.PollResult, error)
+}
+
+// Game is in charge of creating and managing new polls.
+type Game struct {
+	store Storer
+}
+
+// New creates a new Game instance.
+func New(store Storer) Game {
+	return Game{store}
+}
+
+// GameState is the current state of a poll.
+type GameState struct {
+	// Question is the index of the current question.
+	Question int32
+	// Votes maps each player to the choices that they voted for in the
+	// current question.
+	Votes map[qg.PlayerName][]int32
+	// Results holds the results of the closed questions.
+	Results []qg.PollResult
+}
+
+type gameManager struct {
+	store   Storer
+	state   *GameState
+	machine *games.MachineState
+
+	data qg.PollGameData
+	id   qg.GameID
+}
+
+func newGameManager(store Storer, id qg.GameID, data qg.PollGameData, mstate *games.MachineState) *gameManager {
+	return &gameManager{
+		store:   store,
+		state:   &GameState{Question: -1},
+		machine: mstate,
+		data:    data,
+		id:      id,
+	}
+}
+
+func (m *gameManager) ID() qg.GameID      { return m.id }
+func (m *gameManager) Data() qg.IGameData { return qg.GameDataPoll{Data: m.data} }
+
+func (m *gameManager) CompareGamePassword(ctx context.Context, input string) (bool, error) {
+	return m.store.CompareGamePassword(ctx, m.id, input)
+}
+
+// Snapshot implements games.GameManager.
+func (m *gameManager) Snapshot() qg.IGameSnapshot { return nil }
+
+// Leaderboard returns an empty leaderboard, since polls are not scored.
+func (m *gameManager) Leaderboard() qg.Leaderboard {
+	return qg.Leaderboard{}
+}
+
+func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	return m.beginQuestion(0), nil
+}
+
+func (m *gameManager) question() qg.PollQuestion {
+	return m.data.Questions[m.state.Question]
+}
+
+func (m *gameManager) beginQuestion(question int32) cando.NextStates {
+	m.state.Question = question
+	m.state.Votes = make(map[qg.PlayerName][]int32)
+
+	return cando.NextStates{
+		cando.Next[qg.CommandPollNextQuestion](),
+		cando.Next[qg.CommandPollVote](),
+	}
+}
+
+func (m *gameManager) vote(ctx context.Context, cmd qg.CommandPollVote) (cando.NextStates, error) {
+	self := games.PlayerFromContext(ctx)
+
+	question := m.question()
+
+	if len(cmd.Choices) == 0 {
+		return nil, errors.New("must vote for at least one choice")
+	}
+	if len(cmd.Choices) > 1 && (question.Multiple == nil || !*question.Multiple) {
+		return nil, errors.New("this question only allows one choice")
+	}
+
+	seen := make(map[int32]bool, len(cmd.Choices))
+	for _, choice := range cmd.Choices {
+		if choice < 0 || int(choice) >= len(question.Choices) {
+			return nil, fmt.Errorf("invalid choice index: %d", choice)
+		}
+		if seen[choice] {
+			return nil, fmt.Errorf("choice %d is voted for more than once", choice)
+		}
+		seen[choice] = true
+	}
+
+	m.state.Votes[self.Name] = cmd.Choices
+
+	return cando.NextStates{
+		cando.Next[qg.CommandPollVote](),
+		cando.Next[qg.CommandPollNextQuestion](),
+	}, nil
+}
+
+func (m *gameManager) nextQuestion(ctx context.Context, _ qg.CommandPollNextQuestion) (cando.NextStates, error) {
+	// Save the results as we go, so that they can be exported even if the
+	// poll is never finished.
+	results := append(m.state.Results, m.result())
+	if err := m.store.SetPollResults(ctx, m.id, results); err != nil {
+		return nil, errors.Wrap(err, "failed to save poll results")
+	}
+	m.state.Results = results
+
+	if int(m.state.Question)+1 >= len(m.data.Questions) {
+		// That was the last question, so end the poll.
+		return nil, nil
+	}
+
+	return m.beginQuestion(m.state.Question + 1), nil
+}
+
+// result aggregates the votes of the current question.
+func (m *gameManager) result() qg.PollResult {
+	question := m.question()
+
+	votes := make([]int32, len(question.Choices))
+	for _, choices := range m.state.Votes {
+		for _, choice := range choices {
+			votes[choice]++
+		}
+	}
+
+	return qg.PollResult{
+		Question: question.Question,
+		Choices:  question.Choices,
+		Votes:    votes,
+		Voters:   int32(len(m.state.Votes)),
+	}
+}
+
+func validateData(data qg.PollGameData) error {
+	if len(data.Questions) == 0 {
+		return errors.New("no questions found, must have at least one")
+	}
+
+	for i, q := range data.Questions {
+		if len(q.Choices) < 2 {
+			return fmt.Errorf("question %d has %d choices, must have at least two", i+1, len(q.Choices))
+		}
+	}
+
+	return nil
+}
+
+// CreateGame implements the games.GameCreator.
+func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error) {
+	pollData, ok := data.(qg.GameDataPoll)
+	if !ok {
+		return nil, errors.Errorf("invalid game data type: %T", data)
+	}
+
+	if err := validateData(pollData.Data); err != nil {
+		return nil, errors.Wrap(err, "invalid game data")
+	}
+
+	s := games.NewMachineState(ctx)
+	m := newGameManager(g.store, id, pollData.Data, s)
+
+	s.AddReactors(
+		cando.React[any, qg.CommandPollNextQuestion](func(ctx context.Context, prev any) error {
+			if _, ok := prev.(qg.CommandPollVote); ok {
+				// Still voting on the same question.
+				return nil
+			}
+			question := m.question()
+			s.Publish(ctx, qg.EventPollBeginQuestion{
+				Index:    m.state.Question,
+				Question: question.Question,
+				Choices:  question.Choices,
+				Multiple: question.Multiple != nil && *question.Multiple,
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandPollVote](func(ctx context.Context, _ qg.CommandPollVote) error {
+			s.Publish(ctx, qg.EventPollResults{
+				Index:  m.state.Question,
+				Result: m.result(),
+			})
+			return nil
+		}),
+	)
+
+	s.AddState(
+		cando.Guarded(cando.State(m.vote), games.NoAdmins("admins cannot vote")),
+		cando.Guarded(cando.State(m.nextQuestion), games.OnlyAdmins("only admins can push the next question")),
+	)
+
+	return s.StartMachine(ctx, m)
+}
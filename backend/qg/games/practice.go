@@ -0,0 +1,107 @@
+package games
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// PracticeCreator is a GameCreator whose games can also be practiced alone.
+// See CommandPracticeGame.
+type PracticeCreator interface {
+	GameCreator
+	// CreatePractice creates a practice game for a single player. There is no
+	// admin in a practice game, so the game must drive the machine on its own
+	// using Machine.Input and Machine.HostInput. The game begins as soon as
+	// the player joins.
+	CreatePractice(ctx context.Context, id qg.GameID, data qg.IGameData) (*Machine, error)
+}
+
+// practiceGame creates a private practice game from the game with the given
+// ID. The score of the player is recorded as their personal best once the
+// game ends.
+func (g *Manager) practiceGame(ctx context.Context, id qg.GameID) (*Machine, error) {
+	store, ok := g.store.(qg.PracticeStorer)
+	if !ok {
+		return nil, errors.New("store does not support practice games")
+	}
+
+	g.gamesMut.RLock()
+	opensAt := g.opensAt[id]
+	g.gamesMut.RUnlock()
+
+	// Practicing a game gives its questions away.
+	if time.Now().Before(opensAt) {
+		return nil, qg.CodedErrorf(qg.ErrorCodeInvalidState, "the lobby opens at %s", opensAt.UTC().Format(time.RFC3339))
+	}
+
+	data, err := store.GameData(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get game with ID %q", id)
+	}
+
+	gameCreator, err := g.gameCreator(data)
+	if err != nil {
+		return nil, err
+	}
+
+	practiceCreator, ok := gameCreator.(PracticeCreator)
+	if !ok {
+		return nil, qg.CodedErrorf(qg.ErrorCodeInvalidRequest, "game type %q cannot be practiced", qg.GameTypeFromData(data))
+	}
+
+	game, err := practiceCreator.CreatePractice(ctx, id, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create practice game")
+	}
+
+	game.AutoBeginAt(1)
+	game.s.onEnd = func(ctx context.Context, leaderboard qg.Leaderboard) error {
+		players := game.s.Contestants()
+		if len(players) != 1 {
+			return nil
+		}
+
+		ev, err := recordPracticeScore(ctx, store, id, players[0], leaderboard)
+		if err != nil {
+			return errors.Wrap(err, "cannot record practice score")
+		}
+
+		// The personal best is nobody else's business.
+		game.s.PublishTo(ctx, Player(players[0]), ev)
+		return nil
+	}
+
+	return game, nil
+}
+
+func recordPracticeScore(ctx context.Context, store qg.PracticeStorer, id qg.GameID, player qg.PlayerName, leaderboard qg.Leaderboard) (qg.EventPracticeEnded, error) {
+	var score float32
+	for _, entry := range leaderboard {
+		if entry.PlayerName == player {
+			score = entry.Score
+		}
+	}
+
+	best, err := store.PersonalBest(ctx, id, player)
+	if err != nil {
+		return qg.EventPracticeEnded{}, err
+	}
+
+	if err := store.RecordPracticeScore(ctx, id, player, score); err != nil {
+		return qg.EventPracticeEnded{}, err
+	}
+
+	ev := qg.EventPracticeEnded{
+		Score:           score,
+		PersonalBest:    score,
+		NewPersonalBest: best == nil || score > *best,
+	}
+	if !ev.NewPersonalBest {
+		ev.PersonalBest = *best
+	}
+
+	return ev, nil
+}
@@ -0,0 +1,91 @@
+package quiz
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func validateEstimate(q qg.QuizQuestionEstimate) error {
+	if math.IsNaN(q.Answer) || math.IsInf(q.Answer, 0) {
+		return errors.New("answer must be a finite number")
+	}
+
+	if q.Scoring != nil {
+		switch *q.Scoring {
+		case qg.QuizEstimateScoringRank, qg.QuizEstimateScoringLinear:
+		default:
+			return errors.Errorf("unknown scoring %q", *q.Scoring)
+		}
+	}
+
+	if q.Tolerance != nil && !(*q.Tolerance > 0) {
+		return errors.New("tolerance must be positive")
+	}
+
+	return nil
+}
+
+// scoreEstimate scores the guesses to an estimation question, each of which
+// is worth up to points. It fills in the distance and points of each guess and
+// sorts the guesses from the closest to the farthest. Guesses that are equally
+// close keep their order.
+func scoreEstimate(q qg.QuizQuestionEstimate, guesses []qg.QuizEstimateGuess, points float32) {
+	for i := range guesses {
+		guesses[i].Distance = math.Abs(guesses[i].Guess - q.Answer)
+	}
+
+	sort.SliceStable(guesses, func(i, j int) bool {
+		return guesses[i].Distance < guesses[j].Distance
+	})
+
+	scoring := qg.QuizEstimateScoringRank
+	if q.Scoring != nil {
+		scoring = *q.Scoring
+	}
+
+	switch scoring {
+	case qg.QuizEstimateScoringRank:
+		scoreEstimateRank(guesses, points)
+	case qg.QuizEstimateScoringLinear:
+		tolerance := math.Abs(q.Answer)
+		if q.Tolerance != nil {
+			tolerance = *q.Tolerance
+		}
+		scoreEstimateLinear(guesses, points, tolerance)
+	}
+}
+
+// scoreEstimateRank scores sorted guesses by their rank. Equally close
+// guesses share the same rank.
+func scoreEstimateRank(guesses []qg.QuizEstimateGuess, points float32) {
+	ranks := make([]int, len(guesses))
+	var nranks int
+	for i := range guesses {
+		if i == 0 || guesses[i].Distance != guesses[i-1].Distance {
+			nranks++
+		}
+		ranks[i] = nranks - 1
+	}
+
+	for i := range guesses {
+		guesses[i].Points = points * float32(nranks-ranks[i]) / float32(nranks)
+	}
+}
+
+// scoreEstimateLinear scores guesses linearly by their distance, down to no
+// points at the tolerance. A tolerance of 0 only rewards exact guesses.
+func scoreEstimateLinear(guesses []qg.QuizEstimateGuess, points float32, tolerance float64) {
+	for i := range guesses {
+		switch {
+		case guesses[i].Distance == 0:
+			guesses[i].Points = points
+		case guesses[i].Distance >= tolerance:
+			guesses[i].Points = 0
+		default:
+			guesses[i].Points = points * float32(1-guesses[i].Distance/tolerance)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package quiz
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestScoreEstimate(t *testing.T) {
+	// guess is a guess along with its expected score.
+	type guess struct {
+		player qg.PlayerName
+		guess  float64
+		points float32
+	}
+
+	tests := []struct {
+		name     string
+		question qg.QuizQuestionEstimate
+		guesses  []guess
+		expect   []guess
+	}{
+		{
+			name:     "rank",
+			question: qg.QuizQuestionEstimate{Answer: 100},
+			guesses: []guess{
+				{player: "Alice", guess: 150},
+				{player: "Bob", guess: 90},
+				{player: "Carol", guess: 1000},
+				{player: "Dave", guess: 110},
+			},
+			expect: []guess{
+				{player: "Bob", guess: 90, points: 100},
+				{player: "Dave", guess: 110, points: 100},
+				{player: "Alice", guess: 150, points: 200.0 / 3},
+				{player: "Carol", guess: 1000, points: 100.0 / 3},
+			},
+		},
+		{
+			name: "linear",
+			question: qg.QuizQuestionEstimate{
+				Answer:  100,
+				Scoring: ptr(qg.QuizEstimateScoringLinear),
+			},
+			guesses: []guess{
+				{player: "Alice", guess: 250},
+				{player: "Bob", guess: 75},
+				{player: "Carol", guess: 100},
+			},
+			expect: []guess{
+				{player: "Carol", guess: 100, points: 100},
+				{player: "Bob", guess: 75, points: 75},
+				{player: "Alice", guess: 250, points: 0},
+			},
+		},
+		{
+			name: "linear_tolerance",
+			question: qg.QuizQuestionEstimate{
+				Answer:    30_000_000,
+				Scoring:   ptr(qg.QuizEstimateScoringLinear),
+				Tolerance: ptr(10_000_000.0),
+			},
+			guesses: []guess{
+				{player: "Alice", guess: 25_000_000},
+				{player: "Bob", guess: 42_000_000},
+			},
+			expect: []guess{
+				{player: "Alice", guess: 25_000_000, points: 50},
+				{player: "Bob", guess: 42_000_000, points: 0},
+			},
+		},
+		{
+			name: "linear_zero",
+			question: qg.QuizQuestionEstimate{
+				Answer:  0,
+				Scoring: ptr(qg.QuizEstimateScoringLinear),
+			},
+			guesses: []guess{
+				{player: "Alice", guess: 1},
+				{player: "Bob", guess: 0},
+			},
+			expect: []guess{
+				{player: "Bob", guess: 0, points: 100},
+				{player: "Alice", guess: 1, points: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guesses := make([]qg.QuizEstimateGuess, len(test.guesses))
+			for i, g := range test.guesses {
+				guesses[i] = qg.QuizEstimateGuess{PlayerName: g.player, Guess: g.guess}
+			}
+
+			scoreEstimate(test.question, guesses, 100)
+
+			got := make([]guess, len(guesses))
+			for i, g := range guesses {
+				assert.Equal(t, g.Distance, abs(g.Guess-test.question.Answer))
+				got[i] = guess{player: g.PlayerName, guess: g.Guess, points: g.Points}
+			}
+
+			assert.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestValidateEstimate(t *testing.T) {
+	assert.NoError(t, validateEstimate(qg.QuizQuestionEstimate{Answer: 42}))
+	assert.Error(t, validateEstimate(qg.QuizQuestionEstimate{
+		Answer:    42,
+		Tolerance: ptr(0.0),
+	}))
+	assert.Error(t, validateEstimate(qg.QuizQuestionEstimate{
+		Answer:  42,
+		Scoring: ptr(qg.QuizEstimateScoring("closest")),
+	}))
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
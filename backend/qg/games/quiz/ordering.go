@@ -0,0 +1,134 @@
+package quiz
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func validateOrdering(q qg.QuizQuestionOrdering) error {
+	if len(q.Items) < 2 {
+		return fmt.Errorf("has %d items, must have at least two", len(q.Items))
+	}
+
+	seen := make(map[string]bool, len(q.Items))
+	for _, item := range q.Items {
+		if seen[item] {
+			return fmt.Errorf("item %q is listed more than once", item)
+		}
+		seen[item] = true
+	}
+
+	return nil
+}
+
+// shuffleItems returns the order in which the n items of an ordering question
+// are shown as choices. The i-th choice is the item at index order[i]. The
+// items are never shown in the right order.
+func shuffleItems(n int) []int32 {
+	order := make([]int32, n)
+	for i := range order {
+		order[i] = int32(i)
+	}
+
+	for inOrder(order) {
+		rand.Shuffle(n, func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+
+	return order
+}
+
+func inOrder(order []int32) bool {
+	for i, item := range order {
+		if item != int32(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// orderingChoices returns the choices of an ordering question whose items are
+// shown in the given order.
+func orderingChoices(q qg.QuizQuestionOrdering, order []int32) []string {
+	choices := make([]string, len(order))
+	for i, item := range order {
+		choices[i] = q.Items[item]
+	}
+	return choices
+}
+
+// orderingAnswer returns the indices of the choices in the right order when
+// the items are shown in the given order.
+func orderingAnswer(order []int32) []int32 {
+	answer := make([]int32, len(order))
+	for choice, item := range order {
+		answer[item] = int32(choice)
+	}
+	return answer
+}
+
+// checkOrder checks that an order answer lists each of the n choices exactly
+// once.
+func checkOrder(answer qg.QuizAnswerOrder, n int) error {
+	if len(answer.Order) != n {
+		return fmt.Errorf("order has %d choices, must have %d", len(answer.Order), n)
+	}
+
+	seen := make([]bool, n)
+	for _, choice := range answer.Order {
+		if choice < 0 || int(choice) >= n {
+			return fmt.Errorf("invalid choice index: %d", choice)
+		}
+		if seen[choice] {
+			return fmt.Errorf("choice %d is listed more than once", choice)
+		}
+		seen[choice] = true
+	}
+
+	return nil
+}
+
+// kendallTau returns the Kendall tau distance between two orderings of the
+// same items, which is the number of pairs of items that are in a different
+// order.
+func kendallTau(a, b []int32) int32 {
+	// rank maps each item to its position in a.
+	rank := make(map[int32]int, len(a))
+	for i, item := range a {
+		rank[item] = i
+	}
+
+	var distance int32
+	for i := range b {
+		for j := i + 1; j < len(b); j++ {
+			if rank[b[i]] > rank[b[j]] {
+				distance++
+			}
+		}
+	}
+
+	return distance
+}
+
+// scoreOrdering scores the answers to an ordering question, each of which is
+// worth up to points. answer is the right order of the choices. It fills in
+// the distance and points of each guess and sorts the guesses from the
+// closest to the farthest. The points drop linearly with the distance, so an
+// answer in the reverse order gets no points.
+func scoreOrdering(answer []int32, guesses []qg.QuizOrderingGuess, points float32) {
+	n := len(answer)
+	pairs := n * (n - 1) / 2
+
+	for i := range guesses {
+		guesses[i].Distance = kendallTau(answer, guesses[i].Order)
+		guesses[i].Points = points * float32(pairs-int(guesses[i].Distance)) / float32(pairs)
+	}
+
+	sort.SliceStable(guesses, func(i, j int) bool {
+		return guesses[i].Distance < guesses[j].Distance
+	})
+}
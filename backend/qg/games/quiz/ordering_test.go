@@ -0,0 +1,75 @@
+package quiz
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestKendallTau(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []int32
+		distance int32
+	}{
+		{"same", []int32{0, 1, 2, 3}, []int32{0, 1, 2, 3}, 0},
+		{"one_swap", []int32{0, 1, 2, 3}, []int32{1, 0, 2, 3}, 1},
+		{"reversed", []int32{0, 1, 2, 3}, []int32{3, 2, 1, 0}, 6},
+		{"shuffled", []int32{2, 0, 3, 1}, []int32{2, 3, 0, 1}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.distance, kendallTau(test.a, test.b))
+			assert.Equal(t, test.distance, kendallTau(test.b, test.a))
+		})
+	}
+}
+
+func TestScoreOrdering(t *testing.T) {
+	guesses := []qg.QuizOrderingGuess{
+		{PlayerName: "Alice", Order: []int32{3, 2, 1, 0}},
+		{PlayerName: "Bob", Order: []int32{1, 0, 2, 3}},
+		{PlayerName: "Carol", Order: []int32{0, 1, 2, 3}},
+		{PlayerName: "Dave", Order: []int32{0, 2, 3, 1}},
+	}
+
+	scoreOrdering([]int32{0, 1, 2, 3}, guesses, 60)
+
+	assert.Equal(t, []qg.QuizOrderingGuess{
+		{PlayerName: "Carol", Order: []int32{0, 1, 2, 3}, Distance: 0, Points: 60},
+		{PlayerName: "Bob", Order: []int32{1, 0, 2, 3}, Distance: 1, Points: 50},
+		{PlayerName: "Dave", Order: []int32{0, 2, 3, 1}, Distance: 2, Points: 40},
+		{PlayerName: "Alice", Order: []int32{3, 2, 1, 0}, Distance: 6, Points: 0},
+	}, guesses)
+}
+
+func TestShuffleItems(t *testing.T) {
+	q := qg.QuizQuestionOrdering{Items: []string{"C", "Go", "Rust"}}
+
+	for i := 0; i < 20; i++ {
+		order := shuffleItems(len(q.Items))
+		assert.False(t, inOrder(order))
+
+		// The answer must put the shuffled choices back in order.
+		choices := orderingChoices(q, order)
+		answer := orderingAnswer(order)
+		for j, choice := range answer {
+			assert.Equal(t, q.Items[j], choices[choice])
+		}
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	assert.NoError(t, checkOrder(qg.QuizAnswerOrder{Order: []int32{2, 0, 1}}, 3))
+	assert.Error(t, checkOrder(qg.QuizAnswerOrder{Order: []int32{0, 1}}, 3))
+	assert.Error(t, checkOrder(qg.QuizAnswerOrder{Order: []int32{0, 1, 3}}, 3))
+	assert.Error(t, checkOrder(qg.QuizAnswerOrder{Order: []int32{0, 1, 1}}, 3))
+}
+
+func TestValidateOrdering(t *testing.T) {
+	assert.NoError(t, validateOrdering(qg.QuizQuestionOrdering{Items: []string{"C", "Go"}}))
+	assert.Error(t, validateOrdering(qg.QuizQuestionOrdering{Items: []string{"C"}}))
+	assert.Error(t, validateOrdering(qg.QuizQuestionOrdering{Items: []string{"C", "Go", "C"}}))
+}
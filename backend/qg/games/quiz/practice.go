@@ -0,0 +1,31 @@
+package quiz
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// PracticeRevealTime is how long the host of a practice game waits after a
+// question is revealed before moving on to the next one.
+const PracticeRevealTime = 5 * time.Second
+
+// CreatePractice implements games.PracticeCreator. Quizzes already judge
+// answers and time out questions on their own, so the host only moves on to
+// the next question once the player has seen the result.
+func (g Game) CreatePractice(ctx context.Context, id qg.GameID, data qg.IGameData) (*games.Machine, error) {
+	return g.createGame(ctx, id, data, true)
+}
+
+// scheduleNextQuestion makes the host move on to the next question after
+// PracticeRevealTime.
+func (m *gameManager) scheduleNextQuestion() {
+	m.hostTimer = m.machine.AfterFunc(PracticeRevealTime, func() {
+		if err := m.running.HostInput(context.Background(), qg.CommandQuizNextQuestion{}); err != nil {
+			log.Println("quiz: host cannot move on to the next question:", err)
+		}
+	})
+}
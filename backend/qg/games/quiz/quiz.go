@@ -0,0 +1,583 @@
+package quiz
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// DefaultTimeLimit is the default time limit for each question.
+const DefaultTimeLimit = 20 * time.Second
+
+// DefaultPoints is the default number of points for each correct answer.
+const DefaultPoints = 100
+
+// trueFalseChoices are the choices of a true or false question.
+var trueFalseChoices = []string{"True", "False"}
+
+// Game is in charge of creating and managing new quizzes.
+type Game struct {
+	store qg.GameStorer
+}
+
+// New creates a new Game instance.
+func New(store qg.GameStorer) Game {
+	return Game{store}
+}
+
+// GameState is the current state of a quiz.
+type GameState struct {
+	// Question is the index of the current question.
+	Question int32
+	// Order is the order in which the items of the current ordering question
+	// are shown as choices. See shuffleItems.
+	Order []int32
+	// Answers maps each player to their answer to the current question.
+	Answers map[qg.PlayerName]qg.IQuizAnswer
+	// Scores maps each player to their total score.
+	Scores map[qg.PlayerName]float32
+	// Result is the result of the current question once it is revealed.
+	Result qg.IQuizResult
+	// Correct lists the players who got the current question right once it is
+	// revealed.
+	Correct []qg.PlayerName
+	// Eliminated lists the players who were eliminated by the current
+	// question of a survival quiz once it is revealed.
+	Eliminated []qg.PlayerName
+	// Survived maps each player to the number of questions that they survived
+	// in a survival quiz.
+	Survived map[qg.PlayerName]int32
+}
+
+// questionTimedOut is an internal input that is fed into the machine once the
+// time limit of a question is up.
+type questionTimedOut struct {
+	question int32
+}
+
+type gameManager struct {
+	store   qg.GameStorer
+	state   *GameState
+	machine *games.MachineState
+	running *games.Machine
+	timer   *games.Timer
+	// hostTimer moves on to the next question in a practice game.
+	hostTimer *games.Timer
+
+	data      qg.QuizGameData
+	id        qg.GameID
+	timeLimit time.Duration
+	points    float32
+	// questions are the questions that may be played, including the sudden
+	// death questions of a survival quiz.
+	questions []qg.QuizQuestion
+	// practice is true if the game is hosted by a bot. See CreatePractice.
+	practice bool
+}
+
+func newGameManager(store qg.GameStorer, id qg.GameID, data qg.QuizGameData, mstate *games.MachineState) *gameManager {
+	return &gameManager{
+		store: store,
+		state: &GameState{
+			Question: -1,
+			Scores:   make(map[qg.PlayerName]float32),
+			Survived: make(map[qg.PlayerName]int32),
+		},
+		machine:   mstate,
+		data:      data,
+		id:        id,
+		questions: data.Questions,
+	}
+}
+
+func (m *gameManager) ID() qg.GameID      { return m.id }
+func (m *gameManager) Data() qg.IGameData { return qg.GameDataQuiz{Data: m.data} }
+
+func (m *gameManager) CompareGamePassword(ctx context.Context, input string) (bool, error) {
+	return m.store.CompareGamePassword(ctx, m.id, input)
+}
+
+// Snapshot implements games.GameManager.
+func (m *gameManager) Snapshot() qg.IGameSnapshot { return nil }
+
+func (m *gameManager) Leaderboard() qg.Leaderboard {
+	players := m.machine.Contestants()
+
+	leaderboard := make(qg.Leaderboard, len(players))
+	for i, name := range players {
+		leaderboard[i] = qg.LeaderboardEntry{
+			PlayerName: name,
+			Score:      m.state.Scores[name],
+		}
+		if m.isSurvival() {
+			// Survival quizzes rank players by the rounds they survived.
+			leaderboard[i].Score = float32(m.state.Survived[name])
+		}
+	}
+
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		return leaderboard[i].Score > leaderboard[j].Score
+	})
+
+	return leaderboard
+}
+
+func (m *gameManager) BeginGame(ctx context.Context) (cando.NextStates, error) {
+	return m.beginQuestion(0), nil
+}
+
+func (m *gameManager) question() qg.IQuizQuestion {
+	return m.questions[m.state.Question].Value
+}
+
+func (m *gameManager) beginQuestion(question int32) cando.NextStates {
+	m.state.Question = question
+	m.state.Answers = make(map[qg.PlayerName]qg.IQuizAnswer)
+
+	m.state.Order = nil
+	if q, ok := m.question().(qg.QuizQuestionOrdering); ok {
+		m.state.Order = shuffleItems(len(q.Items))
+	}
+
+	m.timer = m.machine.AfterFunc(m.timeLimit, func() {
+		if err := m.running.Input(context.Background(), questionTimedOut{question}); err != nil {
+			log.Println("quiz: cannot time out question:", err)
+		}
+	})
+
+	return answeringStates()
+}
+
+// answeringStates returns the next states of a question that is still being
+// answered.
+func answeringStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandQuizAnswer](),
+		cando.Next[questionTimedOut](),
+	}
+}
+
+// revealStates returns the next states once the current question has been
+// revealed.
+func revealStates() cando.NextStates {
+	return cando.NextStates{
+		cando.Next[qg.CommandQuizNextQuestion](),
+	}
+}
+
+func (m *gameManager) answer(ctx context.Context, cmd qg.CommandQuizAnswer) (cando.NextStates, error) {
+	self := games.PlayerFromContext(ctx)
+	if self.Eliminated {
+		return nil, errors.New("you have been eliminated")
+	}
+
+	if _, ok := m.state.Answers[self.Name]; ok {
+		return nil, errors.New("you already answered this question")
+	}
+
+	if err := checkAnswer(m.question(), cmd.Answer.Value); err != nil {
+		return nil, err
+	}
+
+	m.state.Answers[self.Name] = cmd.Answer.Value
+
+	if len(m.state.Answers) < len(m.machine.Survivors()) {
+		return answeringStates(), nil
+	}
+
+	// Everyone has answered, so there's no need to wait for the time limit.
+	m.timer.Stop()
+	m.endQuestion()
+
+	return revealStates(), nil
+}
+
+func (m *gameManager) timeOut(ctx context.Context, input questionTimedOut) (cando.NextStates, error) {
+	if input.question != m.state.Question {
+		return nil, fmt.Errorf("question %d has already ended", input.question)
+	}
+
+	m.endQuestion()
+	return revealStates(), nil
+}
+
+func (m *gameManager) nextQuestion(ctx context.Context, _ qg.CommandQuizNextQuestion) (cando.NextStates, error) {
+	if m.hostTimer != nil {
+		m.hostTimer.Stop()
+	}
+
+	if m.isOver() {
+		return nil, nil
+	}
+
+	return m.beginQuestion(m.state.Question + 1), nil
+}
+
+// isOver returns true if the quiz ends after the current question.
+func (m *gameManager) isOver() bool {
+	if m.isSurvival() && len(m.machine.Survivors()) <= 1 {
+		return true
+	}
+	return int(m.state.Question)+1 >= len(m.questions)
+}
+
+// endQuestion scores the current question and eliminates players who failed
+// it in a survival quiz.
+func (m *gameManager) endQuestion() {
+	m.scoreQuestion()
+	if m.isSurvival() {
+		m.eliminate()
+	}
+	if m.practice {
+		m.scheduleNextQuestion()
+	}
+}
+
+// scoreQuestion rewards the players who answered the current question
+// correctly and records its result.
+func (m *gameManager) scoreQuestion() {
+	players := m.machine.Survivors()
+
+	points := make(map[qg.PlayerName]float32)
+	correct := []qg.PlayerName{}
+
+	switch question := m.question().(type) {
+	case qg.QuizQuestionMultipleChoice, qg.QuizQuestionTrueFalse:
+		answer := correctChoice(question)
+		distribution := make([]int32, len(choices(question)))
+
+		for _, name := range players {
+			choice, ok := m.state.Answers[name].(qg.QuizAnswerChoice)
+			if !ok {
+				continue
+			}
+			distribution[choice.Choice]++
+			if choice.Choice == answer {
+				points[name] = m.points
+				correct = append(correct, name)
+			}
+		}
+
+		m.state.Result = qg.QuizResultChoice{
+			Answer:       answer,
+			Distribution: distribution,
+		}
+
+	case qg.QuizQuestionEstimate:
+		guesses := make([]qg.QuizEstimateGuess, 0, len(m.state.Answers))
+		for _, name := range players {
+			if number, ok := m.state.Answers[name].(qg.QuizAnswerNumber); ok {
+				guesses = append(guesses, qg.QuizEstimateGuess{
+					PlayerName: name,
+					Guess:      number.Number,
+				})
+			}
+		}
+
+		scoreEstimate(question, guesses, m.points)
+
+		for _, guess := range guesses {
+			points[guess.PlayerName] = guess.Points
+			// Only the closest guesses count as correct.
+			if guess.Distance == guesses[0].Distance {
+				correct = append(correct, guess.PlayerName)
+			}
+		}
+
+		m.state.Result = qg.QuizResultEstimate{
+			Answer:  question.Answer,
+			Guesses: guesses,
+		}
+
+	case qg.QuizQuestionOrdering:
+		answer := orderingAnswer(m.state.Order)
+
+		guesses := make([]qg.QuizOrderingGuess, 0, len(m.state.Answers))
+		for _, name := range players {
+			if order, ok := m.state.Answers[name].(qg.QuizAnswerOrder); ok {
+				guesses = append(guesses, qg.QuizOrderingGuess{
+					PlayerName: name,
+					Order:      order.Order,
+				})
+			}
+		}
+
+		scoreOrdering(answer, guesses, m.points)
+
+		for _, guess := range guesses {
+			points[guess.PlayerName] = guess.Points
+			// Only answers in the right order count as correct.
+			if guess.Distance == 0 {
+				correct = append(correct, guess.PlayerName)
+			}
+		}
+
+		m.state.Result = qg.QuizResultOrdering{
+			Answer: answer,
+			Orders: guesses,
+		}
+	}
+
+	for name, pts := range points {
+		m.state.Scores[name] += pts
+	}
+
+	m.state.Correct = correct
+}
+
+// revealEvent builds the reveal event of the current question. The question
+// must have been scored.
+func (m *gameManager) revealEvent() qg.EventQuizReveal {
+	return qg.EventQuizReveal{
+		Index:       m.state.Question,
+		Result:      qg.QuizResult{Value: m.state.Result},
+		Correct:     m.state.Correct,
+		Leaderboard: m.Leaderboard(),
+	}
+}
+
+// questionText returns the text of the given question.
+func questionText(question qg.IQuizQuestion) string {
+	switch question := question.(type) {
+	case qg.QuizQuestionMultipleChoice:
+		return question.Question
+	case qg.QuizQuestionTrueFalse:
+		return question.Question
+	case qg.QuizQuestionEstimate:
+		return question.Question
+	case qg.QuizQuestionOrdering:
+		return question.Question
+	default:
+		panic(fmt.Sprintf("unknown quiz question type %T", question))
+	}
+}
+
+// choices returns the choices of the current question as they are shown to
+// players.
+func (m *gameManager) choices() []string {
+	if q, ok := m.question().(qg.QuizQuestionOrdering); ok {
+		return orderingChoices(q, m.state.Order)
+	}
+	return choices(m.question())
+}
+
+// choices returns the choices of the given question. Questions that aren't
+// answered with a choice have none, and the choices of ordering questions
+// depend on the order in which their items are shown.
+func choices(question qg.IQuizQuestion) []string {
+	switch question := question.(type) {
+	case qg.QuizQuestionMultipleChoice:
+		return question.Choices
+	case qg.QuizQuestionTrueFalse:
+		return trueFalseChoices
+	default:
+		return []string{}
+	}
+}
+
+// correctChoice returns the index of the correct choice of the given
+// question. The question must be answered with a choice.
+func correctChoice(question qg.IQuizQuestion) int32 {
+	switch question := question.(type) {
+	case qg.QuizQuestionMultipleChoice:
+		return question.Answer
+	case qg.QuizQuestionTrueFalse:
+		if question.Answer {
+			return 0
+		}
+		return 1
+	default:
+		panic(fmt.Sprintf("quiz question type %T has no choices", question))
+	}
+}
+
+// checkAnswer checks that the answer can be given to the question.
+func checkAnswer(question qg.IQuizQuestion, answer qg.IQuizAnswer) error {
+	switch question := question.(type) {
+	case qg.QuizQuestionMultipleChoice, qg.QuizQuestionTrueFalse:
+		if answer, ok := answer.(qg.QuizAnswerChoice); ok {
+			if answer.Choice < 0 || int(answer.Choice) >= len(choices(question)) {
+				return fmt.Errorf("invalid choice index: %d", answer.Choice)
+			}
+			return nil
+		}
+	case qg.QuizQuestionEstimate:
+		if answer, ok := answer.(qg.QuizAnswerNumber); ok {
+			if math.IsNaN(answer.Number) || math.IsInf(answer.Number, 0) {
+				return errors.New("guess must be a finite number")
+			}
+			return nil
+		}
+	case qg.QuizQuestionOrdering:
+		if answer, ok := answer.(qg.QuizAnswerOrder); ok {
+			return checkOrder(answer, len(question.Items))
+		}
+	}
+
+	return fmt.Errorf("%s questions cannot be answered with a %s answer", question.Type(), answer.Type())
+}
+
+func validateData(data qg.QuizGameData) error {
+	if len(data.Questions) == 0 {
+		return errors.New("no questions found, must have at least one")
+	}
+
+	if err := validateQuestions(data.Questions); err != nil {
+		return err
+	}
+
+	if data.Survival != nil {
+		if err := validateSurvival(*data.Survival); err != nil {
+			return errors.Wrap(err, "invalid survival")
+		}
+	}
+
+	return nil
+}
+
+func validateQuestions(questions []qg.QuizQuestion) error {
+	for i, q := range questions {
+		switch q := q.Value.(type) {
+		case qg.QuizQuestionMultipleChoice:
+			if len(q.Choices) < 2 {
+				return fmt.Errorf("question %d has %d choices, must have at least two", i+1, len(q.Choices))
+			}
+			if q.Answer < 0 || int(q.Answer) >= len(q.Choices) {
+				return fmt.Errorf("question %d has an invalid answer index: %d", i+1, q.Answer)
+			}
+		case qg.QuizQuestionEstimate:
+			if err := validateEstimate(q); err != nil {
+				return fmt.Errorf("question %d: %w", i+1, err)
+			}
+		case qg.QuizQuestionOrdering:
+			if err := validateOrdering(q); err != nil {
+				return fmt.Errorf("question %d: %w", i+1, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseTimeLimit(data qg.QuizGameData) (time.Duration, error) {
+	if data.TimeLimit == nil {
+		return DefaultTimeLimit, nil
+	}
+
+	limit, err := time.ParseDuration(*data.TimeLimit)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid time_limit")
+	}
+	if limit <= 0 {
+		return 0, errors.New("time_limit must be positive")
+	}
+
+	return limit, nil
+}
+
+// CreateGame implements the games.GameCreator.
+func (g Game) CreateGame(ctx context.Context, id qg.GameID, data qg.IGameData) (qg.CommandHandlerFactory, error) {
+	running, err := g.createGame(ctx, id, data, false)
+	if err != nil {
+		return nil, err
+	}
+	return running, nil
+}
+
+func (g Game) createGame(ctx context.Context, id qg.GameID, data qg.IGameData, practice bool) (*games.Machine, error) {
+	quizData, ok := data.(qg.GameDataQuiz)
+	if !ok {
+		return nil, errors.Errorf("invalid game data type: %T", data)
+	}
+
+	if practice {
+		// Nobody can be outlasted alone, so survival quizzes are practiced
+		// like regular ones.
+		quizData.Data.Survival = nil
+	}
+
+	if err := validateData(quizData.Data); err != nil {
+		return nil, errors.Wrap(err, "invalid game data")
+	}
+
+	timeLimit, err := parseTimeLimit(quizData.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := games.NewMachineState(ctx)
+	m := newGameManager(g.store, id, quizData.Data, s)
+	m.timeLimit = timeLimit
+	m.practice = practice
+	m.points = DefaultPoints
+	if quizData.Data.Points != nil {
+		m.points = *quizData.Data.Points
+	}
+	if m.tieBreaker() == qg.QuizTieBreakerSuddenDeath {
+		questions := make([]qg.QuizQuestion, 0, len(m.questions)+len(quizData.Data.Survival.SuddenDeath))
+		questions = append(questions, m.questions...)
+		m.questions = append(questions, quizData.Data.Survival.SuddenDeath...)
+	}
+
+	s.AddReactors(
+		cando.React[any, qg.CommandQuizAnswer](func(ctx context.Context, prev any) error {
+			if _, ok := prev.(qg.CommandQuizAnswer); ok {
+				// Still answering the same question.
+				return nil
+			}
+			question := m.question()
+			s.Publish(ctx, qg.EventQuizBeginQuestion{
+				Index:        m.state.Question,
+				QuestionType: qg.QuizQuestionType(question.Type()),
+				Question:     questionText(question),
+				Choices:      m.choices(),
+				TimeLimit:    float32(m.timeLimit) / float32(time.Millisecond),
+				SuddenDeath:  m.isSuddenDeath(),
+			})
+			return nil
+		}),
+		cando.OnEnter[qg.CommandQuizAnswer](func(ctx context.Context, _ qg.CommandQuizAnswer) error {
+			self := games.PlayerFromContext(ctx)
+			s.Publish(ctx, qg.EventQuizPlayerAnswered{PlayerName: self.Name})
+			return nil
+		}),
+		cando.React[any, qg.CommandQuizNextQuestion](func(ctx context.Context, _ any) error {
+			s.Publish(ctx, m.revealEvent())
+			if m.isSurvival() {
+				s.Publish(ctx, qg.EventQuizEliminated{
+					Index:      m.state.Question,
+					Eliminated: m.state.Eliminated,
+					Survivors:  s.Survivors(),
+				})
+			}
+			return nil
+		}),
+	)
+
+	nextQuestion := cando.State(m.nextQuestion)
+	if !m.practice {
+		// The practice player may move on without waiting for the host.
+		nextQuestion = cando.Guarded(nextQuestion, games.OnlyAdmins("only admins can move on to the next question"))
+	}
+
+	s.AddState(
+		cando.Guarded(cando.State(m.answer), games.NoAdmins("admins cannot answer")),
+		cando.State(m.timeOut),
+		nextQuestion,
+	)
+
+	m.running, err = s.StartMachine(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.running, nil
+}
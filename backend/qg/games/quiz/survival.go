@@ -0,0 +1,80 @@
+package quiz
+
+import (
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func validateSurvival(survival qg.QuizSurvival) error {
+	tieBreaker := qg.QuizTieBreakerShare
+	if survival.TieBreaker != nil {
+		tieBreaker = *survival.TieBreaker
+	}
+
+	switch tieBreaker {
+	case qg.QuizTieBreakerShare, qg.QuizTieBreakerRevive:
+		if len(survival.SuddenDeath) > 0 {
+			return errors.New("sudden_death questions require the sudden_death tie_breaker")
+		}
+	case qg.QuizTieBreakerSuddenDeath:
+		if len(survival.SuddenDeath) == 0 {
+			return errors.New("no sudden_death questions found, must have at least one")
+		}
+		if err := validateQuestions(survival.SuddenDeath); err != nil {
+			return errors.Wrap(err, "invalid sudden_death")
+		}
+	default:
+		return errors.Errorf("unknown tie_breaker %q", tieBreaker)
+	}
+
+	return nil
+}
+
+// isSurvival returns true if the quiz is a survival quiz.
+func (m *gameManager) isSurvival() bool {
+	return m.data.Survival != nil
+}
+
+// tieBreaker returns the tie-breaker of a survival quiz.
+func (m *gameManager) tieBreaker() qg.QuizTieBreaker {
+	if m.data.Survival == nil || m.data.Survival.TieBreaker == nil {
+		return qg.QuizTieBreakerShare
+	}
+	return *m.data.Survival.TieBreaker
+}
+
+// isSuddenDeath returns true if the current question is a sudden death
+// question.
+func (m *gameManager) isSuddenDeath() bool {
+	return int(m.state.Question) >= len(m.data.Questions)
+}
+
+// eliminate eliminates the survivors who did not get the current question
+// right. The question must have been scored.
+func (m *gameManager) eliminate() {
+	correct := make(map[qg.PlayerName]bool, len(m.state.Correct))
+	for _, name := range m.state.Correct {
+		correct[name] = true
+	}
+
+	survivors := m.machine.Survivors()
+
+	failed := []qg.PlayerName{}
+	for _, name := range survivors {
+		if !correct[name] {
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) == len(survivors) && m.tieBreaker() != qg.QuizTieBreakerShare {
+		// Nobody got it right, so everyone gets another chance.
+		failed = []qg.PlayerName{}
+	}
+
+	m.machine.Eliminate(failed...)
+	m.state.Eliminated = failed
+
+	for _, name := range m.machine.Survivors() {
+		m.state.Survived[name]++
+	}
+}
@@ -0,0 +1,132 @@
+package games
+
+import (
+	"sync"
+	"time"
+)
+
+type timerState uint8
+
+const (
+	timerRunning timerState = iota
+	timerPaused
+	timerDone
+)
+
+// Timer is a timer that belongs to a game. Unlike time.Timer, it is frozen
+// while its game is paused and continues with the time it had left once the
+// game is resumed.
+type Timer struct {
+	s *MachineState
+	f func()
+
+	mu        sync.Mutex
+	t         *time.Timer
+	state     timerState
+	deadline  time.Time
+	remaining time.Duration
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine, much like time.AfterFunc. The time spent while the game is paused
+// does not count towards the duration.
+func (s *MachineState) AfterFunc(d time.Duration, f func()) *Timer {
+	t := &Timer{s: s, f: f}
+
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	s.timers[t] = struct{}{}
+
+	if s.paused {
+		t.state = timerPaused
+		t.remaining = d
+	} else {
+		t.start(d)
+	}
+
+	return t
+}
+
+// Stop prevents the timer from firing. It returns false if the timer has
+// already fired or been stopped.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+
+	if t.state == timerDone {
+		t.mu.Unlock()
+		return false
+	}
+
+	if t.state == timerRunning && !t.t.Stop() {
+		// Too late, the timer is already firing.
+		t.mu.Unlock()
+		return false
+	}
+
+	t.state = timerDone
+	t.mu.Unlock()
+
+	t.s.removeTimer(t)
+	return true
+}
+
+// start starts the underlying timer. t.mu must be held or t must not be
+// shared yet.
+func (t *Timer) start(d time.Duration) {
+	t.state = timerRunning
+	t.deadline = time.Now().Add(d)
+	t.t = time.AfterFunc(d, t.fire)
+}
+
+func (t *Timer) fire() {
+	t.mu.Lock()
+	if t.state != timerRunning {
+		// The game was paused while we were firing, so resume will start us
+		// again, or we were stopped.
+		t.mu.Unlock()
+		return
+	}
+	t.state = timerDone
+	t.mu.Unlock()
+
+	t.s.removeTimer(t)
+	// The game may still be paused before f gets its input into the machine,
+	// so Machine.Input checks again once the input is handled.
+	t.f()
+}
+
+func (t *Timer) pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != timerRunning {
+		return
+	}
+
+	t.state = timerPaused
+	if t.t.Stop() {
+		t.remaining = time.Until(t.deadline)
+	} else {
+		// The timer is already firing, so fire it again right away once we're
+		// resumed.
+		t.remaining = 0
+	}
+}
+
+func (t *Timer) resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != timerPaused {
+		return
+	}
+
+	t.start(t.remaining)
+}
+
+func (s *MachineState) removeTimer(t *Timer) {
+	s.pauseMu.Lock()
+	delete(s.timers, t)
+	s.pauseMu.Unlock()
+}
@@ -0,0 +1,102 @@
+package games
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestTimerPause(t *testing.T) {
+	s := NewMachineState(context.Background())
+
+	fired := make(chan struct{})
+	s.AfterFunc(50*time.Millisecond, func() { close(fired) })
+
+	s.Pause()
+
+	select {
+	case <-fired:
+		t.Fatal("timer fired while the game was paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.setPaused(false, "")
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire after the game was resumed")
+	}
+
+	if len(s.timers) != 0 {
+		t.Fatalf("fired timer was not removed, %d timers left", len(s.timers))
+	}
+}
+
+func TestTimerStop(t *testing.T) {
+	s := NewMachineState(context.Background())
+
+	timer := s.AfterFunc(time.Hour, func() { t.Error("stopped timer fired") })
+	s.Pause()
+
+	if !timer.Stop() {
+		t.Fatal("paused timer could not be stopped")
+	}
+
+	s.setPaused(false, "")
+
+	if timer.Stop() {
+		t.Fatal("timer was stopped twice")
+	}
+}
+
+type timerFired struct{}
+
+func TestTimerFiresWhilePausing(t *testing.T) {
+	ctx := context.Background()
+
+	fired := make(chan struct{}, 1)
+
+	s := NewMachineState(ctx)
+	s.AddSuperstate(cando.State(func(ctx context.Context, _ timerFired) (cando.NextStates, error) {
+		fired <- struct{}{}
+		return cando.Stay(), nil
+	}))
+
+	m, err := s.StartMachine(ctx, fakeGame{})
+	if err != nil {
+		t.Fatal("cannot start machine:", err)
+	}
+
+	admin := joinFakeGame(t, m, "Admin", true, make(chan qg.IEvent, 64))
+	defer admin.Close()
+
+	if err := admin.HandleCommand(ctx, qg.CommandPauseGame{}); err != nil {
+		t.Fatal("cannot pause game:", err)
+	}
+
+	// The timer fired just before the game was paused, but its input only
+	// gets to the machine afterwards.
+	if err := m.Input(ctx, timerFired{}); err != nil {
+		t.Fatal("cannot feed input:", err)
+	}
+
+	select {
+	case <-fired:
+		t.Fatal("input was handled while the game was paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := admin.HandleCommand(ctx, qg.CommandResumeGame{}); err != nil {
+		t.Fatal("cannot resume game:", err)
+	}
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("input was not handled after the game was resumed")
+	}
+}
@@ -19,3 +19,18 @@ type CommandHandler interface {
 	// from all topics.
 	Close() error
 }
+
+// AdminOnlyEvent is an event that may carry data that only game admins may
+// see, such as the answers to a question. The server never sends such an event
+// to a player who is not an admin.
+type AdminOnlyEvent interface {
+	IEvent
+	// IsAdminOnly returns true if the event carries admin-only data.
+	IsAdminOnly() bool
+}
+
+// IsAdminOnly implements AdminOnlyEvent. Only admins get the game data.
+func (e EventJoinedGame) IsAdminOnly() bool { return e.GameData != nil }
+
+// IsAdminOnly implements AdminOnlyEvent.
+func (EventFeudAnswerKey) IsAdminOnly() bool { return true }
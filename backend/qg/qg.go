@@ -5,10 +5,39 @@ package qg
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type Qg = interface{}
 
+// BuzzerBuzz is a single button press within a buzz round.
+type BuzzerBuzz struct {
+	// latency is the estimated one-way latency of the player in
+	// milliseconds that was compensated for. It is 0 if the game does not
+	// compensate for latency.
+	Latency    float32    `json:"latency"`
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// BuzzerGameData is the game data for a buzzer game. A buzzer game has no
+// questions: the host asks them on their own, opens a buzz round for each
+// one, and awards points by hand.
+type BuzzerGameData struct {
+	// compensate_latency orders buzzes by their estimated send time,
+	// which is the arrival time minus half of the player's round-trip
+	// time. If false, buzzes are ordered by their arrival time. The
+	// default is false.
+	CompensateLatency *bool `json:"compensate_latency,omitempty"`
+	// early_buzz_penalty is how long a player is locked out of their
+	// buzzer if they press it while no round is open. The format is in
+	// Go's time.Duration. The default is 250ms.
+	EarlyBuzzPenalty *string `json:"early_buzz_penalty,omitempty"`
+}
+
+type BuzzerGameInfo struct {
+	CompensateLatency bool `json:"compensateLatency"`
+}
+
 type Command struct {
 	Value ICommand `json:"-"`
 }
@@ -33,10 +62,46 @@ func (v *Command) UnmarshalJSON(b []byte) error {
 		var v CommandBeginGame
 		err = json.Unmarshal(b, &v)
 		value = v
+	case "BuzzerAwardPoints":
+		var v CommandBuzzerAwardPoints
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerCloseRound":
+		var v CommandBuzzerCloseRound
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerOpenRound":
+		var v CommandBuzzerOpenRound
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerPress":
+		var v CommandBuzzerPress
+		err = json.Unmarshal(b, &v)
+		value = v
 	case "EndGame":
 		var v CommandEndGame
 		err = json.Unmarshal(b, &v)
 		value = v
+	case "FeudNextRound":
+		var v CommandFeudNextRound
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudPressButton":
+		var v CommandFeudPressButton
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudRevealAnswer":
+		var v CommandFeudRevealAnswer
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudStrike":
+		var v CommandFeudStrike
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyArmBuzzers":
+		var v CommandJeopardyArmBuzzers
+		err = json.Unmarshal(b, &v)
+		value = v
 	case "JeopardyChooseQuestion":
 		var v CommandJeopardyChooseQuestion
 		err = json.Unmarshal(b, &v)
@@ -49,10 +114,46 @@ func (v *Command) UnmarshalJSON(b []byte) error {
 		var v CommandJeopardyPressButton
 		err = json.Unmarshal(b, &v)
 		value = v
+	case "JeopardySubmitAnswer":
+		var v CommandJeopardySubmitAnswer
+		err = json.Unmarshal(b, &v)
+		value = v
 	case "JoinGame":
 		var v CommandJoinGame
 		err = json.Unmarshal(b, &v)
 		value = v
+	case "PauseGame":
+		var v CommandPauseGame
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PollNextQuestion":
+		var v CommandPollNextQuestion
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PollVote":
+		var v CommandPollVote
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PracticeGame":
+		var v CommandPracticeGame
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizAnswer":
+		var v CommandQuizAnswer
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizNextQuestion":
+		var v CommandQuizNextQuestion
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "RequestSnapshot":
+		var v CommandRequestSnapshot
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "ResumeGame":
+		var v CommandResumeGame
+		err = json.Unmarshal(b, &v)
+		value = v
 	default:
 		err = fmt.Errorf("Command: bad type value: %q", t.T)
 	}
@@ -69,29 +170,83 @@ func (v *Command) UnmarshalJSON(b []byte) error {
 // It can be the following types:
 //
 // - [CommandBeginGame] (BeginGame)
+// - [CommandBuzzerAwardPoints] (BuzzerAwardPoints)
+// - [CommandBuzzerCloseRound] (BuzzerCloseRound)
+// - [CommandBuzzerOpenRound] (BuzzerOpenRound)
+// - [CommandBuzzerPress] (BuzzerPress)
 // - [CommandEndGame] (EndGame)
+// - [CommandFeudNextRound] (FeudNextRound)
+// - [CommandFeudPressButton] (FeudPressButton)
+// - [CommandFeudRevealAnswer] (FeudRevealAnswer)
+// - [CommandFeudStrike] (FeudStrike)
+// - [CommandJeopardyArmBuzzers] (JeopardyArmBuzzers)
 // - [CommandJeopardyChooseQuestion] (JeopardyChooseQuestion)
 // - [CommandJeopardyPlayerJudgment] (JeopardyPlayerJudgment)
 // - [CommandJeopardyPressButton] (JeopardyPressButton)
+// - [CommandJeopardySubmitAnswer] (JeopardySubmitAnswer)
 // - [CommandJoinGame] (JoinGame)
+// - [CommandPauseGame] (PauseGame)
+// - [CommandPollNextQuestion] (PollNextQuestion)
+// - [CommandPollVote] (PollVote)
+// - [CommandPracticeGame] (PracticeGame)
+// - [CommandQuizAnswer] (QuizAnswer)
+// - [CommandQuizNextQuestion] (QuizNextQuestion)
+// - [CommandRequestSnapshot] (RequestSnapshot)
+// - [CommandResumeGame] (ResumeGame)
 type ICommand interface {
 	Type() string
 	isCommand()
 }
 
 func (CommandBeginGame) Type() string              { return "BeginGame" }
+func (CommandBuzzerAwardPoints) Type() string      { return "BuzzerAwardPoints" }
+func (CommandBuzzerCloseRound) Type() string       { return "BuzzerCloseRound" }
+func (CommandBuzzerOpenRound) Type() string        { return "BuzzerOpenRound" }
+func (CommandBuzzerPress) Type() string            { return "BuzzerPress" }
 func (CommandEndGame) Type() string                { return "EndGame" }
+func (CommandFeudNextRound) Type() string          { return "FeudNextRound" }
+func (CommandFeudPressButton) Type() string        { return "FeudPressButton" }
+func (CommandFeudRevealAnswer) Type() string       { return "FeudRevealAnswer" }
+func (CommandFeudStrike) Type() string             { return "FeudStrike" }
+func (CommandJeopardyArmBuzzers) Type() string     { return "JeopardyArmBuzzers" }
 func (CommandJeopardyChooseQuestion) Type() string { return "JeopardyChooseQuestion" }
 func (CommandJeopardyPlayerJudgment) Type() string { return "JeopardyPlayerJudgment" }
 func (CommandJeopardyPressButton) Type() string    { return "JeopardyPressButton" }
+func (CommandJeopardySubmitAnswer) Type() string   { return "JeopardySubmitAnswer" }
 func (CommandJoinGame) Type() string               { return "JoinGame" }
+func (CommandPauseGame) Type() string              { return "PauseGame" }
+func (CommandPollNextQuestion) Type() string       { return "PollNextQuestion" }
+func (CommandPollVote) Type() string               { return "PollVote" }
+func (CommandPracticeGame) Type() string           { return "PracticeGame" }
+func (CommandQuizAnswer) Type() string             { return "QuizAnswer" }
+func (CommandQuizNextQuestion) Type() string       { return "QuizNextQuestion" }
+func (CommandRequestSnapshot) Type() string        { return "RequestSnapshot" }
+func (CommandResumeGame) Type() string             { return "ResumeGame" }
 
 func (CommandBeginGame) isCommand()              {}
+func (CommandBuzzerAwardPoints) isCommand()      {}
+func (CommandBuzzerCloseRound) isCommand()       {}
+func (CommandBuzzerOpenRound) isCommand()        {}
+func (CommandBuzzerPress) isCommand()            {}
 func (CommandEndGame) isCommand()                {}
+func (CommandFeudNextRound) isCommand()          {}
+func (CommandFeudPressButton) isCommand()        {}
+func (CommandFeudRevealAnswer) isCommand()       {}
+func (CommandFeudStrike) isCommand()             {}
+func (CommandJeopardyArmBuzzers) isCommand()     {}
 func (CommandJeopardyChooseQuestion) isCommand() {}
 func (CommandJeopardyPlayerJudgment) isCommand() {}
 func (CommandJeopardyPressButton) isCommand()    {}
+func (CommandJeopardySubmitAnswer) isCommand()   {}
 func (CommandJoinGame) isCommand()               {}
+func (CommandPauseGame) isCommand()              {}
+func (CommandPollNextQuestion) isCommand()       {}
+func (CommandPollVote) isCommand()               {}
+func (CommandPracticeGame) isCommand()           {}
+func (CommandQuizAnswer) isCommand()             {}
+func (CommandQuizNextQuestion) isCommand()       {}
+func (CommandRequestSnapshot) isCommand()        {}
+func (CommandResumeGame) isCommand()             {}
 
 func (v CommandBeginGame) MarshalJSON() ([]byte, error) {
 	type Alias CommandBeginGame
@@ -123,8 +278,8 @@ func (v *CommandBeginGame) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func (v CommandEndGame) MarshalJSON() ([]byte, error) {
-	type Alias CommandEndGame
+func (v CommandBuzzerAwardPoints) MarshalJSON() ([]byte, error) {
+	type Alias CommandBuzzerAwardPoints
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -134,8 +289,8 @@ func (v CommandEndGame) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *CommandEndGame) UnmarshalJSON(b []byte) error {
-	type Alias CommandEndGame
+func (v *CommandBuzzerAwardPoints) UnmarshalJSON(b []byte) error {
+	type Alias CommandBuzzerAwardPoints
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -145,16 +300,16 @@ func (v *CommandEndGame) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "EndGame" {
-		return fmt.Errorf("CommandEndGame: bad type value: %q", a.T)
+	if a.T != "BuzzerAwardPoints" {
+		return fmt.Errorf("CommandBuzzerAwardPoints: bad type value: %q", a.T)
 	}
 
-	*v = CommandEndGame(a.Alias)
+	*v = CommandBuzzerAwardPoints(a.Alias)
 	return nil
 }
 
-func (v CommandJeopardyChooseQuestion) MarshalJSON() ([]byte, error) {
-	type Alias CommandJeopardyChooseQuestion
+func (v CommandBuzzerCloseRound) MarshalJSON() ([]byte, error) {
+	type Alias CommandBuzzerCloseRound
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -164,8 +319,8 @@ func (v CommandJeopardyChooseQuestion) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *CommandJeopardyChooseQuestion) UnmarshalJSON(b []byte) error {
-	type Alias CommandJeopardyChooseQuestion
+func (v *CommandBuzzerCloseRound) UnmarshalJSON(b []byte) error {
+	type Alias CommandBuzzerCloseRound
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -175,16 +330,16 @@ func (v *CommandJeopardyChooseQuestion) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyChooseQuestion" {
-		return fmt.Errorf("CommandJeopardyChooseQuestion: bad type value: %q", a.T)
+	if a.T != "BuzzerCloseRound" {
+		return fmt.Errorf("CommandBuzzerCloseRound: bad type value: %q", a.T)
 	}
 
-	*v = CommandJeopardyChooseQuestion(a.Alias)
+	*v = CommandBuzzerCloseRound(a.Alias)
 	return nil
 }
 
-func (v CommandJeopardyPlayerJudgment) MarshalJSON() ([]byte, error) {
-	type Alias CommandJeopardyPlayerJudgment
+func (v CommandBuzzerOpenRound) MarshalJSON() ([]byte, error) {
+	type Alias CommandBuzzerOpenRound
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -194,8 +349,8 @@ func (v CommandJeopardyPlayerJudgment) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *CommandJeopardyPlayerJudgment) UnmarshalJSON(b []byte) error {
-	type Alias CommandJeopardyPlayerJudgment
+func (v *CommandBuzzerOpenRound) UnmarshalJSON(b []byte) error {
+	type Alias CommandBuzzerOpenRound
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -205,16 +360,16 @@ func (v *CommandJeopardyPlayerJudgment) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyPlayerJudgment" {
-		return fmt.Errorf("CommandJeopardyPlayerJudgment: bad type value: %q", a.T)
+	if a.T != "BuzzerOpenRound" {
+		return fmt.Errorf("CommandBuzzerOpenRound: bad type value: %q", a.T)
 	}
 
-	*v = CommandJeopardyPlayerJudgment(a.Alias)
+	*v = CommandBuzzerOpenRound(a.Alias)
 	return nil
 }
 
-func (v CommandJeopardyPressButton) MarshalJSON() ([]byte, error) {
-	type Alias CommandJeopardyPressButton
+func (v CommandBuzzerPress) MarshalJSON() ([]byte, error) {
+	type Alias CommandBuzzerPress
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -224,8 +379,8 @@ func (v CommandJeopardyPressButton) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *CommandJeopardyPressButton) UnmarshalJSON(b []byte) error {
-	type Alias CommandJeopardyPressButton
+func (v *CommandBuzzerPress) UnmarshalJSON(b []byte) error {
+	type Alias CommandBuzzerPress
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -235,16 +390,16 @@ func (v *CommandJeopardyPressButton) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyPressButton" {
-		return fmt.Errorf("CommandJeopardyPressButton: bad type value: %q", a.T)
+	if a.T != "BuzzerPress" {
+		return fmt.Errorf("CommandBuzzerPress: bad type value: %q", a.T)
 	}
 
-	*v = CommandJeopardyPressButton(a.Alias)
+	*v = CommandBuzzerPress(a.Alias)
 	return nil
 }
 
-func (v CommandJoinGame) MarshalJSON() ([]byte, error) {
-	type Alias CommandJoinGame
+func (v CommandEndGame) MarshalJSON() ([]byte, error) {
+	type Alias CommandEndGame
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -254,8 +409,8 @@ func (v CommandJoinGame) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *CommandJoinGame) UnmarshalJSON(b []byte) error {
-	type Alias CommandJoinGame
+func (v *CommandEndGame) UnmarshalJSON(b []byte) error {
+	type Alias CommandEndGame
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -265,177 +420,46 @@ func (v *CommandJoinGame) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JoinGame" {
-		return fmt.Errorf("CommandJoinGame: bad type value: %q", a.T)
+	if a.T != "EndGame" {
+		return fmt.Errorf("CommandEndGame: bad type value: %q", a.T)
 	}
 
-	*v = CommandJoinGame(a.Alias)
+	*v = CommandEndGame(a.Alias)
 	return nil
 }
 
-// CommandBeginGame is sent by a client to begin a game.
-type CommandBeginGame struct {
-}
-
-// CommandEndGame is sent by a client to end the current game. The server
-// will respond with an EventGameEnded. Only game admins (including the
-// host) can end the game.
-type CommandEndGame struct {
-	// declareWinner determines whether the game should be ended with a
-	// winner or not. If true, the game will be ended with a winner. If
-	// false, the game will be ended abruptly.
-	DeclareWinner bool `json:"declareWinner"`
-}
-
-// CommandJeopardyChooseQuestion is sent by a player to choose a question.
-// The server must do validation to ensure that the player is allowed to
-// choose the question.
-type CommandJeopardyChooseQuestion struct {
-	Category int32 `json:"category"`
-	Question int32 `json:"question"`
-}
-
-// CommandJeopardyPlayerJudgment is emitted by a game admin to indicate
-// whether a player has answered a question correctly. The winning player is
-// whoever the last EventJeopardyButtonPressed event indicated. That player
-// will instantly receive the points for the question, and the game will let
-// them choose the next category and question. If the player answered wrong,
-// then the game will let others press the button.
-type CommandJeopardyPlayerJudgment struct {
-	Correct bool `json:"correct"`
-}
-
-// CommandJeopardyPressButton is emitted when a player presses the button
-// during a question. It is only valid to emit this command when the game is
-// in the question state.
-type CommandJeopardyPressButton struct {
-}
-
-// CommandJoinGame is sent by a client to join a game. The client (or the
-// user) supplies a game ID and a player name. The server will respond with
-// an EventJoinedGame.
-type CommandJoinGame struct {
-	// adminPassword is the password of the admin of the game.
-	AdminPassword *string `json:"adminPassword"`
-	// gameID is the ID of the game to join.
-	GameID GameID `json:"gameID"`
-	// playerName is the wanted name of the user.
-	PlayerName PlayerName `json:"playerName"`
-}
-
-// Error is returned on every API error.
-type Error struct {
-	// Message is the error message
-	Message string `json:"message"`
-}
-
-type Event struct {
-	Value IEvent `json:"-"`
-}
-
-func (v Event) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.Value)
+func (v CommandFeudNextRound) MarshalJSON() ([]byte, error) {
+	type Alias CommandFeudNextRound
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-func (v *Event) UnmarshalJSON(b []byte) error {
-	var t struct {
+func (v *CommandFeudNextRound) UnmarshalJSON(b []byte) error {
+	type Alias CommandFeudNextRound
+	var a struct {
 		T string `json:"type"`
+		Alias
 	}
-	if err := json.Unmarshal(b, &t); err != nil {
-		return err
-	}
-
-	var value IEvent
-	var err error
 
-	switch t.T {
-	case "Error":
-		var v EventError
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "GameEnded":
-		var v EventGameEnded
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "GameStarted":
-		var v EventGameStarted
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "JeopardyBeginQuestion":
-		var v EventJeopardyBeginQuestion
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "JeopardyButtonPressed":
-		var v EventJeopardyButtonPressed
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "JeopardyResumeButton":
-		var v EventJeopardyResumeButton
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "JeopardyTurnEnded":
-		var v EventJeopardyTurnEnded
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "JoinedGame":
-		var v EventJoinedGame
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "PlayerJoined":
-		var v EventPlayerJoined
-		err = json.Unmarshal(b, &v)
-		value = v
-	default:
-		err = fmt.Errorf("Event: bad type value: %q", t.T)
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
 	}
 
-	if err != nil {
-		return err
+	if a.T != "FeudNextRound" {
+		return fmt.Errorf("CommandFeudNextRound: bad type value: %q", a.T)
 	}
 
-	v.Value = value
+	*v = CommandFeudNextRound(a.Alias)
 	return nil
 }
 
-// IEvent is an interface type that Event types implement.
-// It can be the following types:
-//
-// - [EventError] (Error)
-// - [EventGameEnded] (GameEnded)
-// - [EventGameStarted] (GameStarted)
-// - [EventJeopardyBeginQuestion] (JeopardyBeginQuestion)
-// - [EventJeopardyButtonPressed] (JeopardyButtonPressed)
-// - [EventJeopardyResumeButton] (JeopardyResumeButton)
-// - [EventJeopardyTurnEnded] (JeopardyTurnEnded)
-// - [EventJoinedGame] (JoinedGame)
-// - [EventPlayerJoined] (PlayerJoined)
-type IEvent interface {
-	Type() string
-	isEvent()
-}
-
-func (EventError) Type() string                 { return "Error" }
-func (EventGameEnded) Type() string             { return "GameEnded" }
-func (EventGameStarted) Type() string           { return "GameStarted" }
-func (EventJeopardyBeginQuestion) Type() string { return "JeopardyBeginQuestion" }
-func (EventJeopardyButtonPressed) Type() string { return "JeopardyButtonPressed" }
-func (EventJeopardyResumeButton) Type() string  { return "JeopardyResumeButton" }
-func (EventJeopardyTurnEnded) Type() string     { return "JeopardyTurnEnded" }
-func (EventJoinedGame) Type() string            { return "JoinedGame" }
-func (EventPlayerJoined) Type() string          { return "PlayerJoined" }
-
-func (EventError) isEvent()                 {}
-func (EventGameEnded) isEvent()             {}
-func (EventGameStarted) isEvent()           {}
-func (EventJeopardyBeginQuestion) isEvent() {}
-func (EventJeopardyButtonPressed) isEvent() {}
-func (EventJeopardyResumeButton) isEvent()  {}
-func (EventJeopardyTurnEnded) isEvent()     {}
-func (EventJoinedGame) isEvent()            {}
-func (EventPlayerJoined) isEvent()          {}
-
-func (v EventError) MarshalJSON() ([]byte, error) {
-	type Alias EventError
+func (v CommandFeudPressButton) MarshalJSON() ([]byte, error) {
+	type Alias CommandFeudPressButton
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -445,8 +469,8 @@ func (v EventError) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventError) UnmarshalJSON(b []byte) error {
-	type Alias EventError
+func (v *CommandFeudPressButton) UnmarshalJSON(b []byte) error {
+	type Alias CommandFeudPressButton
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -456,16 +480,16 @@ func (v *EventError) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "Error" {
-		return fmt.Errorf("EventError: bad type value: %q", a.T)
+	if a.T != "FeudPressButton" {
+		return fmt.Errorf("CommandFeudPressButton: bad type value: %q", a.T)
 	}
 
-	*v = EventError(a.Alias)
+	*v = CommandFeudPressButton(a.Alias)
 	return nil
 }
 
-func (v EventGameEnded) MarshalJSON() ([]byte, error) {
-	type Alias EventGameEnded
+func (v CommandFeudRevealAnswer) MarshalJSON() ([]byte, error) {
+	type Alias CommandFeudRevealAnswer
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -475,8 +499,8 @@ func (v EventGameEnded) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventGameEnded) UnmarshalJSON(b []byte) error {
-	type Alias EventGameEnded
+func (v *CommandFeudRevealAnswer) UnmarshalJSON(b []byte) error {
+	type Alias CommandFeudRevealAnswer
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -486,16 +510,16 @@ func (v *EventGameEnded) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "GameEnded" {
-		return fmt.Errorf("EventGameEnded: bad type value: %q", a.T)
+	if a.T != "FeudRevealAnswer" {
+		return fmt.Errorf("CommandFeudRevealAnswer: bad type value: %q", a.T)
 	}
 
-	*v = EventGameEnded(a.Alias)
+	*v = CommandFeudRevealAnswer(a.Alias)
 	return nil
 }
 
-func (v EventGameStarted) MarshalJSON() ([]byte, error) {
-	type Alias EventGameStarted
+func (v CommandFeudStrike) MarshalJSON() ([]byte, error) {
+	type Alias CommandFeudStrike
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -505,8 +529,8 @@ func (v EventGameStarted) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventGameStarted) UnmarshalJSON(b []byte) error {
-	type Alias EventGameStarted
+func (v *CommandFeudStrike) UnmarshalJSON(b []byte) error {
+	type Alias CommandFeudStrike
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -516,16 +540,16 @@ func (v *EventGameStarted) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "GameStarted" {
-		return fmt.Errorf("EventGameStarted: bad type value: %q", a.T)
+	if a.T != "FeudStrike" {
+		return fmt.Errorf("CommandFeudStrike: bad type value: %q", a.T)
 	}
 
-	*v = EventGameStarted(a.Alias)
+	*v = CommandFeudStrike(a.Alias)
 	return nil
 }
 
-func (v EventJeopardyBeginQuestion) MarshalJSON() ([]byte, error) {
-	type Alias EventJeopardyBeginQuestion
+func (v CommandJeopardyArmBuzzers) MarshalJSON() ([]byte, error) {
+	type Alias CommandJeopardyArmBuzzers
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -535,8 +559,8 @@ func (v EventJeopardyBeginQuestion) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventJeopardyBeginQuestion) UnmarshalJSON(b []byte) error {
-	type Alias EventJeopardyBeginQuestion
+func (v *CommandJeopardyArmBuzzers) UnmarshalJSON(b []byte) error {
+	type Alias CommandJeopardyArmBuzzers
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -546,16 +570,16 @@ func (v *EventJeopardyBeginQuestion) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyBeginQuestion" {
-		return fmt.Errorf("EventJeopardyBeginQuestion: bad type value: %q", a.T)
+	if a.T != "JeopardyArmBuzzers" {
+		return fmt.Errorf("CommandJeopardyArmBuzzers: bad type value: %q", a.T)
 	}
 
-	*v = EventJeopardyBeginQuestion(a.Alias)
+	*v = CommandJeopardyArmBuzzers(a.Alias)
 	return nil
 }
 
-func (v EventJeopardyButtonPressed) MarshalJSON() ([]byte, error) {
-	type Alias EventJeopardyButtonPressed
+func (v CommandJeopardyChooseQuestion) MarshalJSON() ([]byte, error) {
+	type Alias CommandJeopardyChooseQuestion
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -565,8 +589,8 @@ func (v EventJeopardyButtonPressed) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventJeopardyButtonPressed) UnmarshalJSON(b []byte) error {
-	type Alias EventJeopardyButtonPressed
+func (v *CommandJeopardyChooseQuestion) UnmarshalJSON(b []byte) error {
+	type Alias CommandJeopardyChooseQuestion
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -576,16 +600,16 @@ func (v *EventJeopardyButtonPressed) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyButtonPressed" {
-		return fmt.Errorf("EventJeopardyButtonPressed: bad type value: %q", a.T)
+	if a.T != "JeopardyChooseQuestion" {
+		return fmt.Errorf("CommandJeopardyChooseQuestion: bad type value: %q", a.T)
 	}
 
-	*v = EventJeopardyButtonPressed(a.Alias)
+	*v = CommandJeopardyChooseQuestion(a.Alias)
 	return nil
 }
 
-func (v EventJeopardyResumeButton) MarshalJSON() ([]byte, error) {
-	type Alias EventJeopardyResumeButton
+func (v CommandJeopardyPlayerJudgment) MarshalJSON() ([]byte, error) {
+	type Alias CommandJeopardyPlayerJudgment
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -595,8 +619,8 @@ func (v EventJeopardyResumeButton) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventJeopardyResumeButton) UnmarshalJSON(b []byte) error {
-	type Alias EventJeopardyResumeButton
+func (v *CommandJeopardyPlayerJudgment) UnmarshalJSON(b []byte) error {
+	type Alias CommandJeopardyPlayerJudgment
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -606,16 +630,16 @@ func (v *EventJeopardyResumeButton) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyResumeButton" {
-		return fmt.Errorf("EventJeopardyResumeButton: bad type value: %q", a.T)
+	if a.T != "JeopardyPlayerJudgment" {
+		return fmt.Errorf("CommandJeopardyPlayerJudgment: bad type value: %q", a.T)
 	}
 
-	*v = EventJeopardyResumeButton(a.Alias)
+	*v = CommandJeopardyPlayerJudgment(a.Alias)
 	return nil
 }
 
-func (v EventJeopardyTurnEnded) MarshalJSON() ([]byte, error) {
-	type Alias EventJeopardyTurnEnded
+func (v CommandJeopardyPressButton) MarshalJSON() ([]byte, error) {
+	type Alias CommandJeopardyPressButton
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -625,8 +649,8 @@ func (v EventJeopardyTurnEnded) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventJeopardyTurnEnded) UnmarshalJSON(b []byte) error {
-	type Alias EventJeopardyTurnEnded
+func (v *CommandJeopardyPressButton) UnmarshalJSON(b []byte) error {
+	type Alias CommandJeopardyPressButton
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -636,16 +660,16 @@ func (v *EventJeopardyTurnEnded) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JeopardyTurnEnded" {
-		return fmt.Errorf("EventJeopardyTurnEnded: bad type value: %q", a.T)
+	if a.T != "JeopardyPressButton" {
+		return fmt.Errorf("CommandJeopardyPressButton: bad type value: %q", a.T)
 	}
 
-	*v = EventJeopardyTurnEnded(a.Alias)
+	*v = CommandJeopardyPressButton(a.Alias)
 	return nil
 }
 
-func (v EventJoinedGame) MarshalJSON() ([]byte, error) {
-	type Alias EventJoinedGame
+func (v CommandJeopardySubmitAnswer) MarshalJSON() ([]byte, error) {
+	type Alias CommandJeopardySubmitAnswer
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -655,8 +679,8 @@ func (v EventJoinedGame) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventJoinedGame) UnmarshalJSON(b []byte) error {
-	type Alias EventJoinedGame
+func (v *CommandJeopardySubmitAnswer) UnmarshalJSON(b []byte) error {
+	type Alias CommandJeopardySubmitAnswer
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -666,16 +690,16 @@ func (v *EventJoinedGame) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "JoinedGame" {
-		return fmt.Errorf("EventJoinedGame: bad type value: %q", a.T)
+	if a.T != "JeopardySubmitAnswer" {
+		return fmt.Errorf("CommandJeopardySubmitAnswer: bad type value: %q", a.T)
 	}
 
-	*v = EventJoinedGame(a.Alias)
+	*v = CommandJeopardySubmitAnswer(a.Alias)
 	return nil
 }
 
-func (v EventPlayerJoined) MarshalJSON() ([]byte, error) {
-	type Alias EventPlayerJoined
+func (v CommandJoinGame) MarshalJSON() ([]byte, error) {
+	type Alias CommandJoinGame
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -685,8 +709,8 @@ func (v EventPlayerJoined) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *EventPlayerJoined) UnmarshalJSON(b []byte) error {
-	type Alias EventPlayerJoined
+func (v *CommandJoinGame) UnmarshalJSON(b []byte) error {
+	type Alias CommandJoinGame
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -696,156 +720,179 @@ func (v *EventPlayerJoined) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "PlayerJoined" {
-		return fmt.Errorf("EventPlayerJoined: bad type value: %q", a.T)
+	if a.T != "JoinGame" {
+		return fmt.Errorf("CommandJoinGame: bad type value: %q", a.T)
 	}
 
-	*v = EventPlayerJoined(a.Alias)
+	*v = CommandJoinGame(a.Alias)
 	return nil
 }
 
-type EventError struct {
-	Error Error `json:"error"`
+func (v CommandPauseGame) MarshalJSON() ([]byte, error) {
+	type Alias CommandPauseGame
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-// EventGameEnded is emitted when the current game ends.
-type EventGameEnded struct {
-	Leaderboard Leaderboard `json:"leaderboard"`
-}
+func (v *CommandPauseGame) UnmarshalJSON(b []byte) error {
+	type Alias CommandPauseGame
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
 
-// EventGameStarted is emitted when the game starts. It contains no data and
-// is only meant to be used to trigger the client to start the game.
-type EventGameStarted struct {
-}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
 
-// EventJeopardyBeginQuestion is emitted when a question begins within this
-// Jeopardy game. It is usually emitted once the chooser player has chosen a
-// category and value.
-//
-// Each category name and question value will map to a category and question
-// within the game data. Note that a question may repeat across multiple
-// categories.
-type EventJeopardyBeginQuestion struct {
-	Category int32      `json:"category"`
-	Chooser  PlayerName `json:"chooser"`
-	Points   float32    `json:"points"`
-	Question string     `json:"question"`
-}
+	if a.T != "PauseGame" {
+		return fmt.Errorf("CommandPauseGame: bad type value: %q", a.T)
+	}
 
-// EventJeopardyButtonPressed is emitted when any player had pressed a button
-// on their device, voiding other players' buttons. This event is only
-// emitted when the game is in the "question" state.
-type EventJeopardyButtonPressed struct {
-	PlayerName PlayerName `json:"playerName"`
+	*v = CommandPauseGame(a.Alias)
+	return nil
 }
 
-// EventJeopardyResumeButton is emitted when the player can now continue to
-// press the button whenever they are ready to answer the question. This
-// could happen if the other player who pressed the button first got the
-// question wrong.
-//
-// Note that if alreadyPressed is true, then the player has already pressed
-// the button, so they cannot press it again.
-type EventJeopardyResumeButton struct {
-	AlreadyAnsweredPlayers []PlayerName `json:"alreadyAnsweredPlayers"`
+func (v CommandPollNextQuestion) MarshalJSON() ([]byte, error) {
+	type Alias CommandPollNextQuestion
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-// EventJeopardyTurnEnded is emitted when a turn ends or when the game first
-// starts.
-type EventJeopardyTurnEnded struct {
-	Answered    JeopardyAnsweredQuestions `json:"answered"`
-	Chooser     PlayerName                `json:"chooser"`
-	Leaderboard Leaderboard               `json:"leaderboard"`
-}
+func (v *CommandPollNextQuestion) UnmarshalJSON(b []byte) error {
+	type Alias CommandPollNextQuestion
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
 
-// EventJoinedGame is emitted when the current player joins a game. It is a
-// reply to CommandJoinGame and is only for the current player. Not to be
-// confused with EventPlayerJoinedGame, which is emitted when any player
-// joins the current game.
-type EventJoinedGame struct {
-	GameData *GameData `json:"gameData"`
-	GameID   string    `json:"gameID"`
-	GameInfo GameInfo  `json:"gameInfo"`
-	IsAdmin  bool      `json:"isAdmin"`
-}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
 
-// EventPlayerJoined is emitted when a player joins the current game.
-type EventPlayerJoined struct {
-	PlayerName PlayerName `json:"playerName"`
-}
+	if a.T != "PollNextQuestion" {
+		return fmt.Errorf("CommandPollNextQuestion: bad type value: %q", a.T)
+	}
 
-// GameData is the game data. It contains all the information about the game.
-type GameData struct {
-	Value IGameData `json:"-"`
+	*v = CommandPollNextQuestion(a.Alias)
+	return nil
 }
 
-func (v GameData) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.Value)
+func (v CommandPollVote) MarshalJSON() ([]byte, error) {
+	type Alias CommandPollVote
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-func (v *GameData) UnmarshalJSON(b []byte) error {
-	var t struct {
-		T string `json:"game"`
+func (v *CommandPollVote) UnmarshalJSON(b []byte) error {
+	type Alias CommandPollVote
+	var a struct {
+		T string `json:"type"`
+		Alias
 	}
-	if err := json.Unmarshal(b, &t); err != nil {
+
+	if err := json.Unmarshal(b, &a); err != nil {
 		return err
 	}
 
-	var value IGameData
-	var err error
+	if a.T != "PollVote" {
+		return fmt.Errorf("CommandPollVote: bad type value: %q", a.T)
+	}
 
-	switch t.T {
-	case "jeopardy":
-		var v GameDataJeopardy
-		err = json.Unmarshal(b, &v)
-		value = v
-	case "kahoot":
-		var v GameDataKahoot
-		err = json.Unmarshal(b, &v)
-		value = v
-	default:
-		err = fmt.Errorf("GameData: bad game value: %q", t.T)
+	*v = CommandPollVote(a.Alias)
+	return nil
+}
+
+func (v CommandPracticeGame) MarshalJSON() ([]byte, error) {
+	type Alias CommandPracticeGame
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *CommandPracticeGame) UnmarshalJSON(b []byte) error {
+	type Alias CommandPracticeGame
+	var a struct {
+		T string `json:"type"`
+		Alias
 	}
 
-	if err != nil {
+	if err := json.Unmarshal(b, &a); err != nil {
 		return err
 	}
 
-	v.Value = value
+	if a.T != "PracticeGame" {
+		return fmt.Errorf("CommandPracticeGame: bad type value: %q", a.T)
+	}
+
+	*v = CommandPracticeGame(a.Alias)
 	return nil
 }
 
-// IGameData is an interface type that GameData types implement.
-// It can be the following types:
-//
-// - [GameDataJeopardy] (jeopardy)
-// - [GameDataKahoot] (kahoot)
-type IGameData interface {
-	Game() string
-	isGameData()
+func (v CommandQuizAnswer) MarshalJSON() ([]byte, error) {
+	type Alias CommandQuizAnswer
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-func (GameDataJeopardy) Game() string { return "jeopardy" }
-func (GameDataKahoot) Game() string   { return "kahoot" }
+func (v *CommandQuizAnswer) UnmarshalJSON(b []byte) error {
+	type Alias CommandQuizAnswer
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
 
-func (GameDataJeopardy) isGameData() {}
-func (GameDataKahoot) isGameData()   {}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
 
-func (v GameDataJeopardy) MarshalJSON() ([]byte, error) {
-	type Alias GameDataJeopardy
+	if a.T != "QuizAnswer" {
+		return fmt.Errorf("CommandQuizAnswer: bad type value: %q", a.T)
+	}
+
+	*v = CommandQuizAnswer(a.Alias)
+	return nil
+}
+
+func (v CommandQuizNextQuestion) MarshalJSON() ([]byte, error) {
+	type Alias CommandQuizNextQuestion
 	return json.Marshal(struct {
-		T string `json:"game"`
+		T string `json:"type"`
 		Alias
 	}{
-		v.Game(),
+		v.Type(),
 		Alias(v),
 	})
 }
 
-func (v *GameDataJeopardy) UnmarshalJSON(b []byte) error {
-	type Alias GameDataJeopardy
+func (v *CommandQuizNextQuestion) UnmarshalJSON(b []byte) error {
+	type Alias CommandQuizNextQuestion
 	var a struct {
-		T string `json:"game"`
+		T string `json:"type"`
 		Alias
 	}
 
@@ -853,29 +900,29 @@ func (v *GameDataJeopardy) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "jeopardy" {
-		return fmt.Errorf("GameDataJeopardy: bad game value: %q", a.T)
+	if a.T != "QuizNextQuestion" {
+		return fmt.Errorf("CommandQuizNextQuestion: bad type value: %q", a.T)
 	}
 
-	*v = GameDataJeopardy(a.Alias)
+	*v = CommandQuizNextQuestion(a.Alias)
 	return nil
 }
 
-func (v GameDataKahoot) MarshalJSON() ([]byte, error) {
-	type Alias GameDataKahoot
+func (v CommandRequestSnapshot) MarshalJSON() ([]byte, error) {
+	type Alias CommandRequestSnapshot
 	return json.Marshal(struct {
-		T string `json:"game"`
+		T string `json:"type"`
 		Alias
 	}{
-		v.Game(),
+		v.Type(),
 		Alias(v),
 	})
 }
 
-func (v *GameDataKahoot) UnmarshalJSON(b []byte) error {
-	type Alias GameDataKahoot
+func (v *CommandRequestSnapshot) UnmarshalJSON(b []byte) error {
+	type Alias CommandRequestSnapshot
 	var a struct {
-		T string `json:"game"`
+		T string `json:"type"`
 		Alias
 	}
 
@@ -883,35 +930,3606 @@ func (v *GameDataKahoot) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "kahoot" {
-		return fmt.Errorf("GameDataKahoot: bad game value: %q", a.T)
+	if a.T != "RequestSnapshot" {
+		return fmt.Errorf("CommandRequestSnapshot: bad type value: %q", a.T)
 	}
 
-	*v = GameDataKahoot(a.Alias)
+	*v = CommandRequestSnapshot(a.Alias)
 	return nil
 }
 
-type GameDataJeopardy struct {
-	Data JeopardyGameData `json:"data"`
+func (v CommandResumeGame) MarshalJSON() ([]byte, error) {
+	type Alias CommandResumeGame
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *CommandResumeGame) UnmarshalJSON(b []byte) error {
+	type Alias CommandResumeGame
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "ResumeGame" {
+		return fmt.Errorf("CommandResumeGame: bad type value: %q", a.T)
+	}
+
+	*v = CommandResumeGame(a.Alias)
+	return nil
+}
+
+// CommandBeginGame is sent by a client to begin a game.
+type CommandBeginGame struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandBuzzerAwardPoints is sent by a game admin to award points to a
+// player at any time. points may be negative to take points away.
+type CommandBuzzerAwardPoints struct {
+	PlayerName PlayerName `json:"playerName"`
+	Points     float32    `json:"points"`
+	ID         *string    `json:"id,omitempty"`
+}
+
+// CommandBuzzerCloseRound is sent by a game admin to close the current
+// buzz round.
+type CommandBuzzerCloseRound struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandBuzzerOpenRound is sent by a game admin to open a new buzz round.
+// Only one round can be open at a time.
+type CommandBuzzerOpenRound struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandBuzzerPress is sent by a player to buzz in. Each player can only
+// buzz once per round. Pressing while no round is open locks the player
+// out for a short while.
+type CommandBuzzerPress struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandEndGame is sent by a client to end the current game. The server
+// will respond with an EventGameEnded. Only game admins (including the
+// host) can end the game.
+type CommandEndGame struct {
+	// declareWinner determines whether the game should be ended with a
+	// winner or not. If true, the game will be ended with a winner. If
+	// false, the game will be ended abruptly.
+	DeclareWinner bool    `json:"declareWinner"`
+	ID            *string `json:"id,omitempty"`
+}
+
+// CommandFeudNextRound is sent by a game admin to begin the next round
+// once the current one has ended.
+type CommandFeudNextRound struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandFeudPressButton is sent by a face-off player to buzz in.
+type CommandFeudPressButton struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandFeudRevealAnswer is sent by a game admin when the answering
+// player gave one of the answers on the board. answer is its index.
+type CommandFeudRevealAnswer struct {
+	Answer int32   `json:"answer"`
+	ID     *string `json:"id,omitempty"`
+}
+
+// CommandFeudStrike is sent by a game admin when the answering player
+// gave an answer that is not on the board.
+type CommandFeudStrike struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandJeopardyArmBuzzers is sent by a game admin once they have finished
+// reading the current question. Until then, the question is in its reading
+// phase, and any player that presses their button is locked out for a
+// short penalty.
+type CommandJeopardyArmBuzzers struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandJeopardyChooseQuestion is sent by a player to choose a question.
+// The server must do validation to ensure that the player is allowed to
+// choose the question.
+type CommandJeopardyChooseQuestion struct {
+	Category int32   `json:"category"`
+	Question int32   `json:"question"`
+	ID       *string `json:"id,omitempty"`
+}
+
+// CommandJeopardyPlayerJudgment is emitted by a game admin to indicate
+// whether a player has answered a question correctly. The winning player is
+// whoever the last EventJeopardyButtonPressed event indicated. That player
+// will instantly receive the points for the question, and the game will let
+// them choose the next category and question. If the player answered wrong,
+// then the game will let others press the button.
+//
+// In practice games, the player sends it themselves after an
+// EventJeopardySelfJudge.
+type CommandJeopardyPlayerJudgment struct {
+	Correct bool    `json:"correct"`
+	ID      *string `json:"id,omitempty"`
+}
+
+// CommandJeopardyPressButton is emitted when a player presses the button
+// during a question. It is only valid to emit this command when the game is
+// in the question state. Pressing the button before the buzzers are armed
+// locks the player out for a short penalty.
+type CommandJeopardyPressButton struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandJeopardySubmitAnswer is sent by the player of a practice game to
+// answer the current question after pressing their button. The host
+// judges the answer against the accepted answers of the question and
+// replies with an EventJeopardyAnswerJudged. If the question has no
+// accepted answers, the host replies with an EventJeopardySelfJudge
+// instead.
+type CommandJeopardySubmitAnswer struct {
+	Answer string  `json:"answer"`
+	ID     *string `json:"id,omitempty"`
+}
+
+// CommandJoinGame is sent by a client to join a game. The client (or the
+// user) supplies a game ID and a player name. The server will respond with
+// an EventJoinedGame followed by an EventGameSnapshot. Players may join at
+// any time, even once the game has begun, and the snapshot catches them
+// up.
+type CommandJoinGame struct {
+	// adminPassword is the password of the admin of the game.
+	AdminPassword *string `json:"adminPassword"`
+	// gameID is the ID of the game to join.
+	GameID GameID `json:"gameID"`
+	// playerName is the wanted name of the user.
+	PlayerName PlayerName `json:"playerName"`
+	// rejoinToken is the rejoinToken of an earlier EventJoinedGame. If
+	// it matches, the player takes back their place in the game under
+	// the same name instead of joining as a new player.
+	RejoinToken *string `json:"rejoinToken"`
+	ID          *string `json:"id,omitempty"`
+}
+
+// CommandPauseGame is sent by a game admin to pause the game. The server
+// will respond with an EventGamePaused.
+type CommandPauseGame struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandPollNextQuestion is sent by a game admin to close the current
+// question and push the next one. Once there are no questions left, the
+// poll ends.
+type CommandPollNextQuestion struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandPollVote is sent by a player to vote on the current question.
+// choices are the indices of the chosen choices. Voting again replaces the
+// previous vote.
+type CommandPollVote struct {
+	Choices []int32 `json:"choices"`
+	ID      *string `json:"id,omitempty"`
+}
+
+// CommandPracticeGame is sent by a client instead of CommandJoinGame to
+// play a saved game alone. The server creates a private copy of the game
+// that is hosted by a bot: it begins the game right away, judges answers
+// using the stored answers and moves on using timers. The server will
+// respond with an EventJoinedGame, and the score of the player is kept
+// as their personal best once the game ends. Only Jeopardy games and
+// quizzes can be practiced.
+type CommandPracticeGame struct {
+	// gameID is the ID of the game to practice.
+	GameID GameID `json:"gameID"`
+	// playerName is the name of the user.
+	PlayerName PlayerName `json:"playerName"`
+	ID         *string    `json:"id,omitempty"`
+}
+
+// CommandQuizAnswer is sent by a player to answer the current question.
+// Each player can only answer once per question.
+type CommandQuizAnswer struct {
+	Answer QuizAnswer `json:"answer"`
+	ID     *string    `json:"id,omitempty"`
+}
+
+// CommandQuizNextQuestion is sent by a game admin to move on to the next
+// question once the current one is revealed. Once there are no questions
+// left, the quiz ends. In practice games, the host moves on by itself
+// after a short while, but the player may also send this to move on
+// right away.
+type CommandQuizNextQuestion struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandRequestSnapshot is sent by a client to get the current state of
+// its game. The server will respond with an EventGameSnapshot.
+type CommandRequestSnapshot struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// CommandResumeGame is sent by a game admin to resume a paused game. The
+// server will respond with an EventGameResumed.
+type CommandResumeGame struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// DebugReactor is a reactor of a state machine. hook is one of leave,
+// transition, enter and react. from and to are the states that it reacts
+// to leaving and entering, either of which may be any. A react reactor
+// runs once the state from is entered and it offers the state to.
+type DebugReactor struct {
+	From string `json:"from"`
+	Hook string `json:"hook"`
+	To   string `json:"to"`
+}
+
+// Error is returned on every API error.
+type Error struct {
+	// Message is the error message
+	Message string `json:"message"`
+	// Code tells what kind of error this is, if it is known
+	Code *ErrorCode `json:"code,omitempty"`
+	// Details are the individual problems that make up the error, if any
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorCode is a stable code for an error. Unlike the message, clients may
+// match on it. HTTP responses always have a code, which also decides the
+// status of the response.
+//
+//   - invalid_request is when the request itself is wrong, such as game
+//     data that does not make sense (400).
+//   - unauthorized is when the player may never do this, such as a player
+//     who is not an admin pausing the game or a wrong admin password (403).
+//   - not_found is when the thing that was asked for does not exist (404).
+//   - conflict is when the thing that was created already exists (409).
+//   - invalid_state is when the command cannot be sent at this point of
+//     the game, such as answering before a question is asked (409).
+//   - not_your_turn is when the command is only for another player right
+//     now, such as choosing a question when someone else is the chooser
+//     (409).
+//   - slow_subscriber is when the connection fell too far behind on its
+//     events and is about to be closed. The client may join again with its
+//     rejoin token (503).
+//   - internal is when the server failed on its own (500).
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidRequest ErrorCode = "invalid_request"
+	ErrorCodeUnauthorized   ErrorCode = "unauthorized"
+	ErrorCodeNotFound       ErrorCode = "not_found"
+	ErrorCodeConflict       ErrorCode = "conflict"
+	ErrorCodeInvalidState   ErrorCode = "invalid_state"
+	ErrorCodeNotYourTurn    ErrorCode = "not_your_turn"
+	ErrorCodeSlowSubscriber ErrorCode = "slow_subscriber"
+	ErrorCodeInternal       ErrorCode = "internal"
+)
+
+// ErrorDetail is one of the problems that make up an Error, such as one
+// of the many mistakes in an uploaded Jeopardy board. message explains
+// the problem. path is a JSON Pointer to the value that the problem is
+// in, if it is about one. schema_path is a JSON Pointer to the part of
+// the schema that the value does not match, if the problem was found by
+// checking the value against the schema.
+type ErrorDetail struct {
+	Message    string  `json:"message"`
+	Path       *string `json:"path,omitempty"`
+	SchemaPath *string `json:"schema_path,omitempty"`
+}
+
+type Event struct {
+	Value IEvent `json:"-"`
+}
+
+func (v Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *Event) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IEvent
+	var err error
+
+	switch t.T {
+	case "Ack":
+		var v EventAck
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerBuzzes":
+		var v EventBuzzerBuzzes
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerLockedOut":
+		var v EventBuzzerLockedOut
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerPointsAwarded":
+		var v EventBuzzerPointsAwarded
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerRoundClosed":
+		var v EventBuzzerRoundClosed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "BuzzerRoundOpened":
+		var v EventBuzzerRoundOpened
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "Error":
+		var v EventError
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudAnswerKey":
+		var v EventFeudAnswerKey
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudAnswerRevealed":
+		var v EventFeudAnswerRevealed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudBeginRound":
+		var v EventFeudBeginRound
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudButtonPressed":
+		var v EventFeudButtonPressed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudRoundEnded":
+		var v EventFeudRoundEnded
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudStrike":
+		var v EventFeudStrike
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "FeudTurn":
+		var v EventFeudTurn
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "GameEnded":
+		var v EventGameEnded
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "GamePaused":
+		var v EventGamePaused
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "GameResumed":
+		var v EventGameResumed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "GameSnapshot":
+		var v EventGameSnapshot
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "GameStarted":
+		var v EventGameStarted
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyAnswerJudged":
+		var v EventJeopardyAnswerJudged
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyBeginQuestion":
+		var v EventJeopardyBeginQuestion
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyButtonPressed":
+		var v EventJeopardyButtonPressed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyBuzzerLockedOut":
+		var v EventJeopardyBuzzerLockedOut
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyBuzzersArmed":
+		var v EventJeopardyBuzzersArmed
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyResumeButton":
+		var v EventJeopardyResumeButton
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardySelfJudge":
+		var v EventJeopardySelfJudge
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JeopardyTurnEnded":
+		var v EventJeopardyTurnEnded
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "JoinedGame":
+		var v EventJoinedGame
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PlayerJoined":
+		var v EventPlayerJoined
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PollBeginQuestion":
+		var v EventPollBeginQuestion
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PollResults":
+		var v EventPollResults
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "PracticeEnded":
+		var v EventPracticeEnded
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizBeginQuestion":
+		var v EventQuizBeginQuestion
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizEliminated":
+		var v EventQuizEliminated
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizPlayerAnswered":
+		var v EventQuizPlayerAnswered
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "QuizReveal":
+		var v EventQuizReveal
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("Event: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IEvent is an interface type that Event types implement.
+// It can be the following types:
+//
+// - [EventAck] (Ack)
+// - [EventBuzzerBuzzes] (BuzzerBuzzes)
+// - [EventBuzzerLockedOut] (BuzzerLockedOut)
+// - [EventBuzzerPointsAwarded] (BuzzerPointsAwarded)
+// - [EventBuzzerRoundClosed] (BuzzerRoundClosed)
+// - [EventBuzzerRoundOpened] (BuzzerRoundOpened)
+// - [EventError] (Error)
+// - [EventFeudAnswerKey] (FeudAnswerKey)
+// - [EventFeudAnswerRevealed] (FeudAnswerRevealed)
+// - [EventFeudBeginRound] (FeudBeginRound)
+// - [EventFeudButtonPressed] (FeudButtonPressed)
+// - [EventFeudRoundEnded] (FeudRoundEnded)
+// - [EventFeudStrike] (FeudStrike)
+// - [EventFeudTurn] (FeudTurn)
+// - [EventGameEnded] (GameEnded)
+// - [EventGamePaused] (GamePaused)
+// - [EventGameResumed] (GameResumed)
+// - [EventGameSnapshot] (GameSnapshot)
+// - [EventGameStarted] (GameStarted)
+// - [EventJeopardyAnswerJudged] (JeopardyAnswerJudged)
+// - [EventJeopardyBeginQuestion] (JeopardyBeginQuestion)
+// - [EventJeopardyButtonPressed] (JeopardyButtonPressed)
+// - [EventJeopardyBuzzerLockedOut] (JeopardyBuzzerLockedOut)
+// - [EventJeopardyBuzzersArmed] (JeopardyBuzzersArmed)
+// - [EventJeopardyResumeButton] (JeopardyResumeButton)
+// - [EventJeopardySelfJudge] (JeopardySelfJudge)
+// - [EventJeopardyTurnEnded] (JeopardyTurnEnded)
+// - [EventJoinedGame] (JoinedGame)
+// - [EventPlayerJoined] (PlayerJoined)
+// - [EventPollBeginQuestion] (PollBeginQuestion)
+// - [EventPollResults] (PollResults)
+// - [EventPracticeEnded] (PracticeEnded)
+// - [EventQuizBeginQuestion] (QuizBeginQuestion)
+// - [EventQuizEliminated] (QuizEliminated)
+// - [EventQuizPlayerAnswered] (QuizPlayerAnswered)
+// - [EventQuizReveal] (QuizReveal)
+type IEvent interface {
+	Type() string
+	isEvent()
+}
+
+func (EventAck) Type() string                     { return "Ack" }
+func (EventBuzzerBuzzes) Type() string            { return "BuzzerBuzzes" }
+func (EventBuzzerLockedOut) Type() string         { return "BuzzerLockedOut" }
+func (EventBuzzerPointsAwarded) Type() string     { return "BuzzerPointsAwarded" }
+func (EventBuzzerRoundClosed) Type() string       { return "BuzzerRoundClosed" }
+func (EventBuzzerRoundOpened) Type() string       { return "BuzzerRoundOpened" }
+func (EventError) Type() string                   { return "Error" }
+func (EventFeudAnswerKey) Type() string           { return "FeudAnswerKey" }
+func (EventFeudAnswerRevealed) Type() string      { return "FeudAnswerRevealed" }
+func (EventFeudBeginRound) Type() string          { return "FeudBeginRound" }
+func (EventFeudButtonPressed) Type() string       { return "FeudButtonPressed" }
+func (EventFeudRoundEnded) Type() string          { return "FeudRoundEnded" }
+func (EventFeudStrike) Type() string              { return "FeudStrike" }
+func (EventFeudTurn) Type() string                { return "FeudTurn" }
+func (EventGameEnded) Type() string               { return "GameEnded" }
+func (EventGamePaused) Type() string              { return "GamePaused" }
+func (EventGameResumed) Type() string             { return "GameResumed" }
+func (EventGameSnapshot) Type() string            { return "GameSnapshot" }
+func (EventGameStarted) Type() string             { return "GameStarted" }
+func (EventJeopardyAnswerJudged) Type() string    { return "JeopardyAnswerJudged" }
+func (EventJeopardyBeginQuestion) Type() string   { return "JeopardyBeginQuestion" }
+func (EventJeopardyButtonPressed) Type() string   { return "JeopardyButtonPressed" }
+func (EventJeopardyBuzzerLockedOut) Type() string { return "JeopardyBuzzerLockedOut" }
+func (EventJeopardyBuzzersArmed) Type() string    { return "JeopardyBuzzersArmed" }
+func (EventJeopardyResumeButton) Type() string    { return "JeopardyResumeButton" }
+func (EventJeopardySelfJudge) Type() string       { return "JeopardySelfJudge" }
+func (EventJeopardyTurnEnded) Type() string       { return "JeopardyTurnEnded" }
+func (EventJoinedGame) Type() string              { return "JoinedGame" }
+func (EventPlayerJoined) Type() string            { return "PlayerJoined" }
+func (EventPollBeginQuestion) Type() string       { return "PollBeginQuestion" }
+func (EventPollResults) Type() string             { return "PollResults" }
+func (EventPracticeEnded) Type() string           { return "PracticeEnded" }
+func (EventQuizBeginQuestion) Type() string       { return "QuizBeginQuestion" }
+func (EventQuizEliminated) Type() string          { return "QuizEliminated" }
+func (EventQuizPlayerAnswered) Type() string      { return "QuizPlayerAnswered" }
+func (EventQuizReveal) Type() string              { return "QuizReveal" }
+
+func (EventAck) isEvent()                     {}
+func (EventBuzzerBuzzes) isEvent()            {}
+func (EventBuzzerLockedOut) isEvent()         {}
+func (EventBuzzerPointsAwarded) isEvent()     {}
+func (EventBuzzerRoundClosed) isEvent()       {}
+func (EventBuzzerRoundOpened) isEvent()       {}
+func (EventError) isEvent()                   {}
+func (EventFeudAnswerKey) isEvent()           {}
+func (EventFeudAnswerRevealed) isEvent()      {}
+func (EventFeudBeginRound) isEvent()          {}
+func (EventFeudButtonPressed) isEvent()       {}
+func (EventFeudRoundEnded) isEvent()          {}
+func (EventFeudStrike) isEvent()              {}
+func (EventFeudTurn) isEvent()                {}
+func (EventGameEnded) isEvent()               {}
+func (EventGamePaused) isEvent()              {}
+func (EventGameResumed) isEvent()             {}
+func (EventGameSnapshot) isEvent()            {}
+func (EventGameStarted) isEvent()             {}
+func (EventJeopardyAnswerJudged) isEvent()    {}
+func (EventJeopardyBeginQuestion) isEvent()   {}
+func (EventJeopardyButtonPressed) isEvent()   {}
+func (EventJeopardyBuzzerLockedOut) isEvent() {}
+func (EventJeopardyBuzzersArmed) isEvent()    {}
+func (EventJeopardyResumeButton) isEvent()    {}
+func (EventJeopardySelfJudge) isEvent()       {}
+func (EventJeopardyTurnEnded) isEvent()       {}
+func (EventJoinedGame) isEvent()              {}
+func (EventPlayerJoined) isEvent()            {}
+func (EventPollBeginQuestion) isEvent()       {}
+func (EventPollResults) isEvent()             {}
+func (EventPracticeEnded) isEvent()           {}
+func (EventQuizBeginQuestion) isEvent()       {}
+func (EventQuizEliminated) isEvent()          {}
+func (EventQuizPlayerAnswered) isEvent()      {}
+func (EventQuizReveal) isEvent()              {}
+
+func (v EventAck) MarshalJSON() ([]byte, error) {
+	type Alias EventAck
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventAck) UnmarshalJSON(b []byte) error {
+	type Alias EventAck
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "Ack" {
+		return fmt.Errorf("EventAck: bad type value: %q", a.T)
+	}
+
+	*v = EventAck(a.Alias)
+	return nil
+}
+
+func (v EventBuzzerBuzzes) MarshalJSON() ([]byte, error) {
+	type Alias EventBuzzerBuzzes
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventBuzzerBuzzes) UnmarshalJSON(b []byte) error {
+	type Alias EventBuzzerBuzzes
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "BuzzerBuzzes" {
+		return fmt.Errorf("EventBuzzerBuzzes: bad type value: %q", a.T)
+	}
+
+	*v = EventBuzzerBuzzes(a.Alias)
+	return nil
+}
+
+func (v EventBuzzerLockedOut) MarshalJSON() ([]byte, error) {
+	type Alias EventBuzzerLockedOut
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventBuzzerLockedOut) UnmarshalJSON(b []byte) error {
+	type Alias EventBuzzerLockedOut
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "BuzzerLockedOut" {
+		return fmt.Errorf("EventBuzzerLockedOut: bad type value: %q", a.T)
+	}
+
+	*v = EventBuzzerLockedOut(a.Alias)
+	return nil
+}
+
+func (v EventBuzzerPointsAwarded) MarshalJSON() ([]byte, error) {
+	type Alias EventBuzzerPointsAwarded
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventBuzzerPointsAwarded) UnmarshalJSON(b []byte) error {
+	type Alias EventBuzzerPointsAwarded
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "BuzzerPointsAwarded" {
+		return fmt.Errorf("EventBuzzerPointsAwarded: bad type value: %q", a.T)
+	}
+
+	*v = EventBuzzerPointsAwarded(a.Alias)
+	return nil
+}
+
+func (v EventBuzzerRoundClosed) MarshalJSON() ([]byte, error) {
+	type Alias EventBuzzerRoundClosed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventBuzzerRoundClosed) UnmarshalJSON(b []byte) error {
+	type Alias EventBuzzerRoundClosed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "BuzzerRoundClosed" {
+		return fmt.Errorf("EventBuzzerRoundClosed: bad type value: %q", a.T)
+	}
+
+	*v = EventBuzzerRoundClosed(a.Alias)
+	return nil
+}
+
+func (v EventBuzzerRoundOpened) MarshalJSON() ([]byte, error) {
+	type Alias EventBuzzerRoundOpened
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventBuzzerRoundOpened) UnmarshalJSON(b []byte) error {
+	type Alias EventBuzzerRoundOpened
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "BuzzerRoundOpened" {
+		return fmt.Errorf("EventBuzzerRoundOpened: bad type value: %q", a.T)
+	}
+
+	*v = EventBuzzerRoundOpened(a.Alias)
+	return nil
+}
+
+func (v EventError) MarshalJSON() ([]byte, error) {
+	type Alias EventError
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventError) UnmarshalJSON(b []byte) error {
+	type Alias EventError
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "Error" {
+		return fmt.Errorf("EventError: bad type value: %q", a.T)
+	}
+
+	*v = EventError(a.Alias)
+	return nil
+}
+
+func (v EventFeudAnswerKey) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudAnswerKey
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudAnswerKey) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudAnswerKey
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudAnswerKey" {
+		return fmt.Errorf("EventFeudAnswerKey: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudAnswerKey(a.Alias)
+	return nil
+}
+
+func (v EventFeudAnswerRevealed) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudAnswerRevealed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudAnswerRevealed) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudAnswerRevealed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudAnswerRevealed" {
+		return fmt.Errorf("EventFeudAnswerRevealed: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudAnswerRevealed(a.Alias)
+	return nil
+}
+
+func (v EventFeudBeginRound) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudBeginRound
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudBeginRound) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudBeginRound
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudBeginRound" {
+		return fmt.Errorf("EventFeudBeginRound: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudBeginRound(a.Alias)
+	return nil
+}
+
+func (v EventFeudButtonPressed) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudButtonPressed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudButtonPressed) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudButtonPressed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudButtonPressed" {
+		return fmt.Errorf("EventFeudButtonPressed: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudButtonPressed(a.Alias)
+	return nil
+}
+
+func (v EventFeudRoundEnded) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudRoundEnded
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudRoundEnded) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudRoundEnded
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudRoundEnded" {
+		return fmt.Errorf("EventFeudRoundEnded: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudRoundEnded(a.Alias)
+	return nil
+}
+
+func (v EventFeudStrike) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudStrike
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudStrike) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudStrike
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudStrike" {
+		return fmt.Errorf("EventFeudStrike: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudStrike(a.Alias)
+	return nil
+}
+
+func (v EventFeudTurn) MarshalJSON() ([]byte, error) {
+	type Alias EventFeudTurn
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventFeudTurn) UnmarshalJSON(b []byte) error {
+	type Alias EventFeudTurn
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "FeudTurn" {
+		return fmt.Errorf("EventFeudTurn: bad type value: %q", a.T)
+	}
+
+	*v = EventFeudTurn(a.Alias)
+	return nil
+}
+
+func (v EventGameEnded) MarshalJSON() ([]byte, error) {
+	type Alias EventGameEnded
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventGameEnded) UnmarshalJSON(b []byte) error {
+	type Alias EventGameEnded
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "GameEnded" {
+		return fmt.Errorf("EventGameEnded: bad type value: %q", a.T)
+	}
+
+	*v = EventGameEnded(a.Alias)
+	return nil
+}
+
+func (v EventGamePaused) MarshalJSON() ([]byte, error) {
+	type Alias EventGamePaused
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventGamePaused) UnmarshalJSON(b []byte) error {
+	type Alias EventGamePaused
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "GamePaused" {
+		return fmt.Errorf("EventGamePaused: bad type value: %q", a.T)
+	}
+
+	*v = EventGamePaused(a.Alias)
+	return nil
+}
+
+func (v EventGameResumed) MarshalJSON() ([]byte, error) {
+	type Alias EventGameResumed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventGameResumed) UnmarshalJSON(b []byte) error {
+	type Alias EventGameResumed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "GameResumed" {
+		return fmt.Errorf("EventGameResumed: bad type value: %q", a.T)
+	}
+
+	*v = EventGameResumed(a.Alias)
+	return nil
+}
+
+func (v EventGameSnapshot) MarshalJSON() ([]byte, error) {
+	type Alias EventGameSnapshot
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventGameSnapshot) UnmarshalJSON(b []byte) error {
+	type Alias EventGameSnapshot
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "GameSnapshot" {
+		return fmt.Errorf("EventGameSnapshot: bad type value: %q", a.T)
+	}
+
+	*v = EventGameSnapshot(a.Alias)
+	return nil
+}
+
+func (v EventGameStarted) MarshalJSON() ([]byte, error) {
+	type Alias EventGameStarted
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventGameStarted) UnmarshalJSON(b []byte) error {
+	type Alias EventGameStarted
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "GameStarted" {
+		return fmt.Errorf("EventGameStarted: bad type value: %q", a.T)
+	}
+
+	*v = EventGameStarted(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyAnswerJudged) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyAnswerJudged
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyAnswerJudged) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyAnswerJudged
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyAnswerJudged" {
+		return fmt.Errorf("EventJeopardyAnswerJudged: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyAnswerJudged(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyBeginQuestion) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyBeginQuestion
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyBeginQuestion) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyBeginQuestion
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyBeginQuestion" {
+		return fmt.Errorf("EventJeopardyBeginQuestion: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyBeginQuestion(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyButtonPressed) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyButtonPressed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyButtonPressed) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyButtonPressed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyButtonPressed" {
+		return fmt.Errorf("EventJeopardyButtonPressed: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyButtonPressed(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyBuzzerLockedOut) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyBuzzerLockedOut
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyBuzzerLockedOut) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyBuzzerLockedOut
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyBuzzerLockedOut" {
+		return fmt.Errorf("EventJeopardyBuzzerLockedOut: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyBuzzerLockedOut(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyBuzzersArmed) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyBuzzersArmed
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyBuzzersArmed) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyBuzzersArmed
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyBuzzersArmed" {
+		return fmt.Errorf("EventJeopardyBuzzersArmed: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyBuzzersArmed(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyResumeButton) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyResumeButton
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyResumeButton) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyResumeButton
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyResumeButton" {
+		return fmt.Errorf("EventJeopardyResumeButton: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyResumeButton(a.Alias)
+	return nil
+}
+
+func (v EventJeopardySelfJudge) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardySelfJudge
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardySelfJudge) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardySelfJudge
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardySelfJudge" {
+		return fmt.Errorf("EventJeopardySelfJudge: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardySelfJudge(a.Alias)
+	return nil
+}
+
+func (v EventJeopardyTurnEnded) MarshalJSON() ([]byte, error) {
+	type Alias EventJeopardyTurnEnded
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJeopardyTurnEnded) UnmarshalJSON(b []byte) error {
+	type Alias EventJeopardyTurnEnded
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JeopardyTurnEnded" {
+		return fmt.Errorf("EventJeopardyTurnEnded: bad type value: %q", a.T)
+	}
+
+	*v = EventJeopardyTurnEnded(a.Alias)
+	return nil
+}
+
+func (v EventJoinedGame) MarshalJSON() ([]byte, error) {
+	type Alias EventJoinedGame
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventJoinedGame) UnmarshalJSON(b []byte) error {
+	type Alias EventJoinedGame
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "JoinedGame" {
+		return fmt.Errorf("EventJoinedGame: bad type value: %q", a.T)
+	}
+
+	*v = EventJoinedGame(a.Alias)
+	return nil
+}
+
+func (v EventPlayerJoined) MarshalJSON() ([]byte, error) {
+	type Alias EventPlayerJoined
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventPlayerJoined) UnmarshalJSON(b []byte) error {
+	type Alias EventPlayerJoined
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "PlayerJoined" {
+		return fmt.Errorf("EventPlayerJoined: bad type value: %q", a.T)
+	}
+
+	*v = EventPlayerJoined(a.Alias)
+	return nil
+}
+
+func (v EventPollBeginQuestion) MarshalJSON() ([]byte, error) {
+	type Alias EventPollBeginQuestion
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventPollBeginQuestion) UnmarshalJSON(b []byte) error {
+	type Alias EventPollBeginQuestion
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "PollBeginQuestion" {
+		return fmt.Errorf("EventPollBeginQuestion: bad type value: %q", a.T)
+	}
+
+	*v = EventPollBeginQuestion(a.Alias)
+	return nil
+}
+
+func (v EventPollResults) MarshalJSON() ([]byte, error) {
+	type Alias EventPollResults
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventPollResults) UnmarshalJSON(b []byte) error {
+	type Alias EventPollResults
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "PollResults" {
+		return fmt.Errorf("EventPollResults: bad type value: %q", a.T)
+	}
+
+	*v = EventPollResults(a.Alias)
+	return nil
+}
+
+func (v EventPracticeEnded) MarshalJSON() ([]byte, error) {
+	type Alias EventPracticeEnded
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventPracticeEnded) UnmarshalJSON(b []byte) error {
+	type Alias EventPracticeEnded
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "PracticeEnded" {
+		return fmt.Errorf("EventPracticeEnded: bad type value: %q", a.T)
+	}
+
+	*v = EventPracticeEnded(a.Alias)
+	return nil
+}
+
+func (v EventQuizBeginQuestion) MarshalJSON() ([]byte, error) {
+	type Alias EventQuizBeginQuestion
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventQuizBeginQuestion) UnmarshalJSON(b []byte) error {
+	type Alias EventQuizBeginQuestion
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "QuizBeginQuestion" {
+		return fmt.Errorf("EventQuizBeginQuestion: bad type value: %q", a.T)
+	}
+
+	*v = EventQuizBeginQuestion(a.Alias)
+	return nil
+}
+
+func (v EventQuizEliminated) MarshalJSON() ([]byte, error) {
+	type Alias EventQuizEliminated
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventQuizEliminated) UnmarshalJSON(b []byte) error {
+	type Alias EventQuizEliminated
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "QuizEliminated" {
+		return fmt.Errorf("EventQuizEliminated: bad type value: %q", a.T)
+	}
+
+	*v = EventQuizEliminated(a.Alias)
+	return nil
+}
+
+func (v EventQuizPlayerAnswered) MarshalJSON() ([]byte, error) {
+	type Alias EventQuizPlayerAnswered
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventQuizPlayerAnswered) UnmarshalJSON(b []byte) error {
+	type Alias EventQuizPlayerAnswered
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "QuizPlayerAnswered" {
+		return fmt.Errorf("EventQuizPlayerAnswered: bad type value: %q", a.T)
+	}
+
+	*v = EventQuizPlayerAnswered(a.Alias)
+	return nil
+}
+
+func (v EventQuizReveal) MarshalJSON() ([]byte, error) {
+	type Alias EventQuizReveal
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *EventQuizReveal) UnmarshalJSON(b []byte) error {
+	type Alias EventQuizReveal
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "QuizReveal" {
+		return fmt.Errorf("EventQuizReveal: bad type value: %q", a.T)
+	}
+
+	*v = EventQuizReveal(a.Alias)
+	return nil
+}
+
+// EventAck is emitted once a command with an ID has been handled without
+// error. id is the ID of the command. Events that the command caused may
+// arrive before or after it.
+type EventAck struct {
+	ID string `json:"id"`
+}
+
+// EventBuzzerBuzzes is emitted whenever a player buzzes in. buzzes lists
+// every buzz of the round so far in order, starting with the earliest.
+type EventBuzzerBuzzes struct {
+	Buzzes []BuzzerBuzz `json:"buzzes"`
+	Round  int32        `json:"round"`
+}
+
+// EventBuzzerLockedOut is emitted when a player presses their button while
+// no round is open. The player's buzzer is locked out for penalty
+// milliseconds.
+type EventBuzzerLockedOut struct {
+	Penalty    float32    `json:"penalty"`
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventBuzzerPointsAwarded is emitted when the admin awards points to a
+// player.
+type EventBuzzerPointsAwarded struct {
+	Leaderboard Leaderboard `json:"leaderboard"`
+	PlayerName  PlayerName  `json:"playerName"`
+	Points      float32     `json:"points"`
+}
+
+// EventBuzzerRoundClosed is emitted when the admin closes the current buzz
+// round. buzzes is the final order of the buzzes of the round.
+type EventBuzzerRoundClosed struct {
+	Buzzes []BuzzerBuzz `json:"buzzes"`
+	Round  int32        `json:"round"`
+}
+
+// EventBuzzerRoundOpened is emitted when the admin opens a buzz round.
+// Players can buzz in until the round is closed.
+type EventBuzzerRoundOpened struct {
+	Round int32 `json:"round"`
+}
+
+// EventError is emitted when a command fails. id is the ID of the
+// command, if the client gave it one.
+type EventError struct {
+	Error Error   `json:"error"`
+	ID    *string `json:"id,omitempty"`
+}
+
+// EventFeudAnswerKey is emitted only to admins when a round begins. It
+// holds every answer on the board, so that admins can judge the answers
+// of the players before they are revealed.
+type EventFeudAnswerKey struct {
+	Answers []FeudAnswer `json:"answers"`
+	Round   int32        `json:"round"`
+}
+
+// EventFeudAnswerRevealed is emitted when an answer on the board is
+// revealed. bank is the total points of the answers revealed so far this
+// round, which the winner of the round gets.
+type EventFeudAnswerRevealed struct {
+	Answer int32   `json:"answer"`
+	Bank   float32 `json:"bank"`
+	Points float32 `json:"points"`
+	Team   int32   `json:"team"`
+	Text   string  `json:"text"`
+}
+
+// EventFeudBeginRound is emitted when a round begins, including the first
+// one once the game starts. The round begins with a face-off between the
+// two faceOff players, one from each team.
+type EventFeudBeginRound struct {
+	FaceOff    []PlayerName `json:"faceOff"`
+	NumAnswers int32        `json:"numAnswers"`
+	Question   string       `json:"question"`
+	Round      int32        `json:"round"`
+	Teams      []FeudTeam   `json:"teams"`
+}
+
+// EventFeudButtonPressed is emitted when a face-off player presses their
+// button first. That player gets to answer first.
+type EventFeudButtonPressed struct {
+	PlayerName PlayerName `json:"playerName"`
+	Team       int32      `json:"team"`
+}
+
+// EventFeudRoundEnded is emitted when a round ends. The winner team gets
+// the points in the bank. All answers of the round are revealed.
+type EventFeudRoundEnded struct {
+	Answers []FeudAnswer `json:"answers"`
+	Points  float32      `json:"points"`
+	Teams   []FeudTeam   `json:"teams"`
+	Winner  int32        `json:"winner"`
+}
+
+// EventFeudStrike is emitted when a team answers wrong. strikes only
+// counts up in the play phase.
+type EventFeudStrike struct {
+	Strikes int32 `json:"strikes"`
+	Team    int32 `json:"team"`
+}
+
+// EventFeudTurn is emitted whenever the game waits for a player to answer.
+// The admin then judges the answer using CommandFeudRevealAnswer or
+// CommandFeudStrike. In the steal phase, playerName answers on behalf of
+// their team.
+type EventFeudTurn struct {
+	Phase      FeudPhase  `json:"phase"`
+	PlayerName PlayerName `json:"playerName"`
+	Team       int32      `json:"team"`
+}
+
+// EventGameEnded is emitted when the current game ends.
+type EventGameEnded struct {
+	Leaderboard Leaderboard `json:"leaderboard"`
+}
+
+// EventGamePaused is emitted when an admin pauses the game. While the game
+// is paused, only admins may send commands other than CommandJoinGame,
+// and all of the game's timers are frozen.
+type EventGamePaused struct {
+	// playerName is the name of the admin who paused the game.
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventGameResumed is emitted when an admin resumes a paused game.
+type EventGameResumed struct {
+	// playerName is the name of the admin who resumed the game.
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventGameSnapshot is emitted to a player once they join, to everyone
+// once the game is resumed, and to a player who sends a
+// CommandRequestSnapshot. It describes the whole current state of the
+// game, so that players who join late or reconnect can catch up without
+// waiting for the next turn. players are the players who are not admins
+// in the order that they joined. game holds the state that is specific
+// to the game type, if the game type has any.
+type EventGameSnapshot struct {
+	Ended       bool          `json:"ended"`
+	Game        *GameSnapshot `json:"game"`
+	Leaderboard Leaderboard   `json:"leaderboard"`
+	Paused      bool          `json:"paused"`
+	Players     []PlayerName  `json:"players"`
+	Started     bool          `json:"started"`
+}
+
+// EventGameStarted is emitted when the game starts. It contains no data and
+// is only meant to be used to trigger the client to start the game.
+type EventGameStarted struct {
+}
+
+// EventJeopardyAnswerJudged is emitted in practice games once the host has
+// judged the answer of the player, or once the player ran out of time, in
+// which case answer is empty. answers are the accepted answers to the
+// question.
+type EventJeopardyAnswerJudged struct {
+	Answer  string   `json:"answer"`
+	Answers []string `json:"answers"`
+	Correct bool     `json:"correct"`
+}
+
+// EventJeopardyBeginQuestion is emitted when a question begins within this
+// Jeopardy game. It is usually emitted once the chooser player has chosen a
+// category and value. The question starts in its reading phase, which
+// lasts until an admin sends a CommandJeopardyArmBuzzers.
+//
+// Each category name and question value will map to a category and question
+// within the game data. Note that a question may repeat across multiple
+// categories. content holds the content blocks of the question, if any.
+type EventJeopardyBeginQuestion struct {
+	Category int32             `json:"category"`
+	Chooser  PlayerName        `json:"chooser"`
+	Content  []QuestionContent `json:"content"`
+	Points   float32           `json:"points"`
+	Question string            `json:"question"`
+}
+
+// EventJeopardyButtonPressed is emitted when any player had pressed a button
+// on their device, voiding other players' buttons. This event is only
+// emitted when the game is in the "question" state.
+//
+// If the game uses a fair buzzer, buzzes contains every button press
+// collected within the buzz window in compensated order, and playerName
+// is the first of them. Otherwise, buzzes only contains playerName.
+type EventJeopardyButtonPressed struct {
+	Buzzes     []JeopardyBuzz `json:"buzzes"`
+	PlayerName PlayerName     `json:"playerName"`
+}
+
+// EventJeopardyBuzzerLockedOut is emitted when a player presses their
+// button during the reading phase, before the buzzers are armed. The
+// player cannot press their button again until the penalty has passed.
+type EventJeopardyBuzzerLockedOut struct {
+	// penalty is the lockout duration in milliseconds.
+	Penalty    float32    `json:"penalty"`
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventJeopardyBuzzersArmed is emitted when the admin arms the buzzers,
+// ending the reading phase of the current question. Players may press
+// their button from now on.
+type EventJeopardyBuzzersArmed struct {
+}
+
+// EventJeopardyResumeButton is emitted when the player can now continue to
+// press the button whenever they are ready to answer the question. This
+// could happen if the other player who pressed the button first got the
+// question wrong.
+//
+// Note that if alreadyPressed is true, then the player has already pressed
+// the button, so they cannot press it again.
+type EventJeopardyResumeButton struct {
+	AlreadyAnsweredPlayers []PlayerName `json:"alreadyAnsweredPlayers"`
+}
+
+// EventJeopardySelfJudge is emitted in practice games once the player has
+// answered a question that has no accepted answers, such as one from a
+// set that was saved before answers could be stored. The host cannot
+// judge it, so the player judges their own answer by sending a
+// CommandJeopardyPlayerJudgment before they run out of time. A correct
+// answer uses up the question but scores no points, so that personal
+// bests only count answers that the host could check.
+type EventJeopardySelfJudge struct {
+	Answer string `json:"answer"`
+}
+
+// EventJeopardyTurnEnded is emitted when a turn ends or when the game first
+// starts.
+type EventJeopardyTurnEnded struct {
+	Answered    JeopardyAnsweredQuestions `json:"answered"`
+	Chooser     PlayerName                `json:"chooser"`
+	Leaderboard Leaderboard               `json:"leaderboard"`
+}
+
+// EventJoinedGame is emitted when the current player joins a game. It is a
+// reply to CommandJoinGame and is only for the current player. Not to be
+// confused with EventPlayerJoinedGame, which is emitted when any player
+// joins the current game.
+//
+// rejoinToken lets the player take their place back if they lose their
+// connection, such as when the server closes a connection that fell too
+// far behind. The client keeps it secret and sends it along with the same
+// player name in the CommandJoinGame of its new connection.
+type EventJoinedGame struct {
+	GameData    *GameData `json:"gameData"`
+	GameID      string    `json:"gameID"`
+	GameInfo    GameInfo  `json:"gameInfo"`
+	IsAdmin     bool      `json:"isAdmin"`
+	RejoinToken string    `json:"rejoinToken"`
+}
+
+// EventPlayerJoined is emitted when a player joins the current game.
+type EventPlayerJoined struct {
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventPollBeginQuestion is emitted when the admin pushes a new question.
+// Voting on the previous question is closed.
+type EventPollBeginQuestion struct {
+	Choices  []string `json:"choices"`
+	Index    int32    `json:"index"`
+	Multiple bool     `json:"multiple"`
+	Question string   `json:"question"`
+}
+
+// EventPollResults is emitted whenever a player votes. It contains the
+// aggregated results of the current question so far.
+type EventPollResults struct {
+	Index  int32      `json:"index"`
+	Result PollResult `json:"result"`
+}
+
+// EventPracticeEnded is emitted after EventGameEnded once a practice game
+// ends. score is the final score of the player, and personalBest is their
+// best score in this game, including this one. newPersonalBest is true if
+// this score beat every previous one.
+type EventPracticeEnded struct {
+	NewPersonalBest bool    `json:"newPersonalBest"`
+	PersonalBest    float32 `json:"personalBest"`
+	Score           float32 `json:"score"`
+}
+
+// EventQuizBeginQuestion is emitted when a question begins. Players have
+// timeLimit milliseconds to answer it. True or false questions have the
+// choices "True" and "False", estimation questions have no choices, and
+// the choices of ordering questions are their items in a random order.
+// suddenDeath is true for the sudden death questions of a survival quiz.
+type EventQuizBeginQuestion struct {
+	Choices      []string         `json:"choices"`
+	Index        int32            `json:"index"`
+	Question     string           `json:"question"`
+	QuestionType QuizQuestionType `json:"questionType"`
+	SuddenDeath  bool             `json:"suddenDeath"`
+	TimeLimit    float32          `json:"timeLimit"`
+}
+
+// EventQuizEliminated is emitted after each reveal of a survival quiz.
+// eliminated lists the players who were eliminated by the question, and
+// survivors lists the players who are still in the game.
+type EventQuizEliminated struct {
+	Eliminated []PlayerName `json:"eliminated"`
+	Index      int32        `json:"index"`
+	Survivors  []PlayerName `json:"survivors"`
+}
+
+// EventQuizPlayerAnswered is emitted when a player answers the current
+// question. The answer itself is not revealed until the question ends.
+type EventQuizPlayerAnswered struct {
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// EventQuizReveal is emitted once everyone has answered the current
+// question or its time is up. correct lists the players who got the
+// question right, or the closest guesses for an estimation question.
+type EventQuizReveal struct {
+	Correct     []PlayerName `json:"correct"`
+	Index       int32        `json:"index"`
+	Leaderboard Leaderboard  `json:"leaderboard"`
+	Result      QuizResult   `json:"result"`
+}
+
+type FeudAnswer struct {
+	Answer string  `json:"answer"`
+	Points float32 `json:"points"`
+}
+
+// FeudGameData is the game data for a Family Feud game. Two teams play
+// one round per question.
+type FeudGameData struct {
+	Questions []FeudQuestion `json:"questions"`
+	// max_strikes is the number of strikes that the team in control may
+	// get before the other team gets to steal. The default is 3.
+	MaxStrikes *uint32 `json:"max_strikes,omitempty"`
+	// team_names are the names of the two teams. The default is
+	// "Team 1" and "Team 2".
+	TeamNames []string `json:"team_names,omitempty"`
+}
+
+type FeudGameInfo struct {
+	NumQuestions int32    `json:"numQuestions"`
+	TeamNames    []string `json:"teamNames"`
+}
+
+// FeudPhase is the phase of a Family Feud round.
+//
+//   - face_off is when one player of each team competes for control of
+//     the round.
+//   - play is when the team in control guesses the remaining answers.
+//   - steal is when the other team gets one guess to steal the points
+//     after the team in control has struck out.
+type FeudPhase string
+
+const (
+	FeudPhaseFaceOff FeudPhase = "face_off"
+	FeudPhasePlay    FeudPhase = "play"
+	FeudPhaseSteal   FeudPhase = "steal"
+)
+
+// FeudQuestion is a survey question along with its surveyed answers.
+type FeudQuestion struct {
+	// answers are the surveyed answers, ranked from the most popular to
+	// the least.
+	Answers  []FeudAnswer `json:"answers"`
+	Question string       `json:"question"`
+}
+
+type FeudTeam struct {
+	Name    string       `json:"name"`
+	Players []PlayerName `json:"players"`
+	Score   float32      `json:"score"`
+}
+
+// GameData is the game data. It contains all the information about the game.
+type GameData struct {
+	Value IGameData `json:"-"`
+}
+
+func (v GameData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *GameData) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"game"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IGameData
+	var err error
+
+	switch t.T {
+	case "buzzer":
+		var v GameDataBuzzer
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "feud":
+		var v GameDataFeud
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "jeopardy":
+		var v GameDataJeopardy
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "kahoot":
+		var v GameDataKahoot
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "poll":
+		var v GameDataPoll
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "quiz":
+		var v GameDataQuiz
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("GameData: bad game value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IGameData is an interface type that GameData types implement.
+// It can be the following types:
+//
+// - [GameDataBuzzer] (buzzer)
+// - [GameDataFeud] (feud)
+// - [GameDataJeopardy] (jeopardy)
+// - [GameDataKahoot] (kahoot)
+// - [GameDataPoll] (poll)
+// - [GameDataQuiz] (quiz)
+type IGameData interface {
+	Game() string
+	isGameData()
+}
+
+func (GameDataBuzzer) Game() string   { return "buzzer" }
+func (GameDataFeud) Game() string     { return "feud" }
+func (GameDataJeopardy) Game() string { return "jeopardy" }
+func (GameDataKahoot) Game() string   { return "kahoot" }
+func (GameDataPoll) Game() string     { return "poll" }
+func (GameDataQuiz) Game() string     { return "quiz" }
+
+func (GameDataBuzzer) isGameData()   {}
+func (GameDataFeud) isGameData()     {}
+func (GameDataJeopardy) isGameData() {}
+func (GameDataKahoot) isGameData()   {}
+func (GameDataPoll) isGameData()     {}
+func (GameDataQuiz) isGameData()     {}
+
+func (v GameDataBuzzer) MarshalJSON() ([]byte, error) {
+	type Alias GameDataBuzzer
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataBuzzer) UnmarshalJSON(b []byte) error {
+	type Alias GameDataBuzzer
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "buzzer" {
+		return fmt.Errorf("GameDataBuzzer: bad game value: %q", a.T)
+	}
+
+	*v = GameDataBuzzer(a.Alias)
+	return nil
+}
+
+func (v GameDataFeud) MarshalJSON() ([]byte, error) {
+	type Alias GameDataFeud
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataFeud) UnmarshalJSON(b []byte) error {
+	type Alias GameDataFeud
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "feud" {
+		return fmt.Errorf("GameDataFeud: bad game value: %q", a.T)
+	}
+
+	*v = GameDataFeud(a.Alias)
+	return nil
+}
+
+func (v GameDataJeopardy) MarshalJSON() ([]byte, error) {
+	type Alias GameDataJeopardy
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataJeopardy) UnmarshalJSON(b []byte) error {
+	type Alias GameDataJeopardy
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "jeopardy" {
+		return fmt.Errorf("GameDataJeopardy: bad game value: %q", a.T)
+	}
+
+	*v = GameDataJeopardy(a.Alias)
+	return nil
+}
+
+func (v GameDataKahoot) MarshalJSON() ([]byte, error) {
+	type Alias GameDataKahoot
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataKahoot) UnmarshalJSON(b []byte) error {
+	type Alias GameDataKahoot
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "kahoot" {
+		return fmt.Errorf("GameDataKahoot: bad game value: %q", a.T)
+	}
+
+	*v = GameDataKahoot(a.Alias)
+	return nil
+}
+
+func (v GameDataPoll) MarshalJSON() ([]byte, error) {
+	type Alias GameDataPoll
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataPoll) UnmarshalJSON(b []byte) error {
+	type Alias GameDataPoll
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "poll" {
+		return fmt.Errorf("GameDataPoll: bad game value: %q", a.T)
+	}
+
+	*v = GameDataPoll(a.Alias)
+	return nil
+}
+
+func (v GameDataQuiz) MarshalJSON() ([]byte, error) {
+	type Alias GameDataQuiz
+	return json.Marshal(struct {
+		T string `json:"game"`
+		Alias
+	}{
+		v.Game(),
+		Alias(v),
+	})
+}
+
+func (v *GameDataQuiz) UnmarshalJSON(b []byte) error {
+	type Alias GameDataQuiz
+	var a struct {
+		T string `json:"game"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "quiz" {
+		return fmt.Errorf("GameDataQuiz: bad game value: %q", a.T)
+	}
+
+	*v = GameDataQuiz(a.Alias)
+	return nil
+}
+
+type GameDataBuzzer struct {
+	Data BuzzerGameData `json:"data"`
+}
+
+type GameDataFeud struct {
+	Data FeudGameData `json:"data"`
+}
+
+type GameDataJeopardy struct {
+	Data JeopardyGameData `json:"data"`
 }
 
 type GameDataKahoot struct {
 	Data KahootGameData `json:"data"`
 }
 
-// GameID is the unique identifier for a game. Each player must type this
-// code to join the game.
-type GameID = string
+type GameDataPoll struct {
+	Data PollGameData `json:"data"`
+}
+
+type GameDataQuiz struct {
+	Data QuizGameData `json:"data"`
+}
+
+// GameID is the unique identifier for a game. Each player must type this
+// code to join the game.
+type GameID = string
+
+type GameInfo struct {
+	Value IGameInfo `json:"-"`
+}
+
+func (v GameInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *GameInfo) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IGameInfo
+	var err error
+
+	switch t.T {
+	case "buzzer":
+		var v GameInfoBuzzer
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "feud":
+		var v GameInfoFeud
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "jeopardy":
+		var v GameInfoJeopardy
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "poll":
+		var v GameInfoPoll
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "quiz":
+		var v GameInfoQuiz
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("GameInfo: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IGameInfo is an interface type that GameInfo types implement.
+// It can be the following types:
+//
+// - [GameInfoBuzzer] (buzzer)
+// - [GameInfoFeud] (feud)
+// - [GameInfoJeopardy] (jeopardy)
+// - [GameInfoPoll] (poll)
+// - [GameInfoQuiz] (quiz)
+type IGameInfo interface {
+	Type() string
+	isGameInfo()
+}
+
+func (GameInfoBuzzer) Type() string   { return "buzzer" }
+func (GameInfoFeud) Type() string     { return "feud" }
+func (GameInfoJeopardy) Type() string { return "jeopardy" }
+func (GameInfoPoll) Type() string     { return "poll" }
+func (GameInfoQuiz) Type() string     { return "quiz" }
+
+func (GameInfoBuzzer) isGameInfo()   {}
+func (GameInfoFeud) isGameInfo()     {}
+func (GameInfoJeopardy) isGameInfo() {}
+func (GameInfoPoll) isGameInfo()     {}
+func (GameInfoQuiz) isGameInfo()     {}
+
+func (v GameInfoBuzzer) MarshalJSON() ([]byte, error) {
+	type Alias GameInfoBuzzer
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameInfoBuzzer) UnmarshalJSON(b []byte) error {
+	type Alias GameInfoBuzzer
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "buzzer" {
+		return fmt.Errorf("GameInfoBuzzer: bad type value: %q", a.T)
+	}
+
+	*v = GameInfoBuzzer(a.Alias)
+	return nil
+}
+
+func (v GameInfoFeud) MarshalJSON() ([]byte, error) {
+	type Alias GameInfoFeud
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameInfoFeud) UnmarshalJSON(b []byte) error {
+	type Alias GameInfoFeud
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "feud" {
+		return fmt.Errorf("GameInfoFeud: bad type value: %q", a.T)
+	}
+
+	*v = GameInfoFeud(a.Alias)
+	return nil
+}
+
+func (v GameInfoJeopardy) MarshalJSON() ([]byte, error) {
+	type Alias GameInfoJeopardy
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameInfoJeopardy) UnmarshalJSON(b []byte) error {
+	type Alias GameInfoJeopardy
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "jeopardy" {
+		return fmt.Errorf("GameInfoJeopardy: bad type value: %q", a.T)
+	}
+
+	*v = GameInfoJeopardy(a.Alias)
+	return nil
+}
+
+func (v GameInfoPoll) MarshalJSON() ([]byte, error) {
+	type Alias GameInfoPoll
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameInfoPoll) UnmarshalJSON(b []byte) error {
+	type Alias GameInfoPoll
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "poll" {
+		return fmt.Errorf("GameInfoPoll: bad type value: %q", a.T)
+	}
+
+	*v = GameInfoPoll(a.Alias)
+	return nil
+}
+
+func (v GameInfoQuiz) MarshalJSON() ([]byte, error) {
+	type Alias GameInfoQuiz
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameInfoQuiz) UnmarshalJSON(b []byte) error {
+	type Alias GameInfoQuiz
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "quiz" {
+		return fmt.Errorf("GameInfoQuiz: bad type value: %q", a.T)
+	}
+
+	*v = GameInfoQuiz(a.Alias)
+	return nil
+}
+
+type GameInfoBuzzer struct {
+	Data BuzzerGameInfo `json:"data"`
+}
+
+type GameInfoFeud struct {
+	Data FeudGameInfo `json:"data"`
+}
+
+type GameInfoJeopardy struct {
+	Data JeopardyGameInfo `json:"data"`
+}
+
+type GameInfoPoll struct {
+	Data PollGameInfo `json:"data"`
+}
+
+type GameInfoQuiz struct {
+	Data QuizGameInfo `json:"data"`
+}
+
+// GameSchedule describes when the lobby of a scheduled game opens.
+type GameSchedule struct {
+	// opensAt is the time that the lobby opens and players can start
+	// joining the game.
+	OpensAt time.Time `json:"opensAt"`
+	// autoBegin begins the game on its own once this many players
+	// (excluding admins) have joined. If omitted, an admin has to begin
+	// the game.
+	AutoBegin *uint32 `json:"autoBegin,omitempty"`
+}
+
+type GameSnapshot struct {
+	Value IGameSnapshot `json:"-"`
+}
+
+func (v GameSnapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *GameSnapshot) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IGameSnapshot
+	var err error
+
+	switch t.T {
+	case "jeopardy":
+		var v GameSnapshotJeopardy
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("GameSnapshot: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IGameSnapshot is an interface type that GameSnapshot types implement.
+// It can be the following types:
+//
+// - [GameSnapshotJeopardy] (jeopardy)
+type IGameSnapshot interface {
+	Type() string
+	isGameSnapshot()
+}
+
+func (GameSnapshotJeopardy) Type() string { return "jeopardy" }
+
+func (GameSnapshotJeopardy) isGameSnapshot() {}
+
+func (v GameSnapshotJeopardy) MarshalJSON() ([]byte, error) {
+	type Alias GameSnapshotJeopardy
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *GameSnapshotJeopardy) UnmarshalJSON(b []byte) error {
+	type Alias GameSnapshotJeopardy
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "jeopardy" {
+		return fmt.Errorf("GameSnapshotJeopardy: bad type value: %q", a.T)
+	}
+
+	*v = GameSnapshotJeopardy(a.Alias)
+	return nil
+}
+
+type GameSnapshotJeopardy struct {
+	Data JeopardySnapshot `json:"data"`
+}
+
+type GameType string
+
+const (
+	GameTypeJeopardy GameType = "jeopardy"
+	GameTypeKahoot   GameType = "kahoot"
+	GameTypeFeud     GameType = "feud"
+	GameTypePoll     GameType = "poll"
+	GameTypeQuiz     GameType = "quiz"
+	GameTypeBuzzer   GameType = "buzzer"
+)
+
+type JeopardyAnsweredQuestion struct {
+	Category int32      `json:"category"`
+	Player   PlayerName `json:"player"`
+	Question int32      `json:"question"`
+}
+
+// JeopardyAnsweredQuestions is the list of answered questions for a player.
+// In practice games, questions that were missed are also used up, and
+// their player is empty.
+type JeopardyAnsweredQuestions = []JeopardyAnsweredQuestion
+
+// JeopardyBuzz is a single button press within a buzz window.
+type JeopardyBuzz struct {
+	// latency is the estimated one-way latency of the player in
+	// milliseconds. It was subtracted from the arrival time of the button
+	// press when ordering the buzzes.
+	Latency    float32    `json:"latency"`
+	PlayerName PlayerName `json:"playerName"`
+}
+
+// JeopardyCategory is a category in a Jeopardy game.
+type JeopardyCategory struct {
+	// name is the name of the category.
+	Name string `json:"name"`
+	// questions are the questions in the category.
+	Questions []JeopardyQuestion `json:"questions"`
+}
+
+// JeopardyChooserPolicy is a policy for picking the player who chooses the
+// next question. The starting chooser is picked using the same policy,
+// with ties going to whoever joined first.
+//
+//   - random picks a random player every turn.
+//   - last_correct picks the last player who answered correctly. The
+//     chooser stays the same if nobody did.
+//   - round_robin cycles through the players in the order that they
+//     joined.
+//   - lowest_score picks the player with the lowest score.
+type JeopardyChooserPolicy string
+
+const (
+	JeopardyChooserPolicyRandom      JeopardyChooserPolicy = "random"
+	JeopardyChooserPolicyLastCorrect JeopardyChooserPolicy = "last_correct"
+	JeopardyChooserPolicyRoundRobin  JeopardyChooserPolicy = "round_robin"
+	JeopardyChooserPolicyLowestScore JeopardyChooserPolicy = "lowest_score"
+)
+
+// JeopardyCurrentQuestion is the question that is being played. Its fields
+// are the same as in EventJeopardyBeginQuestion, and index is the index of
+// the question within its category.
+type JeopardyCurrentQuestion struct {
+	Category int32             `json:"category"`
+	Content  []QuestionContent `json:"content"`
+	Index    int32             `json:"index"`
+	Points   float32           `json:"points"`
+	Question string            `json:"question"`
+}
+
+// JeopardyFairBuzzer configures latency-compensated buzzer ordering. Once
+// the first button press arrives, the server keeps collecting presses for
+// the duration of the window, then orders them by their estimated send
+// time, which is the arrival time minus half of the player's round-trip
+// time.
+type JeopardyFairBuzzer struct {
+	// window is the collection window after the first button press. The
+	// format is in Go's time.Duration, e.g. 250ms.
+	Window string `json:"window"`
+}
+
+// JeopardyGameData is the game data for a Jeopardy game.
+type JeopardyGameData struct {
+	Categories []JeopardyCategory `json:"categories"`
+	// chooser_policy determines who chooses the next question once a
+	// turn ends. The default is last_correct.
+	ChooserPolicy *JeopardyChooserPolicy `json:"chooser_policy,omitempty"`
+	// chooser_seed seeds the random number generator of the random
+	// chooser policy. If omitted, a random seed is used.
+	ChooserSeed *uint32 `json:"chooser_seed,omitempty"`
+	// early_buzz_penalty is how long a player is locked out of their
+	// buzzer if they press it before the admin arms the buzzers. The
+	// format is in Go's time.Duration. The default is 250ms.
+	EarlyBuzzPenalty *string `json:"early_buzz_penalty,omitempty"`
+	// fair_buzzer enables latency-compensated buzzer ordering. If
+	// omitted, the first button press to arrive wins.
+	FairBuzzer *JeopardyFairBuzzer `json:"fair_buzzer,omitempty"`
+	// score_multiplier is the score multiplier for each question. The
+	// default is 100.
+	ScoreMultiplier *float32 `json:"score_multiplier,omitempty"`
+}
+
+// JeopardyGameInfo is the initial information for a Jeopardy game. This type
+// contains no useful information about the entire game data, so it's used to
+// send to players the first time they join.
+type JeopardyGameInfo struct {
+	Categories      []string `json:"categories"`
+	NumQuestions    int32    `json:"numQuestions"`
+	ScoreMultiplier float32  `json:"scoreMultiplier"`
+}
+
+// JeopardyPhase is the phase of a Jeopardy turn.
+//
+//   - choosing is when the chooser picks the next question.
+//   - reading is when an admin reads the question out loud. Players who
+//     press their button now are locked out for a while.
+//   - buzzing is when players may press their button.
+//   - answering is when the player who pressed first answers.
+type JeopardyPhase string
+
+const (
+	JeopardyPhaseChoosing  JeopardyPhase = "choosing"
+	JeopardyPhaseReading   JeopardyPhase = "reading"
+	JeopardyPhaseBuzzing   JeopardyPhase = "buzzing"
+	JeopardyPhaseAnswering JeopardyPhase = "answering"
+)
+
+// JeopardyQuestion is a question in a Jeopardy game.
+type JeopardyQuestion struct {
+	// question is the question.
+	Question string `json:"question"`
+	// answers are the accepted answers to the question. They are used
+	// to judge practice games, where the host is a bot. In practice
+	// games, players judge their own answers to questions without
+	// any. Answers are compared ignoring case, punctuation, articles
+	// and a leading "what is" or "who is". They are never sent to
+	// players.
+	Answers []string `json:"answers,omitempty"`
+	// content are extra blocks that are shown along with the question,
+	// such as code snippets, images or audio clips.
+	Content []QuestionContent `json:"content,omitempty"`
+}
+
+// JeopardySnapshot is the state of a Jeopardy game. The scores are in the
+// leaderboard of the EventGameSnapshot. question is the current question,
+// which is null while the chooser is choosing. answering is the player
+// who is answering it, if any, and pressed are the players who have
+// already pressed their button for it.
+type JeopardySnapshot struct {
+	Answered  JeopardyAnsweredQuestions `json:"answered"`
+	Answering PlayerName                `json:"answering"`
+	Chooser   PlayerName                `json:"chooser"`
+	Phase     JeopardyPhase             `json:"phase"`
+	Pressed   []PlayerName              `json:"pressed"`
+	Question  *JeopardyCurrentQuestion  `json:"question"`
+}
+
+// KahootGameData is the game data for a Kahoot game.
+type KahootGameData struct {
+	// questions are the questions in the game.
+	Questions []KahootQuestion `json:"questions"`
+	// time_limit is the time limit for each question. The format is in
+	// Go's time.Duration, e.g. 10s for 10 seconds.
+	TimeLimit string `json:"time_limit"`
+}
+
+// KahootQuestion is a question in a Kahoot game.
+type KahootQuestion struct {
+	// answers are the possible answers.
+	Answers []string `json:"answers"`
+	// question is the question.
+	Question string `json:"question"`
+}
+
+// Leaderboard is a list of players and their scores.
+type Leaderboard = []LeaderboardEntry
+
+type LeaderboardEntry struct {
+	PlayerName string  `json:"playerName"`
+	Score      float32 `json:"score"`
+}
+
+// Media describes an uploaded media blob. Its content is served at
+// /media/{id}.
+type Media struct {
+	ContentType string  `json:"contentType"`
+	ID          MediaID `json:"id"`
+	Size        uint32  `json:"size"`
+}
+
+// MediaID is the ID of an uploaded media blob. It is the hex-encoded
+// SHA-256 hash of the blob, so uploading the same blob twice gives the
+// same ID.
+type MediaID = string
+
+// PlayerName is the name of a player.
+type PlayerName = string
+
+// PollGameData is the game data for a poll. A poll is not scored: players
+// vote on each question and everyone sees the results live.
+type PollGameData struct {
+	Questions []PollQuestion `json:"questions"`
+}
+
+type PollGameInfo struct {
+	NumQuestions int32 `json:"numQuestions"`
+}
+
+type PollQuestion struct {
+	Choices  []string `json:"choices"`
+	Question string   `json:"question"`
+	// multiple allows players to vote for more than one choice. The
+	// default is false.
+	Multiple *bool `json:"multiple,omitempty"`
+}
+
+// PollResult is the result of a poll question. votes holds the number of
+// votes for each choice, and voters is the number of players who voted.
+type PollResult struct {
+	Choices  []string `json:"choices"`
+	Question string   `json:"question"`
+	Voters   int32    `json:"voters"`
+	Votes    []int32  `json:"votes"`
+}
+
+// QuestionContent is a block of content that is shown along with a
+// question.
+type QuestionContent struct {
+	Value IQuestionContent `json:"-"`
+}
+
+func (v QuestionContent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *QuestionContent) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IQuestionContent
+	var err error
+
+	switch t.T {
+	case "audio":
+		var v QuestionContentAudio
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "code":
+		var v QuestionContentCode
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "image":
+		var v QuestionContentImage
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "text":
+		var v QuestionContentText
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("QuestionContent: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IQuestionContent is an interface type that QuestionContent types implement.
+// It can be the following types:
+//
+// - [QuestionContentAudio] (audio)
+// - [QuestionContentCode] (code)
+// - [QuestionContentImage] (image)
+// - [QuestionContentText] (text)
+type IQuestionContent interface {
+	Type() string
+	isQuestionContent()
+}
+
+func (QuestionContentAudio) Type() string { return "audio" }
+func (QuestionContentCode) Type() string  { return "code" }
+func (QuestionContentImage) Type() string { return "image" }
+func (QuestionContentText) Type() string  { return "text" }
+
+func (QuestionContentAudio) isQuestionContent() {}
+func (QuestionContentCode) isQuestionContent()  {}
+func (QuestionContentImage) isQuestionContent() {}
+func (QuestionContentText) isQuestionContent()  {}
+
+func (v QuestionContentAudio) MarshalJSON() ([]byte, error) {
+	type Alias QuestionContentAudio
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuestionContentAudio) UnmarshalJSON(b []byte) error {
+	type Alias QuestionContentAudio
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "audio" {
+		return fmt.Errorf("QuestionContentAudio: bad type value: %q", a.T)
+	}
+
+	*v = QuestionContentAudio(a.Alias)
+	return nil
+}
+
+func (v QuestionContentCode) MarshalJSON() ([]byte, error) {
+	type Alias QuestionContentCode
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuestionContentCode) UnmarshalJSON(b []byte) error {
+	type Alias QuestionContentCode
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "code" {
+		return fmt.Errorf("QuestionContentCode: bad type value: %q", a.T)
+	}
+
+	*v = QuestionContentCode(a.Alias)
+	return nil
+}
+
+func (v QuestionContentImage) MarshalJSON() ([]byte, error) {
+	type Alias QuestionContentImage
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuestionContentImage) UnmarshalJSON(b []byte) error {
+	type Alias QuestionContentImage
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "image" {
+		return fmt.Errorf("QuestionContentImage: bad type value: %q", a.T)
+	}
+
+	*v = QuestionContentImage(a.Alias)
+	return nil
+}
+
+func (v QuestionContentText) MarshalJSON() ([]byte, error) {
+	type Alias QuestionContentText
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuestionContentText) UnmarshalJSON(b []byte) error {
+	type Alias QuestionContentText
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "text" {
+		return fmt.Errorf("QuestionContentText: bad type value: %q", a.T)
+	}
+
+	*v = QuestionContentText(a.Alias)
+	return nil
+}
+
+// QuestionContentAudio is an uploaded audio clip.
+type QuestionContentAudio struct {
+	Media MediaID `json:"media"`
+}
+
+// QuestionContentCode is a code snippet.
+type QuestionContentCode struct {
+	Code string `json:"code"`
+	// language is a hint for syntax highlighting, such as go or
+	// python.
+	Language *string `json:"language,omitempty"`
+}
+
+// QuestionContentImage is an uploaded image.
+type QuestionContentImage struct {
+	Media MediaID `json:"media"`
+	// alt describes the image for players who cannot see it. It
+	// should not give the answer away.
+	Alt *string `json:"alt,omitempty"`
+}
+
+// QuestionContentText is a paragraph of plain text.
+type QuestionContentText struct {
+	Text string `json:"text"`
+}
+
+// QuizAnswer is a player's answer to a quiz question. Its type must match
+// the type of the question.
+type QuizAnswer struct {
+	Value IQuizAnswer `json:"-"`
+}
+
+func (v QuizAnswer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *QuizAnswer) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IQuizAnswer
+	var err error
+
+	switch t.T {
+	case "choice":
+		var v QuizAnswerChoice
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "number":
+		var v QuizAnswerNumber
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "order":
+		var v QuizAnswerOrder
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("QuizAnswer: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IQuizAnswer is an interface type that QuizAnswer types implement.
+// It can be the following types:
+//
+// - [QuizAnswerChoice] (choice)
+// - [QuizAnswerNumber] (number)
+// - [QuizAnswerOrder] (order)
+type IQuizAnswer interface {
+	Type() string
+	isQuizAnswer()
+}
+
+func (QuizAnswerChoice) Type() string { return "choice" }
+func (QuizAnswerNumber) Type() string { return "number" }
+func (QuizAnswerOrder) Type() string  { return "order" }
+
+func (QuizAnswerChoice) isQuizAnswer() {}
+func (QuizAnswerNumber) isQuizAnswer() {}
+func (QuizAnswerOrder) isQuizAnswer()  {}
+
+func (v QuizAnswerChoice) MarshalJSON() ([]byte, error) {
+	type Alias QuizAnswerChoice
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizAnswerChoice) UnmarshalJSON(b []byte) error {
+	type Alias QuizAnswerChoice
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "choice" {
+		return fmt.Errorf("QuizAnswerChoice: bad type value: %q", a.T)
+	}
+
+	*v = QuizAnswerChoice(a.Alias)
+	return nil
+}
+
+func (v QuizAnswerNumber) MarshalJSON() ([]byte, error) {
+	type Alias QuizAnswerNumber
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizAnswerNumber) UnmarshalJSON(b []byte) error {
+	type Alias QuizAnswerNumber
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "number" {
+		return fmt.Errorf("QuizAnswerNumber: bad type value: %q", a.T)
+	}
+
+	*v = QuizAnswerNumber(a.Alias)
+	return nil
+}
+
+func (v QuizAnswerOrder) MarshalJSON() ([]byte, error) {
+	type Alias QuizAnswerOrder
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizAnswerOrder) UnmarshalJSON(b []byte) error {
+	type Alias QuizAnswerOrder
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "order" {
+		return fmt.Errorf("QuizAnswerOrder: bad type value: %q", a.T)
+	}
+
+	*v = QuizAnswerOrder(a.Alias)
+	return nil
+}
+
+// QuizAnswerChoice answers a multiple-choice or true or false
+// question. choice is the index of the chosen choice.
+type QuizAnswerChoice struct {
+	Choice int32 `json:"choice"`
+}
+
+// QuizAnswerNumber answers an estimation question.
+type QuizAnswerNumber struct {
+	Number float64 `json:"number"`
+}
+
+// QuizAnswerOrder answers an ordering question. order lists the
+// indices of the choices of the question from first to last, and it
+// must contain every index exactly once.
+type QuizAnswerOrder struct {
+	Order []int32 `json:"order"`
+}
+
+type QuizEstimateGuess struct {
+	Distance   float64    `json:"distance"`
+	Guess      float64    `json:"guess"`
+	PlayerName PlayerName `json:"playerName"`
+	Points     float32    `json:"points"`
+}
+
+// QuizEstimateScoring is how guesses to an estimation question are scored.
+// The default is rank.
+//
+//   - rank ranks the guesses by their distance to the answer. The closest
+//     guesses get the full points, and each rank after that gets a smaller
+//     share of them, down to 1/n of the points for the farthest of n ranks.
+//   - linear gives the full points to an exact guess, and the points drop
+//     linearly with the distance until they reach 0 at the tolerance.
+type QuizEstimateScoring string
+
+const (
+	QuizEstimateScoringRank   QuizEstimateScoring = "rank"
+	QuizEstimateScoringLinear QuizEstimateScoring = "linear"
+)
+
+// QuizGameData is the game data for a quiz. Everyone answers every
+// question within the time limit, and correct answers are scored
+// automatically.
+type QuizGameData struct {
+	Questions []QuizQuestion `json:"questions"`
+	// points is the number of points for each correct answer. The
+	// default is 100.
+	Points *float32 `json:"points,omitempty"`
+	// survival turns the quiz into a survival game. See QuizSurvival.
+	Survival *QuizSurvival `json:"survival,omitempty"`
+	// time_limit is the time limit for each question. The format is in
+	// Go's time.Duration, e.g. 10s for 10 seconds. The default is 20s.
+	TimeLimit *string `json:"time_limit,omitempty"`
+}
+
+type QuizGameInfo struct {
+	NumQuestions int32 `json:"numQuestions"`
+	Survival     bool  `json:"survival"`
+}
+
+// QuizOrderingGuess is a player's answer to an ordering question. distance
+// is the Kendall tau distance to the right order, which is the number of
+// pairs of items that the player put in the wrong order.
+type QuizOrderingGuess struct {
+	Distance   int32      `json:"distance"`
+	Order      []int32    `json:"order"`
+	PlayerName PlayerName `json:"playerName"`
+	Points     float32    `json:"points"`
+}
+
+// QuizQuestion is a question in a quiz.
+type QuizQuestion struct {
+	Value IQuizQuestion `json:"-"`
+}
+
+func (v QuizQuestion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *QuizQuestion) UnmarshalJSON(b []byte) error {
+	var t struct {
+		T string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	var value IQuizQuestion
+	var err error
+
+	switch t.T {
+	case "estimate":
+		var v QuizQuestionEstimate
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "multiple_choice":
+		var v QuizQuestionMultipleChoice
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "ordering":
+		var v QuizQuestionOrdering
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "true_false":
+		var v QuizQuestionTrueFalse
+		err = json.Unmarshal(b, &v)
+		value = v
+	default:
+		err = fmt.Errorf("QuizQuestion: bad type value: %q", t.T)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.Value = value
+	return nil
+}
+
+// IQuizQuestion is an interface type that QuizQuestion types implement.
+// It can be the following types:
+//
+// - [QuizQuestionEstimate] (estimate)
+// - [QuizQuestionMultipleChoice] (multiple_choice)
+// - [QuizQuestionOrdering] (ordering)
+// - [QuizQuestionTrueFalse] (true_false)
+type IQuizQuestion interface {
+	Type() string
+	isQuizQuestion()
+}
+
+func (QuizQuestionEstimate) Type() string       { return "estimate" }
+func (QuizQuestionMultipleChoice) Type() string { return "multiple_choice" }
+func (QuizQuestionOrdering) Type() string       { return "ordering" }
+func (QuizQuestionTrueFalse) Type() string      { return "true_false" }
+
+func (QuizQuestionEstimate) isQuizQuestion()       {}
+func (QuizQuestionMultipleChoice) isQuizQuestion() {}
+func (QuizQuestionOrdering) isQuizQuestion()       {}
+func (QuizQuestionTrueFalse) isQuizQuestion()      {}
+
+func (v QuizQuestionEstimate) MarshalJSON() ([]byte, error) {
+	type Alias QuizQuestionEstimate
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizQuestionEstimate) UnmarshalJSON(b []byte) error {
+	type Alias QuizQuestionEstimate
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
 
-type GameInfo struct {
-	Value IGameInfo `json:"-"`
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "estimate" {
+		return fmt.Errorf("QuizQuestionEstimate: bad type value: %q", a.T)
+	}
+
+	*v = QuizQuestionEstimate(a.Alias)
+	return nil
 }
 
-func (v GameInfo) MarshalJSON() ([]byte, error) {
+func (v QuizQuestionMultipleChoice) MarshalJSON() ([]byte, error) {
+	type Alias QuizQuestionMultipleChoice
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizQuestionMultipleChoice) UnmarshalJSON(b []byte) error {
+	type Alias QuizQuestionMultipleChoice
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "multiple_choice" {
+		return fmt.Errorf("QuizQuestionMultipleChoice: bad type value: %q", a.T)
+	}
+
+	*v = QuizQuestionMultipleChoice(a.Alias)
+	return nil
+}
+
+func (v QuizQuestionOrdering) MarshalJSON() ([]byte, error) {
+	type Alias QuizQuestionOrdering
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizQuestionOrdering) UnmarshalJSON(b []byte) error {
+	type Alias QuizQuestionOrdering
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "ordering" {
+		return fmt.Errorf("QuizQuestionOrdering: bad type value: %q", a.T)
+	}
+
+	*v = QuizQuestionOrdering(a.Alias)
+	return nil
+}
+
+func (v QuizQuestionTrueFalse) MarshalJSON() ([]byte, error) {
+	type Alias QuizQuestionTrueFalse
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
+}
+
+func (v *QuizQuestionTrueFalse) UnmarshalJSON(b []byte) error {
+	type Alias QuizQuestionTrueFalse
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "true_false" {
+		return fmt.Errorf("QuizQuestionTrueFalse: bad type value: %q", a.T)
+	}
+
+	*v = QuizQuestionTrueFalse(a.Alias)
+	return nil
+}
+
+// QuizQuestionEstimate is a question with a numeric answer that
+// players have to estimate. The closer a guess is to answer, the more
+// points it gets.
+type QuizQuestionEstimate struct {
+	Answer   float64              `json:"answer"`
+	Question string               `json:"question"`
+	Scoring  *QuizEstimateScoring `json:"scoring,omitempty"`
+	// tolerance is the distance from the answer at which a guess
+	// stops getting any points when scoring is linear. The default
+	// is the answer itself, so a guess of 0 or twice the answer gets
+	// no points.
+	Tolerance *float64 `json:"tolerance,omitempty"`
+}
+
+// QuizQuestionMultipleChoice is a multiple-choice question. answer
+// is the index of the correct choice.
+type QuizQuestionMultipleChoice struct {
+	Answer   int32    `json:"answer"`
+	Choices  []string `json:"choices"`
+	Question string   `json:"question"`
+}
+
+// QuizQuestionOrdering is a question where players put items into
+// the right order, such as sorting languages by their release year.
+// items are listed in the right order and are shown to players in a
+// random order. Answers that are partly in order get partial credit.
+type QuizQuestionOrdering struct {
+	Items    []string `json:"items"`
+	Question string   `json:"question"`
+}
+
+// QuizQuestionTrueFalse is a true or false question. Players answer
+// with choice 0 for true and choice 1 for false.
+type QuizQuestionTrueFalse struct {
+	Answer   bool   `json:"answer"`
+	Question string `json:"question"`
+}
+
+type QuizQuestionType string
+
+const (
+	QuizQuestionTypeMultipleChoice QuizQuestionType = "multiple_choice"
+	QuizQuestionTypeTrueFalse      QuizQuestionType = "true_false"
+	QuizQuestionTypeEstimate       QuizQuestionType = "estimate"
+	QuizQuestionTypeOrdering       QuizQuestionType = "ordering"
+)
+
+// QuizResult is the result of a quiz question once it is revealed. Its
+// type depends on the type of the question.
+type QuizResult struct {
+	Value IQuizResult `json:"-"`
+}
+
+func (v QuizResult) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v.Value)
 }
 
-func (v *GameInfo) UnmarshalJSON(b []byte) error {
+func (v *QuizResult) UnmarshalJSON(b []byte) error {
 	var t struct {
 		T string `json:"type"`
 	}
@@ -919,16 +4537,24 @@ func (v *GameInfo) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	var value IGameInfo
+	var value IQuizResult
 	var err error
 
 	switch t.T {
-	case "jeopardy":
-		var v GameInfoJeopardy
+	case "choice":
+		var v QuizResultChoice
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "estimate":
+		var v QuizResultEstimate
+		err = json.Unmarshal(b, &v)
+		value = v
+	case "ordering":
+		var v QuizResultOrdering
 		err = json.Unmarshal(b, &v)
 		value = v
 	default:
-		err = fmt.Errorf("GameInfo: bad type value: %q", t.T)
+		err = fmt.Errorf("QuizResult: bad type value: %q", t.T)
 	}
 
 	if err != nil {
@@ -939,21 +4565,27 @@ func (v *GameInfo) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// IGameInfo is an interface type that GameInfo types implement.
+// IQuizResult is an interface type that QuizResult types implement.
 // It can be the following types:
 //
-// - [GameInfoJeopardy] (jeopardy)
-type IGameInfo interface {
+// - [QuizResultChoice] (choice)
+// - [QuizResultEstimate] (estimate)
+// - [QuizResultOrdering] (ordering)
+type IQuizResult interface {
 	Type() string
-	isGameInfo()
+	isQuizResult()
 }
 
-func (GameInfoJeopardy) Type() string { return "jeopardy" }
+func (QuizResultChoice) Type() string   { return "choice" }
+func (QuizResultEstimate) Type() string { return "estimate" }
+func (QuizResultOrdering) Type() string { return "ordering" }
 
-func (GameInfoJeopardy) isGameInfo() {}
+func (QuizResultChoice) isQuizResult()   {}
+func (QuizResultEstimate) isQuizResult() {}
+func (QuizResultOrdering) isQuizResult() {}
 
-func (v GameInfoJeopardy) MarshalJSON() ([]byte, error) {
-	type Alias GameInfoJeopardy
+func (v QuizResultChoice) MarshalJSON() ([]byte, error) {
+	type Alias QuizResultChoice
 	return json.Marshal(struct {
 		T string `json:"type"`
 		Alias
@@ -963,8 +4595,8 @@ func (v GameInfoJeopardy) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (v *GameInfoJeopardy) UnmarshalJSON(b []byte) error {
-	type Alias GameInfoJeopardy
+func (v *QuizResultChoice) UnmarshalJSON(b []byte) error {
+	type Alias QuizResultChoice
 	var a struct {
 		T string `json:"type"`
 		Alias
@@ -974,92 +4606,130 @@ func (v *GameInfoJeopardy) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if a.T != "jeopardy" {
-		return fmt.Errorf("GameInfoJeopardy: bad type value: %q", a.T)
+	if a.T != "choice" {
+		return fmt.Errorf("QuizResultChoice: bad type value: %q", a.T)
 	}
 
-	*v = GameInfoJeopardy(a.Alias)
+	*v = QuizResultChoice(a.Alias)
 	return nil
 }
 
-type GameInfoJeopardy struct {
-	Data JeopardyGameInfo `json:"data"`
+func (v QuizResultEstimate) MarshalJSON() ([]byte, error) {
+	type Alias QuizResultEstimate
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-type GameType string
+func (v *QuizResultEstimate) UnmarshalJSON(b []byte) error {
+	type Alias QuizResultEstimate
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
 
-const (
-	GameTypeJeopardy GameType = "jeopardy"
-	GameTypeKahoot   GameType = "kahoot"
-)
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
 
-type JeopardyAnsweredQuestion struct {
-	Category int32      `json:"category"`
-	Player   PlayerName `json:"player"`
-	Question int32      `json:"question"`
-}
+	if a.T != "estimate" {
+		return fmt.Errorf("QuizResultEstimate: bad type value: %q", a.T)
+	}
 
-// JeopardyAnsweredQuestions is the list of answered questions for a player.
-type JeopardyAnsweredQuestions = []JeopardyAnsweredQuestion
+	*v = QuizResultEstimate(a.Alias)
+	return nil
+}
 
-// JeopardyCategory is a category in a Jeopardy game.
-type JeopardyCategory struct {
-	// name is the name of the category.
-	Name string `json:"name"`
-	// questions are the questions in the category.
-	Questions []JeopardyQuestion `json:"questions"`
+func (v QuizResultOrdering) MarshalJSON() ([]byte, error) {
+	type Alias QuizResultOrdering
+	return json.Marshal(struct {
+		T string `json:"type"`
+		Alias
+	}{
+		v.Type(),
+		Alias(v),
+	})
 }
 
-// JeopardyGameData is the game data for a Jeopardy game.
-type JeopardyGameData struct {
-	Categories []JeopardyCategory `json:"categories"`
-	// score_multiplier is the score multiplier for each question. The
-	// default is 100.
-	ScoreMultiplier *float32 `json:"score_multiplier,omitempty"`
+func (v *QuizResultOrdering) UnmarshalJSON(b []byte) error {
+	type Alias QuizResultOrdering
+	var a struct {
+		T string `json:"type"`
+		Alias
+	}
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	if a.T != "ordering" {
+		return fmt.Errorf("QuizResultOrdering: bad type value: %q", a.T)
+	}
+
+	*v = QuizResultOrdering(a.Alias)
+	return nil
 }
 
-// JeopardyGameInfo is the initial information for a Jeopardy game. This type
-// contains no useful information about the entire game data, so it's used to
-// send to players the first time they join.
-type JeopardyGameInfo struct {
-	Categories      []string `json:"categories"`
-	NumQuestions    int32    `json:"numQuestions"`
-	ScoreMultiplier float32  `json:"scoreMultiplier"`
+// QuizResultChoice is the result of a multiple-choice or true or
+// false question. distribution holds the number of players who picked
+// each choice.
+type QuizResultChoice struct {
+	Answer       int32   `json:"answer"`
+	Distribution []int32 `json:"distribution"`
 }
 
-// JeopardyQuestion is a question in a Jeopardy game.
-type JeopardyQuestion struct {
-	// question is the question.
-	Question string `json:"question"`
+// QuizResultEstimate is the result of an estimation question. guesses
+// are sorted from the closest to the farthest from answer.
+type QuizResultEstimate struct {
+	Answer  float64             `json:"answer"`
+	Guesses []QuizEstimateGuess `json:"guesses"`
 }
 
-// KahootGameData is the game data for a Kahoot game.
-type KahootGameData struct {
-	// questions are the questions in the game.
-	Questions []KahootQuestion `json:"questions"`
-	// time_limit is the time limit for each question. The format is in
-	// Go's time.Duration, e.g. 10s for 10 seconds.
-	TimeLimit string `json:"time_limit"`
+// QuizResultOrdering is the result of an ordering question. answer
+// lists the indices of the choices in the right order, and orders
+// are sorted from the closest to the farthest from it.
+type QuizResultOrdering struct {
+	Answer []int32             `json:"answer"`
+	Orders []QuizOrderingGuess `json:"orders"`
 }
 
-// KahootQuestion is a question in a Kahoot game.
-type KahootQuestion struct {
-	// answers are the possible answers.
-	Answers []string `json:"answers"`
-	// question is the question.
-	Question string `json:"question"`
+// QuizSurvival configures a survival quiz. A wrong answer or no answer
+// eliminates the player, who stays connected as a spectator. The game ends
+// once one player remains or the questions run out. Players are ranked by
+// the number of questions that they survived.
+type QuizSurvival struct {
+	// sudden_death are the questions that are played one at a time
+	// when the tie_breaker is sudden_death.
+	SuddenDeath []QuizQuestion  `json:"sudden_death,omitempty"`
+	TieBreaker  *QuizTieBreaker `json:"tie_breaker,omitempty"`
 }
 
-// Leaderboard is a list of players and their scores.
-type Leaderboard = []LeaderboardEntry
+// QuizTieBreaker is how a survival quiz handles players who are tied. The
+// default is share.
+//
+//   - share eliminates everyone who fails a question, even if nobody would
+//     be left. Players who are left at the end share the win.
+//   - revive lets everyone survive a question that all remaining players
+//     failed. Players who are left at the end share the win.
+//   - sudden_death works like revive, but players who are left once the
+//     questions run out play the sudden_death questions until only one of
+//     them survives. If those run out too, the players share the win.
+type QuizTieBreaker string
 
-type LeaderboardEntry struct {
-	PlayerName string  `json:"playerName"`
-	Score      float32 `json:"score"`
-}
+const (
+	QuizTieBreakerShare       QuizTieBreaker = "share"
+	QuizTieBreakerRevive      QuizTieBreaker = "revive"
+	QuizTieBreakerSuddenDeath QuizTieBreaker = "sudden_death"
+)
 
-// PlayerName is the name of a player.
-type PlayerName = string
+type RequestDebugGame struct {
+	AdminPassword string `json:"admin_password"`
+	GameID        GameID `json:"gameID"`
+}
 
 type RequestGetGame struct {
 	GameID string `json:"gameID"`
@@ -1069,20 +4739,154 @@ type RequestGetJeopardyGame struct {
 	GameID string `json:"gameID"`
 }
 
+// RequestGetPollResults asks for the results of a poll. Voters may not
+// want their answers to be seen by everyone who knows the game ID, so
+// only admins may export them.
+type RequestGetPollResults struct {
+	AdminPassword string `json:"admin_password"`
+	GameID        GameID `json:"gameID"`
+}
+
+type RequestGetTournament struct {
+	TournamentID TournamentID `json:"tournamentID"`
+}
+
 type RequestNewGame struct {
 	AdminPassword string   `json:"admin_password"`
 	Data          GameData `json:"data"`
+	// schedule schedules the game ahead of time. The game ID is returned
+	// right away, but players cannot join until the lobby opens. If
+	// omitted, the lobby opens immediately.
+	Schedule *GameSchedule `json:"schedule,omitempty"`
+}
+
+type RequestNewTournament struct {
+	// admin_password is the admin password of every match game, so that
+	// the host can run the matches.
+	AdminPassword string         `json:"admin_password"`
+	Data          TournamentData `json:"data"`
+}
+
+type RequestPreviewGameMedia struct {
+	AdminPassword string `json:"admin_password"`
+	GameID        GameID `json:"gameID"`
+}
+
+// ResponseDebugGame is a snapshot of the state machine of a running game,
+// for admins to debug it. Inputs are named by their Go types. The graphs
+// only contain the transitions that the game has offered so far.
+type ResponseDebugGame struct {
+	// current is the input of the current state, or init before the game
+	// has begun taking inputs.
+	Current string `json:"current"`
+	// dot is the graph of the state machine in the Graphviz DOT language.
+	Dot   string `json:"dot"`
+	Ended bool   `json:"ended"`
+	// mermaid is the graph of the state machine as a Mermaid state
+	// diagram.
+	Mermaid string `json:"mermaid"`
+	// next lists the inputs that the game accepts next, besides those of
+	// the superstate.
+	Next     []string       `json:"next"`
+	Reactors []DebugReactor `json:"reactors"`
+	// superstate lists the inputs that the game accepts in any state.
+	Superstate []string `json:"superstate"`
 }
 
 type ResponseGetGame struct {
 	GameType GameType `json:"gameType"`
+	// schedule is the schedule of the game if it was scheduled ahead of
+	// time.
+	Schedule *GameSchedule `json:"schedule,omitempty"`
 }
 
 type ResponseGetJeopardyGame struct {
 	Info JeopardyGameInfo `json:"info"`
 }
 
+// ResponseGetPollResults contains the results of every question of a poll
+// that has been closed so far.
+type ResponseGetPollResults struct {
+	Results []PollResult `json:"results"`
+}
+
+type ResponseGetTournament struct {
+	Bracket TournamentBracket `json:"bracket"`
+}
+
 type ResponseNewGame struct {
 	GameID   string   `json:"gameID"`
 	GameType GameType `json:"gameType"`
 }
+
+type ResponseNewTournament struct {
+	Bracket TournamentBracket `json:"bracket"`
+}
+
+// ResponsePreviewGameMedia lists every media blob that the questions of a
+// game use, so that admins can check them before players see them.
+type ResponsePreviewGameMedia struct {
+	Media []Media `json:"media"`
+}
+
+// ResponseUploadMedia is returned after uploading a media blob. The blob
+// is uploaded as the raw request body, and its Content-Type header must
+// be an image or audio type.
+type ResponseUploadMedia struct {
+	Media Media `json:"media"`
+}
+
+// TournamentBracket is the current state of a tournament. rounds holds
+// the matches of each round, from the first round to the final. winner
+// is set once the final has ended.
+type TournamentBracket struct {
+	Name         string              `json:"name"`
+	Rounds       [][]TournamentMatch `json:"rounds"`
+	TournamentID TournamentID        `json:"tournamentID"`
+	Winner       *PlayerName         `json:"winner,omitempty"`
+}
+
+// TournamentData describes a single-elimination tournament. Each match
+// is its own game, and the winner of a match advances to the next round.
+// The entrant with the higher score wins a match, and ties go to the
+// higher seed. An entrant who never joins their match loses it, unless
+// neither entrant joins, in which case the higher seed wins.
+type TournamentData struct {
+	// entrants are the players or teams in the tournament, from the top
+	// seed to the bottom one. Each entrant joins their matches using
+	// their name as the player name. Top seeds get a bye if the number of
+	// entrants is not a power of two.
+	Entrants []PlayerName `json:"entrants"`
+	// games are the question sets that the matches are played with. The
+	// matches of round i use games[i % len(games)].
+	Games []GameData `json:"games"`
+	Name  string     `json:"name"`
+}
+
+// TournamentID is the unique identifier for a tournament.
+type TournamentID = string
+
+// TournamentMatch is a match between two entrants. entrants only holds
+// the entrants that are known so far. A match with a single entrant in
+// the first round is a bye.
+type TournamentMatch struct {
+	Entrants    []PlayerName         `json:"entrants"`
+	State       TournamentMatchState `json:"state"`
+	GameID      *GameID              `json:"gameID,omitempty"`
+	Leaderboard *Leaderboard         `json:"leaderboard,omitempty"`
+	Winner      *PlayerName          `json:"winner,omitempty"`
+}
+
+// TournamentMatchState is the state of a tournament match.
+//
+//   - pending is when the match is waiting for its entrants.
+//   - playing is when the match game has been created. Its entrants can
+//     join it using gameID.
+//   - done is when the match has a winner.
+type TournamentMatchState string
+
+const (
+	TournamentMatchStatePending TournamentMatchState = "pending"
+	TournamentMatchStatePlaying TournamentMatchState = "playing"
+	TournamentMatchStateDone    TournamentMatchState = "done"
+)
@@ -1,13 +1,17 @@
 package qg
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"sync"
 
+	"github.com/pkg/errors"
+
 	cryptorand "crypto/rand"
 	mathrand "math/rand"
 )
@@ -47,6 +51,12 @@ func GenerateGameID() GameID {
 	return GameID(id[:])
 }
 
+// MediaIDFromData returns the media ID of the given media blob.
+func MediaIDFromData(data []byte) MediaID {
+	sum := sha256.Sum256(data)
+	return MediaID(hex.EncodeToString(sum[:]))
+}
+
 // GameTypeFromData returns the game type from the given game data.
 func GameTypeFromData(data IGameData) GameType {
 	return GameType(data.Game())
@@ -56,6 +66,19 @@ func GameInfoFromData(data IGameData) IGameInfo {
 	switch data := data.(type) {
 	case GameDataJeopardy:
 		return GameInfoJeopardy{ConvertJeopardyGameData(data.Data)}
+	case GameDataFeud:
+		return GameInfoFeud{ConvertFeudGameData(data.Data)}
+	case GameDataPoll:
+		return GameInfoPoll{PollGameInfo{NumQuestions: int32(len(data.Data.Questions))}}
+	case GameDataQuiz:
+		return GameInfoQuiz{QuizGameInfo{
+			NumQuestions: int32(len(data.Data.Questions)),
+			Survival:     data.Data.Survival != nil,
+		}}
+	case GameDataBuzzer:
+		return GameInfoBuzzer{BuzzerGameInfo{
+			CompensateLatency: data.Data.CompensateLatency != nil && *data.Data.CompensateLatency,
+		}}
 	default:
 		panic("unknown game type")
 	}
@@ -74,24 +97,43 @@ func ValidatePlayerName(name string) error {
 	return nil
 }
 
-// Validate validates the given game data.
+// Validate validates the given game data against the schema, then checks that
+// the board is playable. Every problem that is found is returned as a detail of
+// an invalid_request error.
 func (data *JeopardyGameData) Validate() error {
-	if len(data.Categories) == 0 {
-		return fmt.Errorf("no categories found, must have at least one")
+	var details []ErrorDetail
+
+	if err := Validate("JeopardyGameData", data); err != nil {
+		var coded *CodedError
+		if !errors.As(err, &coded) {
+			return err
+		}
+		details = append(details, coded.Details...)
 	}
 
-	nQuestions := len(data.Categories[0].Questions)
-	for i, c := range data.Categories {
-		if len(c.Questions) != nQuestions {
-			return fmt.Errorf("category %d has %d questions, expected %d", i+1, len(c.Questions), nQuestions)
+	if len(data.Categories) == 0 {
+		details = append(details, newErrorDetail("/categories", "must have at least one category"))
+	} else {
+		nQuestions := len(data.Categories[0].Questions)
+		for i, c := range data.Categories[1:] {
+			if len(c.Questions) != nQuestions {
+				details = append(details, newErrorDetail(
+					fmt.Sprintf("/categories/%d/questions", i+1),
+					fmt.Sprintf("has %d questions, but the first category has %d", len(c.Questions), nQuestions),
+				))
+			}
 		}
 	}
 
-	if data.ScoreMultiplier == nil {
-		*data.ScoreMultiplier = 100
+	if len(details) == 0 {
+		return nil
 	}
 
-	return nil
+	return newValidationError("invalid JeopardyGameData", details)
+}
+
+func newErrorDetail(path, message string) ErrorDetail {
+	return ErrorDetail{Message: message, Path: &path}
 }
 
 // ConvertJeopardyGameData converts a Jeopardy game data to a Jeopardy game
@@ -150,12 +192,81 @@ func (data JeopardyGameData) TotalQuestions() int {
 	return len(data.Categories) * len(data.Categories[0].Questions)
 }
 
+// GameMedia returns the IDs of the media that the questions of the given game
+// data use, in the order that they first appear.
+func GameMedia(data IGameData) []MediaID {
+	var content []QuestionContent
+
+	switch data := data.(type) {
+	case GameDataJeopardy:
+		for _, c := range data.Data.Categories {
+			for _, q := range c.Questions {
+				content = append(content, q.Content...)
+			}
+		}
+	}
+
+	ids := []MediaID{}
+	seen := make(map[MediaID]bool)
+
+	for _, block := range content {
+		var id MediaID
+		switch block := block.Value.(type) {
+		case QuestionContentImage:
+			id = block.Media
+		case QuestionContentAudio:
+			id = block.Media
+		default:
+			continue
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// DefaultFeudMaxStrikes is the default number of strikes in a Feud round.
+const DefaultFeudMaxStrikes = 3
+
+// ConvertFeudGameData converts a Feud game data to a Feud game info.
+func ConvertFeudGameData(data FeudGameData) FeudGameInfo {
+	return FeudGameInfo{
+		NumQuestions: int32(len(data.Questions)),
+		TeamNames:    data.Teams(),
+	}
+}
+
+// Teams returns the names of the two teams.
+func (data FeudGameData) Teams() []string {
+	if len(data.TeamNames) == 2 {
+		return data.TeamNames
+	}
+	return []string{"Team 1", "Team 2"}
+}
+
+// Strikes returns the number of strikes that the team in control may get.
+func (data FeudGameData) Strikes() int {
+	if data.MaxStrikes != nil {
+		return int(*data.MaxStrikes)
+	}
+	return DefaultFeudMaxStrikes
+}
+
 // Define this here because we're lazy.
 
-// WriteHTTPError writes the given error to the given response writer.
-func WriteHTTPError(w http.ResponseWriter, code int, err error) {
-	msg := Error{Message: err.Error()}
+// WriteHTTPError writes the given error to the given response writer. If err
+// has no ErrorCode, one is picked from the status.
+func WriteHTTPError(w http.ResponseWriter, status int, err error) {
+	msg := NewError(err)
+	if msg.Code == nil {
+		code := errorCodeForStatus(status)
+		msg.Code = &code
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(msg)
 }
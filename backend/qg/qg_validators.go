@@ -2,24 +2,84 @@
 
 package qg
 
+// Validate validates the BuzzerBuzz object. It implements the
+// Validator interface.
+func (v *BuzzerBuzz) Validate() error {
+	return Validate("BuzzerBuzz", v)
+}
+
+// Validate validates the BuzzerGameData object. It implements the
+// Validator interface.
+func (v *BuzzerGameData) Validate() error {
+	return Validate("BuzzerGameData", v)
+}
+
+// Validate validates the BuzzerGameInfo object. It implements the
+// Validator interface.
+func (v *BuzzerGameInfo) Validate() error {
+	return Validate("BuzzerGameInfo", v)
+}
+
 // Validate validates the Command object. It implements the
 // Validator interface.
 func (v *Command) Validate() error {
 	return Validate("Command", v)
 }
 
+// Validate validates the DebugReactor object. It implements the
+// Validator interface.
+func (v *DebugReactor) Validate() error {
+	return Validate("DebugReactor", v)
+}
+
 // Validate validates the Error object. It implements the
 // Validator interface.
 func (v *Error) Validate() error {
 	return Validate("Error", v)
 }
 
+// Validate validates the ErrorDetail object. It implements the
+// Validator interface.
+func (v *ErrorDetail) Validate() error {
+	return Validate("ErrorDetail", v)
+}
+
 // Validate validates the Event object. It implements the
 // Validator interface.
 func (v *Event) Validate() error {
 	return Validate("Event", v)
 }
 
+// Validate validates the FeudAnswer object. It implements the
+// Validator interface.
+func (v *FeudAnswer) Validate() error {
+	return Validate("FeudAnswer", v)
+}
+
+// Validate validates the FeudGameData object. It implements the
+// Validator interface.
+func (v *FeudGameData) Validate() error {
+	return Validate("FeudGameData", v)
+}
+
+// Validate validates the FeudGameInfo object. It implements the
+// Validator interface.
+func (v *FeudGameInfo) Validate() error {
+	return Validate("FeudGameInfo", v)
+}
+
+// Validate validates the FeudQuestion object. It implements the
+// Validator interface.
+func (v *FeudQuestion) Validate() error {
+	return Validate("FeudQuestion", v)
+}
+
+// Validate validates the FeudTeam object. It implements the
+// Validator interface.
+func (v *FeudTeam) Validate() error {
+	return Validate("FeudTeam", v)
+}
+
 // Validate validates the GameData object. It implements the
 // Validator interface.
 func (v *GameData) Validate() error {
@@ -32,12 +92,42 @@ func (v *GameInfo) Validate() error {
 	return Validate("GameInfo", v)
 }
 
+// Validate validates the GameSchedule object. It implements the
+// Validator interface.
+func (v *GameSchedule) Validate() error {
+	return Validate("GameSchedule", v)
+}
+
+// Validate validates the GameSnapshot object. It implements the
+// Validator interface.
+func (v *GameSnapshot) Validate() error {
+	return Validate("GameSnapshot", v)
+}
+
+// Validate validates the JeopardyBuzz object. It implements the
+// Validator interface.
+func (v *JeopardyBuzz) Validate() error {
+	return Validate("JeopardyBuzz", v)
+}
+
 // Validate validates the JeopardyCategory object. It implements the
 // Validator interface.
 func (v *JeopardyCategory) Validate() error {
 	return Validate("JeopardyCategory", v)
 }
 
+// Validate validates the JeopardyCurrentQuestion object. It implements the
+// Validator interface.
+func (v *JeopardyCurrentQuestion) Validate() error {
+	return Validate("JeopardyCurrentQuestion", v)
+}
+
+// Validate validates the JeopardyFairBuzzer object. It implements the
+// Validator interface.
+func (v *JeopardyFairBuzzer) Validate() error {
+	return Validate("JeopardyFairBuzzer", v)
+}
+
 // Validate validates the JeopardyGameInfo object. It implements the
 // Validator interface.
 func (v *JeopardyGameInfo) Validate() error {
@@ -50,6 +140,12 @@ func (v *JeopardyQuestion) Validate() error {
 	return Validate("JeopardyQuestion", v)
 }
 
+// Validate validates the JeopardySnapshot object. It implements the
+// Validator interface.
+func (v *JeopardySnapshot) Validate() error {
+	return Validate("JeopardySnapshot", v)
+}
+
 // Validate validates the KahootGameData object. It implements the
 // Validator interface.
 func (v *KahootGameData) Validate() error {
@@ -68,6 +164,96 @@ func (v *LeaderboardEntry) Validate() error {
 	return Validate("LeaderboardEntry", v)
 }
 
+// Validate validates the Media object. It implements the
+// Validator interface.
+func (v *Media) Validate() error {
+	return Validate("Media", v)
+}
+
+// Validate validates the PollGameData object. It implements the
+// Validator interface.
+func (v *PollGameData) Validate() error {
+	return Validate("PollGameData", v)
+}
+
+// Validate validates the PollGameInfo object. It implements the
+// Validator interface.
+func (v *PollGameInfo) Validate() error {
+	return Validate("PollGameInfo", v)
+}
+
+// Validate validates the PollQuestion object. It implements the
+// Validator interface.
+func (v *PollQuestion) Validate() error {
+	return Validate("PollQuestion", v)
+}
+
+// Validate validates the PollResult object. It implements the
+// Validator interface.
+func (v *PollResult) Validate() error {
+	return Validate("PollResult", v)
+}
+
+// Validate validates the QuestionContent object. It implements the
+// Validator interface.
+func (v *QuestionContent) Validate() error {
+	return Validate("QuestionContent", v)
+}
+
+// Validate validates the QuizAnswer object. It implements the
+// Validator interface.
+func (v *QuizAnswer) Validate() error {
+	return Validate("QuizAnswer", v)
+}
+
+// Validate validates the QuizEstimateGuess object. It implements the
+// Validator interface.
+func (v *QuizEstimateGuess) Validate() error {
+	return Validate("QuizEstimateGuess", v)
+}
+
+// Validate validates the QuizGameData object. It implements the
+// Validator interface.
+func (v *QuizGameData) Validate() error {
+	return Validate("QuizGameData", v)
+}
+
+// Validate validates the QuizGameInfo object. It implements the
+// Validator interface.
+func (v *QuizGameInfo) Validate() error {
+	return Validate("QuizGameInfo", v)
+}
+
+// Validate validates the QuizOrderingGuess object. It implements the
+// Validator interface.
+func (v *QuizOrderingGuess) Validate() error {
+	return Validate("QuizOrderingGuess", v)
+}
+
+// Validate validates the QuizQuestion object. It implements the
+// Validator interface.
+func (v *QuizQuestion) Validate() error {
+	return Validate("QuizQuestion", v)
+}
+
+// Validate validates the QuizResult object. It implements the
+// Validator interface.
+func (v *QuizResult) Validate() error {
+	return Validate("QuizResult", v)
+}
+
+// Validate validates the QuizSurvival object. It implements the
+// Validator interface.
+func (v *QuizSurvival) Validate() error {
+	return Validate("QuizSurvival", v)
+}
+
+// Validate validates the RequestDebugGame object. It implements the
+// Validator interface.
+func (v *RequestDebugGame) Validate() error {
+	return Validate("RequestDebugGame", v)
+}
+
 // Validate validates the RequestGetGame object. It implements the
 // Validator interface.
 func (v *RequestGetGame) Validate() error {
@@ -80,12 +266,42 @@ func (v *RequestGetJeopardyGame) Validate() error {
 	return Validate("RequestGetJeopardyGame", v)
 }
 
+// Validate validates the RequestGetPollResults object. It implements the
+// Validator interface.
+func (v *RequestGetPollResults) Validate() error {
+	return Validate("RequestGetPollResults", v)
+}
+
+// Validate validates the RequestGetTournament object. It implements the
+// Validator interface.
+func (v *RequestGetTournament) Validate() error {
+	return Validate("RequestGetTournament", v)
+}
+
 // Validate validates the RequestNewGame object. It implements the
 // Validator interface.
 func (v *RequestNewGame) Validate() error {
 	return Validate("RequestNewGame", v)
 }
 
+// Validate validates the RequestNewTournament object. It implements the
+// Validator interface.
+func (v *RequestNewTournament) Validate() error {
+	return Validate("RequestNewTournament", v)
+}
+
+// Validate validates the RequestPreviewGameMedia object. It implements the
+// Validator interface.
+func (v *RequestPreviewGameMedia) Validate() error {
+	return Validate("RequestPreviewGameMedia", v)
+}
+
+// Validate validates the ResponseDebugGame object. It implements the
+// Validator interface.
+func (v *ResponseDebugGame) Validate() error {
+	return Validate("ResponseDebugGame", v)
+}
+
 // Validate validates the ResponseGetGame object. It implements the
 // Validator interface.
 func (v *ResponseGetGame) Validate() error {
@@ -98,8 +314,56 @@ func (v *ResponseGetJeopardyGame) Validate() error {
 	return Validate("ResponseGetJeopardyGame", v)
 }
 
+// Validate validates the ResponseGetPollResults object. It implements the
+// Validator interface.
+func (v *ResponseGetPollResults) Validate() error {
+	return Validate("ResponseGetPollResults", v)
+}
+
+// Validate validates the ResponseGetTournament object. It implements the
+// Validator interface.
+func (v *ResponseGetTournament) Validate() error {
+	return Validate("ResponseGetTournament", v)
+}
+
 // Validate validates the ResponseNewGame object. It implements the
 // Validator interface.
 func (v *ResponseNewGame) Validate() error {
 	return Validate("ResponseNewGame", v)
 }
+
+// Validate validates the ResponseNewTournament object. It implements the
+// Validator interface.
+func (v *ResponseNewTournament) Validate() error {
+	return Validate("ResponseNewTournament", v)
+}
+
+// Validate validates the ResponsePreviewGameMedia object. It implements the
+// Validator interface.
+func (v *ResponsePreviewGameMedia) Validate() error {
+	return Validate("ResponsePreviewGameMedia", v)
+}
+
+// Validate validates the ResponseUploadMedia object. It implements the
+// Validator interface.
+func (v *ResponseUploadMedia) Validate() error {
+	return Validate("ResponseUploadMedia", v)
+}
+
+// Validate validates the TournamentBracket object. It implements the
+// Validator interface.
+func (v *TournamentBracket) Validate() error {
+	return Validate("TournamentBracket", v)
+}
+
+// Validate validates the TournamentData object. It implements the
+// Validator interface.
+func (v *TournamentData) Validate() error {
+	return Validate("TournamentData", v)
+}
+
+// Validate validates the TournamentMatch object. It implements the
+// Validator interface.
+func (v *TournamentMatch) Validate() error {
+	return Validate("TournamentMatch", v)
+}
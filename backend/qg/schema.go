@@ -3,11 +3,12 @@ package qg
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	_ "embed"
 
-	"github.com/davecgh/go-spew/spew"
 	jtd "github.com/jsontypedef/json-typedef-go"
 	"github.com/pkg/errors"
 )
@@ -33,22 +34,9 @@ func init() {
 
 // func
 
-// TypeValidationError is an error that occurs when a value does not
-// match the expected type definition.
-type TypeValidationError jtd.ValidateError
-
-func (err TypeValidationError) Error() string {
-	path := "$"
-	if len(err.InstancePath) > 0 {
-		path += "." + strings.Join(err.InstancePath, ".")
-	}
-
-	return fmt.Sprintf(
-		"error at %s (%s)",
-		path, strings.Join(err.SchemaPath, "."))
-}
-
-// Validate validates the given value against the qg schema.
+// Validate validates the given value against the qg schema. If the value is
+// invalid, an invalid_request CodedError is returned with every problem that
+// was found as its details.
 func Validate(name string, v any) error {
 	vschema, ok := Schema.Definitions[name]
 	if !ok {
@@ -62,20 +50,213 @@ func Validate(name string, v any) error {
 		return errors.Wrap(err, "marshaling value to JSON")
 	}
 
-	spew.Dump(m)
-
-	errors, err := jtd.Validate(vschema, m,
-		jtd.WithMaxDepth(100),
-		jtd.WithMaxErrors(1))
+	verrs, err := jtd.Validate(vschema, m, jtd.WithMaxDepth(100))
 	if err != nil {
 		return fmt.Errorf("cannot validate %q: %w", name, err)
 	}
 
-	if len(errors) == 0 {
+	if len(verrs) == 0 {
 		return nil
 	}
 
-	return TypeValidationError(errors[0])
+	details := make([]ErrorDetail, len(verrs))
+	for i, verr := range verrs {
+		path := jsonPointer(verr.InstancePath)
+		schemaPath := jsonPointer(verr.SchemaPath)
+		details[i] = ErrorDetail{
+			Message:    explainValidateError(vschema, verr),
+			Path:       &path,
+			SchemaPath: &schemaPath,
+		}
+	}
+
+	// The validator walks maps, so the errors come in no particular order.
+	sort.Slice(details, func(i, j int) bool {
+		if *details[i].Path != *details[j].Path {
+			return *details[i].Path < *details[j].Path
+		}
+		return *details[i].SchemaPath < *details[j].SchemaPath
+	})
+
+	return newValidationError("invalid "+name, details)
+}
+
+// newValidationError returns an invalid_request error with the given details.
+// The message has the first few of them.
+func newValidationError(message string, details []ErrorDetail) error {
+	const maxShown = 3
+
+	var b strings.Builder
+	b.WriteString(message)
+	for i, detail := range details {
+		if i == maxShown {
+			fmt.Fprintf(&b, "; and %d more", len(details)-maxShown)
+			break
+		}
+
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+
+		if detail.Path != nil {
+			path := *detail.Path
+			if path == "" {
+				path = "/"
+			}
+			b.WriteString(path)
+			b.WriteString(" ")
+		}
+		b.WriteString(detail.Message)
+	}
+
+	return &CodedError{
+		Code:    ErrorCodeInvalidRequest,
+		Err:     errors.New(b.String()),
+		Details: details,
+	}
+}
+
+// jsonPointer joins the given tokens into a JSON Pointer.
+func jsonPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(jsonPointerEscaper.Replace(token))
+	}
+	return b.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// explainValidateError explains a validation error of the given root schema in
+// plain words.
+func explainValidateError(root jtd.Schema, verr jtd.ValidateError) string {
+	path := verr.SchemaPath
+
+	// Errors in a definition that was referenced start over from the root.
+	if len(path) >= 2 && path[0] == "definitions" {
+		root = Schema.Definitions[path[1]]
+		path = path[2:]
+	}
+
+	if len(path) == 0 {
+		// The schema of an object that got a property it doesn't have.
+		return "is not allowed here"
+	}
+
+	last := path[len(path)-1]
+	parent := path[:len(path)-1]
+
+	if len(parent) > 0 && parent[len(parent)-1] == "properties" {
+		return fmt.Sprintf("is missing %q", last)
+	}
+
+	schema, ok := schemaAt(root, parent)
+	if !ok {
+		return "does not match the schema"
+	}
+
+	switch last {
+	case "type":
+		return explainType(schema.Type)
+	case "enum":
+		return "must be one of " + quoteJoin(schema.Enum)
+	case "elements":
+		return "must be an array"
+	case "properties", "optionalProperties", "values":
+		return "must be an object"
+	case "discriminator":
+		instance := verr.InstancePath
+		if len(instance) > 0 && instance[len(instance)-1] == schema.Discriminator {
+			return "must be a string"
+		}
+		return fmt.Sprintf("must be an object with %q", schema.Discriminator)
+	case "mapping":
+		tags := make([]string, 0, len(schema.Mapping))
+		for tag := range schema.Mapping {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		return "must be one of " + quoteJoin(tags)
+	default:
+		// The schema of an object that got a property it doesn't have.
+		return "is not allowed here"
+	}
+}
+
+// schemaAt returns the schema at the given schema path.
+func schemaAt(schema jtd.Schema, path []string) (jtd.Schema, bool) {
+	for len(path) > 0 {
+		switch path[0] {
+		case "elements", "values":
+			next := schema.Elements
+			if path[0] == "values" {
+				next = schema.Values
+			}
+			if next == nil {
+				return jtd.Schema{}, false
+			}
+			schema = *next
+			path = path[1:]
+			continue
+		}
+
+		if len(path) < 2 {
+			return jtd.Schema{}, false
+		}
+
+		var ok bool
+		switch path[0] {
+		case "properties":
+			schema, ok = schema.Properties[path[1]]
+		case "optionalProperties":
+			schema, ok = schema.OptionalProperties[path[1]]
+		case "mapping":
+			schema, ok = schema.Mapping[path[1]]
+		}
+		if !ok {
+			return jtd.Schema{}, false
+		}
+		path = path[2:]
+	}
+	return schema, true
+}
+
+func explainType(t jtd.Type) string {
+	switch t {
+	case jtd.TypeBoolean:
+		return "must be true or false"
+	case jtd.TypeString:
+		return "must be a string"
+	case jtd.TypeTimestamp:
+		return "must be an RFC 3339 timestamp"
+	case jtd.TypeFloat32, jtd.TypeFloat64:
+		return "must be a number"
+	case jtd.TypeInt8:
+		return "must be a whole number from -128 to 127"
+	case jtd.TypeUint8:
+		return "must be a whole number from 0 to 255"
+	case jtd.TypeInt16:
+		return "must be a whole number from -32768 to 32767"
+	case jtd.TypeUint16:
+		return "must be a whole number from 0 to 65535"
+	case jtd.TypeInt32:
+		return "must be a whole number from -2147483648 to 2147483647"
+	case jtd.TypeUint32:
+		return "must be a whole number from 0 to 4294967295"
+	default:
+		return fmt.Sprintf("must be of type %s", t)
+	}
+}
+
+func quoteJoin(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
 }
 
 func asJSONMap(v any) (map[string]any, error) {
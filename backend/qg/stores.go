@@ -1,6 +1,9 @@
 package qg
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // GameStorer is a store for games.
 type GameStorer interface {
@@ -19,3 +22,56 @@ type GameStorer interface {
 	// Games gets the game IDs for all games.
 	Games(context.Context) ([]GameID, error)
 }
+
+// GameScheduleStorer is a store for games that are scheduled ahead of time.
+type GameScheduleStorer interface {
+	// ScheduleGame sets the schedule for the given game.
+	ScheduleGame(context.Context, GameID, GameSchedule) error
+	// UnscheduleGame removes the schedule of the given game. It does nothing
+	// if the game is not scheduled.
+	UnscheduleGame(context.Context, GameID) error
+	// GameSchedule gets the schedule of the given game. If the game is not
+	// scheduled, nil is returned.
+	GameSchedule(context.Context, GameID) (*GameSchedule, error)
+	// ScheduledGames gets all scheduled games along with their game data.
+	ScheduledGames(context.Context) ([]ScheduledGame, error)
+}
+
+// ScheduledGame is a game that is scheduled ahead of time.
+type ScheduledGame struct {
+	ID       GameID
+	Data     IGameData
+	Schedule GameSchedule
+}
+
+// PracticeStorer is a store for games that are practiced alone. See
+// CommandPracticeGame.
+type PracticeStorer interface {
+	// GameData gets the game data for the given game ID.
+	GameData(context.Context, GameID) (IGameData, error)
+	// PersonalBest gets the best practice score of the given player in the
+	// given game. If the player has never finished practicing the game, nil
+	// is returned.
+	PersonalBest(context.Context, GameID, PlayerName) (*float32, error)
+	// RecordPracticeScore records the score of a finished practice game. The
+	// personal best of the player is only replaced if the score beats it.
+	RecordPracticeScore(context.Context, GameID, PlayerName, float32) error
+}
+
+// ErrMediaNotFound is returned by a MediaStorer when a media blob does not
+// exist.
+var ErrMediaNotFound = NewCodedError(ErrorCodeNotFound, "media not found")
+
+// MediaStorer is a store for media blobs that are attached to questions, such
+// as images and audio clips. Media blobs are identified by their content, so
+// they never change once stored.
+type MediaStorer interface {
+	// StoreMedia stores a media blob with the given content type. Storing a
+	// blob that already exists keeps the existing one.
+	StoreMedia(ctx context.Context, contentType string, data []byte) (Media, error)
+	// Media gets the metadata of the given media blob.
+	Media(context.Context, MediaID) (Media, error)
+	// OpenMedia opens the given media blob for reading. The caller must close
+	// the returned reader.
+	OpenMedia(context.Context, MediaID) (Media, io.ReadSeekCloser, error)
+}
@@ -0,0 +1,139 @@
+// Package filesystem implements stores that keep their data in a local
+// directory.
+package filesystem
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// MediaStore is a media store that keeps every media blob in its own file,
+// named after its ID. The content type of a blob is kept next to it in a file
+// with a .type extension.
+type MediaStore struct {
+	dir string
+}
+
+var _ qg.MediaStorer = (*MediaStore)(nil)
+
+// NewMediaStore creates a new media store in the given directory. The
+// directory is created if it doesn't exist.
+func NewMediaStore(dir string) (*MediaStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "cannot create media directory")
+	}
+	return &MediaStore{dir: dir}, nil
+}
+
+// paths returns the paths of the blob and the content type of the given
+// media. IDs that aren't SHA-256 hashes are rejected, so that they can never
+// point outside of the directory.
+func (s *MediaStore) paths(id qg.MediaID) (blob, typ string, err error) {
+	if b, err := hex.DecodeString(id); err != nil || len(b) != 32 {
+		return "", "", qg.ErrMediaNotFound
+	}
+
+	blob = filepath.Join(s.dir, id)
+	return blob, blob + ".type", nil
+}
+
+func (s *MediaStore) StoreMedia(ctx context.Context, contentType string, data []byte) (qg.Media, error) {
+	id := qg.MediaIDFromData(data)
+
+	blob, typ, err := s.paths(id)
+	if err != nil {
+		return qg.Media{}, err
+	}
+
+	if _, err := os.Stat(blob); err == nil {
+		// The blob already exists, so keep it.
+		return s.Media(ctx, id)
+	}
+
+	// Write the content type first, since the blob only counts as stored once
+	// it exists.
+	if err := writeFile(typ, []byte(contentType)); err != nil {
+		return qg.Media{}, errors.Wrap(err, "cannot write content type")
+	}
+	if err := writeFile(blob, data); err != nil {
+		return qg.Media{}, errors.Wrap(err, "cannot write media")
+	}
+
+	return qg.Media{
+		ID:          id,
+		ContentType: contentType,
+		Size:        uint32(len(data)),
+	}, nil
+}
+
+func (s *MediaStore) Media(ctx context.Context, id qg.MediaID) (qg.Media, error) {
+	blob, typ, err := s.paths(id)
+	if err != nil {
+		return qg.Media{}, err
+	}
+
+	stat, err := os.Stat(blob)
+	if err != nil {
+		return qg.Media{}, fileErr(err)
+	}
+
+	contentType, err := os.ReadFile(typ)
+	if err != nil {
+		return qg.Media{}, fileErr(err)
+	}
+
+	return qg.Media{
+		ID:          id,
+		ContentType: string(contentType),
+		Size:        uint32(stat.Size()),
+	}, nil
+}
+
+func (s *MediaStore) OpenMedia(ctx context.Context, id qg.MediaID) (qg.Media, io.ReadSeekCloser, error) {
+	media, err := s.Media(ctx, id)
+	if err != nil {
+		return qg.Media{}, nil, err
+	}
+
+	blob, _, _ := s.paths(id)
+
+	f, err := os.Open(blob)
+	if err != nil {
+		return qg.Media{}, nil, fileErr(err)
+	}
+
+	return media, f, nil
+}
+
+// writeFile atomically writes a file by writing to a temporary file first.
+func writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+func fileErr(err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return qg.ErrMediaNotFound
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestMediaStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewMediaStore(t.TempDir())
+	assert.NoError(t, err)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+
+	media, err := store.StoreMedia(ctx, "image/png", data)
+	assert.NoError(t, err)
+	assert.Equal(t, qg.Media{
+		ID:          qg.MediaIDFromData(data),
+		ContentType: "image/png",
+		Size:        uint32(len(data)),
+	}, media)
+
+	t.Run("open", func(t *testing.T) {
+		got, r, err := store.OpenMedia(ctx, media.ID)
+		assert.NoError(t, err)
+		defer r.Close()
+
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, data, b)
+		assert.Equal(t, media, got)
+	})
+
+	t.Run("store_again", func(t *testing.T) {
+		// The blob already exists, so its content type is kept.
+		again, err := store.StoreMedia(ctx, "application/octet-stream", data)
+		assert.NoError(t, err)
+		assert.Equal(t, media, again)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := store.Media(ctx, qg.MediaIDFromData([]byte("nope")))
+		assert.True(t, errors.Is(err, qg.ErrMediaNotFound))
+
+		_, _, err = store.OpenMedia(ctx, "../../etc/passwd")
+		assert.True(t, errors.Is(err, qg.ErrMediaNotFound))
+	})
+}
@@ -13,5 +13,44 @@ SELECT typ FROM games WHERE id = ?;
 -- name: GetGameData :one
 SELECT data FROM games WHERE id = ? AND typ = ?;
 
+-- name: GetAnyGameData :one
+SELECT data FROM games WHERE id = ?;
+
 -- name: ListGames :many
 SELECT id FROM games;
+
+-- name: SetGameSchedule :exec
+REPLACE INTO game_schedules (game_id, opens_at, auto_begin) VALUES (?, ?, ?);
+
+-- name: DeleteGameSchedule :exec
+DELETE FROM game_schedules WHERE game_id = ?;
+
+-- name: GetGameSchedule :one
+SELECT opens_at, auto_begin FROM game_schedules WHERE game_id = ?;
+
+-- name: ListGameSchedules :many
+SELECT game_schedules.game_id, game_schedules.opens_at, game_schedules.auto_begin, games.data
+FROM game_schedules
+JOIN games ON games.id = game_schedules.game_id;
+
+-- name: SetPollResults :exec
+REPLACE INTO poll_results (game_id, results) VALUES (?, ?);
+
+-- name: GetPollResults :one
+SELECT results FROM poll_results WHERE game_id = ?;
+
+-- name: AddMedia :exec
+INSERT OR IGNORE INTO media (id, content_type, data) VALUES (?, ?, ?);
+
+-- name: GetMedia :one
+SELECT content_type, length(data) AS size FROM media WHERE id = ?;
+
+-- name: GetMediaData :one
+SELECT content_type, data FROM media WHERE id = ?;
+
+-- name: GetPracticeBest :one
+SELECT score FROM practice_bests WHERE game_id = ? AND player_name = ?;
+
+-- name: SetPracticeBest :exec
+INSERT INTO practice_bests (game_id, player_name, score) VALUES (?, ?, ?)
+ON CONFLICT (game_id, player_name) DO UPDATE SET score = max(score, excluded.score);
@@ -4,3 +4,35 @@ CREATE TABLE games (
 	mod_password TEXT,
 	data BLOB NOT NULL
 );
+
+-- MIGRATE --
+
+CREATE TABLE game_schedules (
+	game_id TEXT PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+	opens_at INTEGER NOT NULL, -- Unix milliseconds
+	auto_begin INTEGER
+);
+
+-- MIGRATE --
+
+CREATE TABLE poll_results (
+	game_id TEXT PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+	results BLOB NOT NULL -- JSON array of PollResult
+);
+
+-- MIGRATE --
+
+CREATE TABLE media (
+	id TEXT PRIMARY KEY, -- hex-encoded SHA-256 of data
+	content_type TEXT NOT NULL,
+	data BLOB NOT NULL
+);
+
+-- MIGRATE --
+
+CREATE TABLE practice_bests (
+	game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	player_name TEXT NOT NULL,
+	score REAL NOT NULL,
+	PRIMARY KEY (game_id, player_name)
+);
@@ -1,20 +1,25 @@
 package sqlite
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"oss.acmcsuf.com/qg/backend/qg"
 	"oss.acmcsuf.com/qg/backend/qg/games/jeopardy"
+	"oss.acmcsuf.com/qg/backend/qg/games/poll"
 	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite/sqlitec"
 
 	_ "embed"
 
-	_ "modernc.org/sqlite"
+	moderncsqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 //go:generate sqlc generate
@@ -49,8 +54,12 @@ type Store struct {
 }
 
 var (
-	_ qg.GameStorer   = (*Store)(nil)
-	_ jeopardy.Storer = (*Store)(nil)
+	_ qg.GameStorer         = (*Store)(nil)
+	_ qg.GameScheduleStorer = (*Store)(nil)
+	_ jeopardy.Storer       = (*Store)(nil)
+	_ poll.Storer           = (*Store)(nil)
+	_ qg.PracticeStorer     = (*Store)(nil)
+	_ qg.MediaStorer        = (*Store)(nil)
 )
 
 // New creates a new SQLite store.
@@ -163,13 +172,223 @@ func (s *Store) JeopardyGameData(ctx context.Context, id qg.GameID) (qg.Jeopardy
 		return data, sqliteErr(err)
 	}
 
-	if err := json.Unmarshal(b, &data); err != nil {
+	var gameData qg.GameData
+	if err := json.Unmarshal(b, &gameData); err != nil {
 		return data, errors.Wrap(err, "cannot decode data")
 	}
 
-	return data, nil
+	jeopardyData, ok := gameData.Value.(qg.GameDataJeopardy)
+	if !ok {
+		return data, errors.Errorf("unexpected game data type %T", gameData.Value)
+	}
+
+	return jeopardyData.Data, nil
+}
+
+func (s *Store) SetPollResults(ctx context.Context, id qg.GameID, results []qg.PollResult) error {
+	b, err := json.Marshal(results)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode results")
+	}
+
+	return sqliteErr(s.q.SetPollResults(ctx, sqlitec.SetPollResultsParams{
+		GameID:  id,
+		Results: b,
+	}))
+}
+
+func (s *Store) PollResults(ctx context.Context, id qg.GameID) ([]qg.PollResult, error) {
+	b, err := s.q.GetPollResults(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// No question has been closed yet.
+			return []qg.PollResult{}, nil
+		}
+		return nil, sqliteErr(err)
+	}
+
+	var results []qg.PollResult
+	if err := json.Unmarshal(b, &results); err != nil {
+		return nil, errors.Wrap(err, "cannot decode results")
+	}
+
+	return results, nil
+}
+
+func (s *Store) ScheduleGame(ctx context.Context, id qg.GameID, schedule qg.GameSchedule) error {
+	var autoBegin sql.NullInt64
+	if schedule.AutoBegin != nil {
+		autoBegin = sql.NullInt64{Int64: int64(*schedule.AutoBegin), Valid: true}
+	}
+
+	return sqliteErr(s.q.SetGameSchedule(ctx, sqlitec.SetGameScheduleParams{
+		GameID:    id,
+		OpensAt:   schedule.OpensAt.UnixMilli(),
+		AutoBegin: autoBegin,
+	}))
+}
+
+func (s *Store) UnscheduleGame(ctx context.Context, id qg.GameID) error {
+	return sqliteErr(s.q.DeleteGameSchedule(ctx, id))
+}
+
+func (s *Store) GameSchedule(ctx context.Context, id qg.GameID) (*qg.GameSchedule, error) {
+	row, err := s.q.GetGameSchedule(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, sqliteErr(err)
+	}
+
+	schedule := convertGameSchedule(row.OpensAt, row.AutoBegin)
+	return &schedule, nil
 }
 
+func (s *Store) ScheduledGames(ctx context.Context) ([]qg.ScheduledGame, error) {
+	rows, err := s.q.ListGameSchedules(ctx)
+	if err != nil {
+		return nil, sqliteErr(err)
+	}
+
+	games := make([]qg.ScheduledGame, len(rows))
+	for i, row := range rows {
+		var data qg.GameData
+		if err := json.Unmarshal(row.Data, &data); err != nil {
+			return nil, errors.Wrapf(err, "cannot decode data of game %q", row.GameID)
+		}
+
+		games[i] = qg.ScheduledGame{
+			ID:       row.GameID,
+			Data:     data.Value,
+			Schedule: convertGameSchedule(row.OpensAt, row.AutoBegin),
+		}
+	}
+
+	return games, nil
+}
+
+func (s *Store) GameData(ctx context.Context, id qg.GameID) (qg.IGameData, error) {
+	b, err := s.q.GetAnyGameData(ctx, id)
+	if err != nil {
+		return nil, sqliteErr(err)
+	}
+
+	var data qg.GameData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, errors.Wrap(err, "cannot decode data")
+	}
+
+	return data.Value, nil
+}
+
+func (s *Store) PersonalBest(ctx context.Context, id qg.GameID, player qg.PlayerName) (*float32, error) {
+	score, err := s.q.GetPracticeBest(ctx, sqlitec.GetPracticeBestParams{
+		GameID:     id,
+		PlayerName: player,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, sqliteErr(err)
+	}
+
+	best := float32(score)
+	return &best, nil
+}
+
+func (s *Store) RecordPracticeScore(ctx context.Context, id qg.GameID, player qg.PlayerName, score float32) error {
+	return sqliteErr(s.q.SetPracticeBest(ctx, sqlitec.SetPracticeBestParams{
+		GameID:     id,
+		PlayerName: player,
+		Score:      float64(score),
+	}))
+}
+
+func (s *Store) StoreMedia(ctx context.Context, contentType string, data []byte) (qg.Media, error) {
+	media := qg.Media{
+		ID:          qg.MediaIDFromData(data),
+		ContentType: contentType,
+		Size:        uint32(len(data)),
+	}
+
+	if err := s.q.AddMedia(ctx, sqlitec.AddMediaParams{
+		ID:          media.ID,
+		ContentType: contentType,
+		Data:        data,
+	}); err != nil {
+		return qg.Media{}, sqliteErr(err)
+	}
+
+	// The blob may have been stored before with another content type.
+	return s.Media(ctx, media.ID)
+}
+
+func (s *Store) Media(ctx context.Context, id qg.MediaID) (qg.Media, error) {
+	row, err := s.q.GetMedia(ctx, id)
+	if err != nil {
+		return qg.Media{}, mediaErr(err)
+	}
+
+	return qg.Media{
+		ID:          id,
+		ContentType: row.ContentType,
+		Size:        uint32(row.Size),
+	}, nil
+}
+
+func (s *Store) OpenMedia(ctx context.Context, id qg.MediaID) (qg.Media, io.ReadSeekCloser, error) {
+	row, err := s.q.GetMediaData(ctx, id)
+	if err != nil {
+		return qg.Media{}, nil, mediaErr(err)
+	}
+
+	media := qg.Media{
+		ID:          id,
+		ContentType: row.ContentType,
+		Size:        uint32(len(row.Data)),
+	}
+
+	return media, nopCloser{bytes.NewReader(row.Data)}, nil
+}
+
+type nopCloser struct{ io.ReadSeeker }
+
+func (nopCloser) Close() error { return nil }
+
+func mediaErr(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return qg.ErrMediaNotFound
+	}
+	return sqliteErr(err)
+}
+
+func convertGameSchedule(opensAt int64, autoBegin sql.NullInt64) qg.GameSchedule {
+	schedule := qg.GameSchedule{
+		OpensAt: time.UnixMilli(opensAt),
+	}
+	if autoBegin.Valid {
+		n := uint32(autoBegin.Int64)
+		schedule.AutoBegin = &n
+	}
+	return schedule
+}
+
+// sqliteErr turns the errors that callers can act on into qg.ErrNotFound and
+// qg.ErrConflict.
 func sqliteErr(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return qg.ErrNotFound
+	}
+
+	var sqliteErr *moderncsqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return qg.ErrConflict
+		}
+	}
+
 	return err
 }
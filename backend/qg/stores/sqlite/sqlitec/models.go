@@ -14,3 +14,26 @@ type Game struct {
 	ModPassword sql.NullString
 	Data        []byte
 }
+
+type GameSchedule struct {
+	GameID    string
+	OpensAt   int64
+	AutoBegin sql.NullInt64
+}
+
+type Medium struct {
+	ID          string
+	ContentType string
+	Data        []byte
+}
+
+type PollResult struct {
+	GameID  string
+	Results []byte
+}
+
+type PracticeBest struct {
+	GameID     string
+	PlayerName string
+	Score      float64
+}
@@ -25,6 +25,41 @@ func (q *Queries) AddGame(ctx context.Context, arg AddGameParams) error {
 	return err
 }
 
+const addMedia = `-- name: AddMedia :exec
+INSERT OR IGNORE INTO media (id, content_type, data) VALUES (?, ?, ?)
+`
+
+type AddMediaParams struct {
+	ID          string
+	ContentType string
+	Data        []byte
+}
+
+func (q *Queries) AddMedia(ctx context.Context, arg AddMediaParams) error {
+	_, err := q.db.ExecContext(ctx, addMedia, arg.ID, arg.ContentType, arg.Data)
+	return err
+}
+
+const deleteGameSchedule = `-- name: DeleteGameSchedule :exec
+DELETE FROM game_schedules WHERE game_id = ?
+`
+
+func (q *Queries) DeleteGameSchedule(ctx context.Context, gameID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGameSchedule, gameID)
+	return err
+}
+
+const getAnyGameData = `-- name: GetAnyGameData :one
+SELECT data FROM games WHERE id = ?
+`
+
+func (q *Queries) GetAnyGameData(ctx context.Context, id string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getAnyGameData, id)
+	var data []byte
+	err := row.Scan(&data)
+	return data, err
+}
+
 const getGameAdminPassword = `-- name: GetGameAdminPassword :one
 SELECT mod_password FROM games WHERE id = ?
 `
@@ -52,6 +87,22 @@ func (q *Queries) GetGameData(ctx context.Context, arg GetGameDataParams) ([]byt
 	return data, err
 }
 
+const getGameSchedule = `-- name: GetGameSchedule :one
+SELECT opens_at, auto_begin FROM game_schedules WHERE game_id = ?
+`
+
+type GetGameScheduleRow struct {
+	OpensAt   int64
+	AutoBegin sql.NullInt64
+}
+
+func (q *Queries) GetGameSchedule(ctx context.Context, gameID string) (GetGameScheduleRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameSchedule, gameID)
+	var i GetGameScheduleRow
+	err := row.Scan(&i.OpensAt, &i.AutoBegin)
+	return i, err
+}
+
 const getGameType = `-- name: GetGameType :one
 SELECT typ FROM games WHERE id = ?
 `
@@ -63,6 +114,106 @@ func (q *Queries) GetGameType(ctx context.Context, id string) (string, error) {
 	return typ, err
 }
 
+const getMedia = `-- name: GetMedia :one
+SELECT content_type, length(data) AS size FROM media WHERE id = ?
+`
+
+type GetMediaRow struct {
+	ContentType string
+	Size        int64
+}
+
+func (q *Queries) GetMedia(ctx context.Context, id string) (GetMediaRow, error) {
+	row := q.db.QueryRowContext(ctx, getMedia, id)
+	var i GetMediaRow
+	err := row.Scan(&i.ContentType, &i.Size)
+	return i, err
+}
+
+const getMediaData = `-- name: GetMediaData :one
+SELECT content_type, data FROM media WHERE id = ?
+`
+
+type GetMediaDataRow struct {
+	ContentType string
+	Data        []byte
+}
+
+func (q *Queries) GetMediaData(ctx context.Context, id string) (GetMediaDataRow, error) {
+	row := q.db.QueryRowContext(ctx, getMediaData, id)
+	var i GetMediaDataRow
+	err := row.Scan(&i.ContentType, &i.Data)
+	return i, err
+}
+
+const getPollResults = `-- name: GetPollResults :one
+SELECT results FROM poll_results WHERE game_id = ?
+`
+
+func (q *Queries) GetPollResults(ctx context.Context, gameID string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getPollResults, gameID)
+	var results []byte
+	err := row.Scan(&results)
+	return results, err
+}
+
+const getPracticeBest = `-- name: GetPracticeBest :one
+SELECT score FROM practice_bests WHERE game_id = ? AND player_name = ?
+`
+
+type GetPracticeBestParams struct {
+	GameID     string
+	PlayerName string
+}
+
+func (q *Queries) GetPracticeBest(ctx context.Context, arg GetPracticeBestParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getPracticeBest, arg.GameID, arg.PlayerName)
+	var score float64
+	err := row.Scan(&score)
+	return score, err
+}
+
+const listGameSchedules = `-- name: ListGameSchedules :many
+SELECT game_schedules.game_id, game_schedules.opens_at, game_schedules.auto_begin, games.data
+FROM game_schedules
+JOIN games ON games.id = game_schedules.game_id
+`
+
+type ListGameSchedulesRow struct {
+	GameID    string
+	OpensAt   int64
+	AutoBegin sql.NullInt64
+	Data      []byte
+}
+
+func (q *Queries) ListGameSchedules(ctx context.Context) ([]ListGameSchedulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameSchedulesRow
+	for rows.Next() {
+		var i ListGameSchedulesRow
+		if err := rows.Scan(
+			&i.GameID,
+			&i.OpensAt,
+			&i.AutoBegin,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGames = `-- name: ListGames :many
 SELECT id FROM games
 `
@@ -103,3 +254,48 @@ func (q *Queries) SetGameAdminPassword(ctx context.Context, arg SetGameAdminPass
 	_, err := q.db.ExecContext(ctx, setGameAdminPassword, arg.ModPassword, arg.ID)
 	return err
 }
+
+const setGameSchedule = `-- name: SetGameSchedule :exec
+REPLACE INTO game_schedules (game_id, opens_at, auto_begin) VALUES (?, ?, ?)
+`
+
+type SetGameScheduleParams struct {
+	GameID    string
+	OpensAt   int64
+	AutoBegin sql.NullInt64
+}
+
+func (q *Queries) SetGameSchedule(ctx context.Context, arg SetGameScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, setGameSchedule, arg.GameID, arg.OpensAt, arg.AutoBegin)
+	return err
+}
+
+const setPollResults = `-- name: SetPollResults :exec
+REPLACE INTO poll_results (game_id, results) VALUES (?, ?)
+`
+
+type SetPollResultsParams struct {
+	GameID  string
+	Results []byte
+}
+
+func (q *Queries) SetPollResults(ctx context.Context, arg SetPollResultsParams) error {
+	_, err := q.db.ExecContext(ctx, setPollResults, arg.GameID, arg.Results)
+	return err
+}
+
+const setPracticeBest = `-- name: SetPracticeBest :exec
+INSERT INTO practice_bests (game_id, player_name, score) VALUES (?, ?, ?)
+ON CONFLICT (game_id, player_name) DO UPDATE SET score = max(score, excluded.score)
+`
+
+type SetPracticeBestParams struct {
+	GameID     string
+	PlayerName string
+	Score      float64
+}
+
+func (q *Queries) SetPracticeBest(ctx context.Context, arg SetPracticeBestParams) error {
+	_, err := q.db.ExecContext(ctx, setPracticeBest, arg.GameID, arg.PlayerName, arg.Score)
+	return err
+}
@@ -0,0 +1,168 @@
+package tournament
+
+import (
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// match is a match within a bracket.
+type match struct {
+	// entrants are the two entrants of the match. An entrant is empty if it is
+	// not known yet.
+	entrants [2]qg.PlayerName
+	// bye is true if the match only ever has its first entrant.
+	bye         bool
+	gameID      qg.GameID
+	winner      qg.PlayerName
+	leaderboard qg.Leaderboard
+}
+
+func (m *match) state() qg.TournamentMatchState {
+	switch {
+	case m.winner != "":
+		return qg.TournamentMatchStateDone
+	case m.gameID != "":
+		return qg.TournamentMatchStatePlaying
+	default:
+		return qg.TournamentMatchStatePending
+	}
+}
+
+// ready returns true if the match is waiting for its game to be created.
+func (m *match) ready() bool {
+	return !m.bye && m.state() == qg.TournamentMatchStatePending &&
+		m.entrants[0] != "" && m.entrants[1] != ""
+}
+
+// bracket is a single-elimination bracket.
+type bracket struct {
+	rounds [][]*match
+	// seeds maps each entrant to their seed, starting from 0 for the top one.
+	seeds map[qg.PlayerName]int
+}
+
+// newBracket creates a new bracket for the given entrants, which are ordered
+// from the top seed to the bottom one. Byes are resolved right away.
+func newBracket(entrants []qg.PlayerName) *bracket {
+	size := 2
+	for size < len(entrants) {
+		size *= 2
+	}
+
+	b := bracket{seeds: make(map[qg.PlayerName]int, len(entrants))}
+	for seed, entrant := range entrants {
+		b.seeds[entrant] = seed
+	}
+
+	for n := size / 2; n > 0; n /= 2 {
+		round := make([]*match, n)
+		for i := range round {
+			round[i] = &match{}
+		}
+		b.rounds = append(b.rounds, round)
+	}
+
+	seeds := seedOrder(size)
+	for i, m := range b.rounds[0] {
+		for j := range m.entrants {
+			if seed := seeds[2*i+j]; seed < len(entrants) {
+				m.entrants[j] = entrants[seed]
+			}
+		}
+		if m.entrants[1] == "" {
+			// Byes always go to the top seeds, so the first entrant is known.
+			m.bye = true
+			b.finish(0, i, m.entrants[0])
+		}
+	}
+
+	return &b
+}
+
+// seedOrder returns the seeds (starting from 0) in bracket order for a bracket
+// of the given size, which must be a power of two. Adjacent seeds play each
+// other in the first round, and the top seeds only meet in the late rounds.
+func seedOrder(size int) []int {
+	seeds := []int{0}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, seed := range seeds {
+			next = append(next, seed, n-1-seed)
+		}
+		seeds = next
+	}
+	return seeds
+}
+
+// finish sets the winner of a match and advances them to the next round.
+func (b *bracket) finish(round, index int, winner qg.PlayerName) {
+	b.rounds[round][index].winner = winner
+
+	if round+1 < len(b.rounds) {
+		next := b.rounds[round+1][index/2]
+		next.entrants[index%2] = winner
+	}
+}
+
+// winner returns the winner of the final, if any.
+func (b *bracket) winner() qg.PlayerName {
+	final := b.rounds[len(b.rounds)-1][0]
+	return final.winner
+}
+
+// matchWinner picks the winner of a match from the leaderboard of its game.
+// The entrant with the higher score wins, and ties go to the higher seed. An
+// entrant who never joined the game is not on the leaderboard, so they lose
+// to one who did. If neither joined, the higher seed wins by default.
+func (b *bracket) matchWinner(entrants [2]qg.PlayerName, leaderboard qg.Leaderboard) qg.PlayerName {
+	higher, lower := entrants[0], entrants[1]
+	if b.seeds[lower] < b.seeds[higher] {
+		higher, lower = lower, higher
+	}
+
+	scores := make(map[qg.PlayerName]float32, 2)
+	for _, entry := range leaderboard {
+		if entry.PlayerName == higher || entry.PlayerName == lower {
+			scores[entry.PlayerName] = entry.Score
+		}
+	}
+
+	lowerScore, lowerPlayed := scores[lower]
+	higherScore, higherPlayed := scores[higher]
+	if lowerPlayed && (!higherPlayed || lowerScore > higherScore) {
+		return lower
+	}
+	return higher
+}
+
+// convert converts the bracket into its API representation.
+func (b *bracket) convert() [][]qg.TournamentMatch {
+	rounds := make([][]qg.TournamentMatch, len(b.rounds))
+	for i, round := range b.rounds {
+		rounds[i] = make([]qg.TournamentMatch, len(round))
+		for j, m := range round {
+			match := qg.TournamentMatch{
+				Entrants: []qg.PlayerName{},
+				State:    m.state(),
+			}
+			for _, entrant := range m.entrants {
+				if entrant != "" {
+					match.Entrants = append(match.Entrants, entrant)
+				}
+			}
+			if m.gameID != "" {
+				gameID := m.gameID
+				match.GameID = &gameID
+			}
+			if m.winner != "" {
+				winner := m.winner
+				match.Winner = &winner
+			}
+			if m.leaderboard != nil {
+				leaderboard := m.leaderboard
+				match.Leaderboard = &leaderboard
+			}
+			rounds[i][j] = match
+		}
+	}
+	return rounds
+}
@@ -0,0 +1,69 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func TestSeedOrder(t *testing.T) {
+	assert.Equal(t, []int{0, 1}, seedOrder(2))
+	assert.Equal(t, []int{0, 3, 1, 2}, seedOrder(4))
+	assert.Equal(t, []int{0, 7, 3, 4, 1, 6, 2, 5}, seedOrder(8))
+}
+
+func TestBracket(t *testing.T) {
+	b := newBracket([]qg.PlayerName{"A", "B", "C", "D", "E"})
+	assert.Equal(t, 3, len(b.rounds))
+
+	// The top three seeds get a bye.
+	first := b.convert()[0]
+	assert.Equal(t, []qg.PlayerName{"A"}, first[0].Entrants)
+	assert.Equal(t, []qg.PlayerName{"D", "E"}, first[1].Entrants)
+	assert.Equal(t, []qg.PlayerName{"B"}, first[2].Entrants)
+	assert.Equal(t, []qg.PlayerName{"C"}, first[3].Entrants)
+
+	assert.Equal(t, [2]qg.PlayerName{"A", ""}, b.rounds[1][0].entrants)
+	assert.Equal(t, [2]qg.PlayerName{"B", "C"}, b.rounds[1][1].entrants)
+	assert.False(t, b.rounds[1][0].ready())
+	assert.True(t, b.rounds[1][1].ready())
+
+	b.finish(0, 1, "E")
+	assert.True(t, b.rounds[1][0].ready())
+
+	b.finish(1, 0, "E")
+	b.finish(1, 1, "C")
+	assert.Equal(t, [2]qg.PlayerName{"E", "C"}, b.rounds[2][0].entrants)
+	assert.Equal(t, qg.PlayerName(""), b.winner())
+
+	b.finish(2, 0, "C")
+	assert.Equal(t, qg.PlayerName("C"), b.winner())
+}
+
+func TestMatchWinner(t *testing.T) {
+	b := newBracket([]qg.PlayerName{"A", "B", "C", "D"})
+
+	assert.Equal(t, "B", b.matchWinner([2]qg.PlayerName{"A", "B"}, qg.Leaderboard{
+		{PlayerName: "Host", Score: 500},
+		{PlayerName: "B", Score: 200},
+		{PlayerName: "A", Score: 100},
+	}))
+
+	// Ties go to the higher seed, no matter how the leaderboard is ordered
+	// or which side of the match they are on.
+	tied := qg.Leaderboard{
+		{PlayerName: "D", Score: 100},
+		{PlayerName: "B", Score: 100},
+	}
+	assert.Equal(t, "B", b.matchWinner([2]qg.PlayerName{"B", "D"}, tied))
+	assert.Equal(t, "B", b.matchWinner([2]qg.PlayerName{"D", "B"}, tied))
+
+	// C never joined, so D wins even without a single point.
+	assert.Equal(t, "D", b.matchWinner([2]qg.PlayerName{"C", "D"}, qg.Leaderboard{
+		{PlayerName: "D", Score: 0},
+	}))
+
+	// Nobody showed up, so the higher seed wins.
+	assert.Equal(t, "C", b.matchWinner([2]qg.PlayerName{"D", "C"}, qg.Leaderboard{}))
+}
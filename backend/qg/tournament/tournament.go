@@ -0,0 +1,236 @@
+// Package tournament runs single-elimination tournaments on top of
+// games.Manager. Each match is its own game, and the winners advance
+// automatically once their match game ends.
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/pubsub"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/games"
+)
+
+// ErrNotFound is returned when a tournament does not exist.
+var ErrNotFound = qg.NewCodedError(qg.ErrorCodeNotFound, "tournament not found")
+
+// Manager manages tournaments.
+type Manager struct {
+	ctx   context.Context
+	games *games.Manager
+	store qg.GameStorer
+
+	mu          sync.Mutex
+	tournaments map[qg.TournamentID]*tournament
+}
+
+type tournament struct {
+	id       qg.TournamentID
+	data     qg.TournamentData
+	password string
+	bracket  *bracket
+	watchers map[chan struct{}]struct{}
+}
+
+// NewManager creates a new tournament manager. Match games are created using
+// the given game manager, and they are watched until ctx is canceled.
+func NewManager(ctx context.Context, games *games.Manager, store qg.GameStorer) *Manager {
+	return &Manager{
+		ctx:         ctx,
+		games:       games,
+		store:       store,
+		tournaments: make(map[qg.TournamentID]*tournament),
+	}
+}
+
+func validateData(data qg.TournamentData) error {
+	if len(data.Entrants) < 2 {
+		return errors.New("must have at least two entrants")
+	}
+
+	seen := make(map[qg.PlayerName]bool, len(data.Entrants))
+	for _, entrant := range data.Entrants {
+		if err := qg.ValidatePlayerName(entrant); err != nil {
+			return err
+		}
+		if seen[entrant] {
+			return fmt.Errorf("entrant %q is listed more than once", entrant)
+		}
+		seen[entrant] = true
+	}
+
+	if len(data.Games) == 0 {
+		return errors.New("no games found, must have at least one")
+	}
+
+	return nil
+}
+
+// CreateTournament creates a new tournament and the games of its first
+// round. Every match game gets the given admin password.
+func (m *Manager) CreateTournament(ctx context.Context, data qg.TournamentData, password string) (qg.TournamentBracket, error) {
+	if err := validateData(data); err != nil {
+		return qg.TournamentBracket{}, qg.WithErrorCode(errors.Wrap(err, "invalid tournament data"), qg.ErrorCodeInvalidRequest)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := qg.GenerateGameID()
+	for m.tournaments[id] != nil {
+		id = qg.GenerateGameID()
+	}
+
+	t := &tournament{
+		id:       id,
+		data:     data,
+		password: password,
+		bracket:  newBracket(data.Entrants),
+		watchers: make(map[chan struct{}]struct{}),
+	}
+
+	if err := m.startMatches(ctx, t); err != nil {
+		return qg.TournamentBracket{}, err
+	}
+
+	m.tournaments[id] = t
+	return t.convert(), nil
+}
+
+// Bracket returns the current bracket of a tournament.
+func (m *Manager) Bracket(id qg.TournamentID) (qg.TournamentBracket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tournaments[id]
+	if !ok {
+		return qg.TournamentBracket{}, ErrNotFound
+	}
+
+	return t.convert(), nil
+}
+
+// Watch returns a channel that receives a value whenever the bracket of a
+// tournament changes. Changes that happen while the previous one is still
+// unread are coalesced, so the receiver should always fetch the latest
+// bracket. Watching stops once stop is called.
+func (m *Manager) Watch(id qg.TournamentID) (changed <-chan struct{}, stop func(), err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tournaments[id]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+
+	ch := make(chan struct{}, 1)
+	t.watchers[ch] = struct{}{}
+
+	return ch, func() {
+		m.mu.Lock()
+		delete(t.watchers, ch)
+		m.mu.Unlock()
+	}, nil
+}
+
+// startMatches creates the games of the matches that have both of their
+// entrants. m.mu must be held.
+func (m *Manager) startMatches(ctx context.Context, t *tournament) error {
+	for r, round := range t.bracket.rounds {
+		for i, match := range round {
+			if !match.ready() {
+				continue
+			}
+
+			data := t.data.Games[r%len(t.data.Games)]
+
+			id, err := m.games.CreateGame(ctx, data.Value)
+			if err != nil {
+				return errors.Wrapf(err, "cannot create game for round %d match %d", r+1, i+1)
+			}
+
+			if err := m.store.SetGamePassword(ctx, id, t.password); err != nil {
+				return errors.Wrap(err, "cannot set game password")
+			}
+
+			// We only wait for the game to end, so we drop old events
+			// rather than ever holding up the game's players.
+			evs := make(chan qg.IEvent, 64)
+			watchCtx := pubsub.WithSubscribeOpts(m.ctx, pubsub.SubscribeOpts{
+				Policy:     pubsub.DropOldestPolicy,
+				BufferSize: 64,
+			})
+
+			stop, err := m.games.WatchGame(watchCtx, id, evs)
+			if err != nil {
+				return err
+			}
+
+			match.gameID = id
+			go m.watchMatch(t, r, i, evs, stop)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) watchMatch(t *tournament, round, index int, evs <-chan qg.IEvent, stop func() error) {
+	defer stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case ev := <-evs:
+			if ended, ok := ev.(qg.EventGameEnded); ok {
+				m.endMatch(t, round, index, ended.Leaderboard)
+				return
+			}
+		}
+	}
+}
+
+func (m *Manager) endMatch(t *tournament, round, index int, leaderboard qg.Leaderboard) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	match := t.bracket.rounds[round][index]
+	match.leaderboard = leaderboard
+	t.bracket.finish(round, index, t.bracket.matchWinner(match.entrants, leaderboard))
+
+	if err := m.startMatches(m.ctx, t); err != nil {
+		log.Printf("tournament %q: %v", t.id, err)
+	}
+
+	t.notify()
+}
+
+// notify notifies the watchers of the tournament. m.mu must be held.
+func (t *tournament) notify() {
+	for ch := range t.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// The watcher hasn't read the previous change yet, so it will
+			// see this one as well.
+		}
+	}
+}
+
+func (t *tournament) convert() qg.TournamentBracket {
+	bracket := qg.TournamentBracket{
+		TournamentID: t.id,
+		Name:         t.data.Name,
+		Rounds:       t.bracket.convert(),
+	}
+
+	if winner := t.bracket.winner(); winner != "" {
+		bracket.Winner = &winner
+	}
+
+	return bracket
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+var quizGameData = qg.QuizGameData{
+	Questions: []qg.QuizQuestion{
+		{Value: qg.QuizQuestionMultipleChoice{
+			Question: "Which language has goroutines?",
+			Choices:  []string{"Rust", "Go", "Zig"},
+			Answer:   1,
+		}},
+		{Value: qg.QuizQuestionTrueFalse{
+			Question: "Go has generics.",
+			Answer:   true,
+		}},
+		{Value: qg.QuizQuestionEstimate{
+			Question: "How many keywords does Go have?",
+			Answer:   25,
+		}},
+		{Value: qg.QuizQuestionOrdering{
+			Question: "Sort these languages by their release year.",
+			Items:    []string{"C", "Python", "Go"},
+		}},
+	},
+	TimeLimit: p("500ms"),
+}
+
+func TestQuizWebsocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataQuiz{Data: quizGameData},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create new game:", err)
+	}
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	alice := startTestWebsocket(ctx, t, srv, "alice")
+	bob := startTestWebsocket(ctx, t, srv, "bob")
+	all := []*west.WebsocketTest{admin, alice, bob}
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	for _, player := range []struct {
+		name string
+		ws   *west.WebsocketTest
+	}{
+		{"Alice", alice},
+		{"Bob", bob},
+	} {
+		sendCommand(ctx, t, player.ws, qg.CommandJoinGame{
+			GameID:     r.GameID,
+			PlayerName: player.name,
+		})
+		joined := expectEvent[qg.EventJoinedGame](ctx, t, player.ws)
+		assert.Equal(t, qg.GameInfo{Value: qg.GameInfoQuiz{Data: qg.QuizGameInfo{
+			NumQuestions: 4,
+		}}}, joined.GameInfo)
+	}
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+
+	for _, ws := range all {
+		question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+		assert.Equal(t, qg.EventQuizBeginQuestion{
+			Index:        0,
+			QuestionType: qg.QuizQuestionTypeMultipleChoice,
+			Question:     "Which language has goroutines?",
+			Choices:      []string{"Rust", "Go", "Zig"},
+			TimeLimit:    500,
+		}, question)
+	}
+
+	answer := func(choice int32) qg.CommandQuizAnswer {
+		return qg.CommandQuizAnswer{Answer: qg.QuizAnswer{Value: qg.QuizAnswerChoice{Choice: choice}}}
+	}
+
+	t.Run("everyone_answers", func(t *testing.T) {
+		err := expectCommandError(ctx, t, admin, answer(1))
+		assert.Contains(t, err.Message, "admins cannot answer")
+
+		err = expectCommandError(ctx, t, alice, answer(3))
+		assert.Contains(t, err.Message, "invalid choice index")
+
+		sendCommand(ctx, t, alice, answer(1))
+		for _, ws := range all {
+			answered := expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+			assert.Equal(t, "Alice", answered.PlayerName)
+		}
+
+		err = expectCommandError(ctx, t, alice, answer(0))
+		assert.Contains(t, err.Message, "already answered")
+
+		// Bob is the last one to answer, so the question is revealed right
+		// away.
+		sendCommand(ctx, t, bob, answer(2))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+
+			reveal := expectEvent[qg.EventQuizReveal](ctx, t, ws)
+			assert.Equal(t, qg.EventQuizReveal{
+				Index: 0,
+				Result: qg.QuizResult{Value: qg.QuizResultChoice{
+					Answer:       1,
+					Distribution: []int32{0, 1, 1},
+				}},
+				Correct: []qg.PlayerName{"Alice"},
+				Leaderboard: qg.Leaderboard{
+					{PlayerName: "Alice", Score: 100},
+					{PlayerName: "Bob", Score: 0},
+				},
+			}, reveal)
+		}
+	})
+
+	t.Run("time_limit", func(t *testing.T) {
+		err := expectCommandError(ctx, t, alice, qg.CommandQuizNextQuestion{})
+		assert.Contains(t, err.Message, "only admins")
+
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		for _, ws := range all {
+			question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+			assert.Equal(t, []string{"True", "False"}, question.Choices)
+		}
+
+		sendCommand(ctx, t, bob, answer(0))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+		}
+
+		// Alice never answers, so the question is revealed once its time is
+		// up.
+		for _, ws := range all {
+			reveal := expectEvent[qg.EventQuizReveal](ctx, t, ws)
+			assert.Equal(t, qg.EventQuizReveal{
+				Index: 1,
+				Result: qg.QuizResult{Value: qg.QuizResultChoice{
+					Answer:       0,
+					Distribution: []int32{1, 0},
+				}},
+				Correct: []qg.PlayerName{"Bob"},
+				Leaderboard: qg.Leaderboard{
+					{PlayerName: "Alice", Score: 100},
+					{PlayerName: "Bob", Score: 100},
+				},
+			}, reveal)
+		}
+
+		err = expectCommandError(ctx, t, alice, answer(0))
+		assert.NotZero(t, err.Message)
+	})
+
+	t.Run("estimate", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		for _, ws := range all {
+			question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+			assert.Equal(t, qg.QuizQuestionTypeEstimate, question.QuestionType)
+			assert.Equal(t, []string{}, question.Choices)
+		}
+
+		err := expectCommandError(ctx, t, alice, answer(0))
+		assert.Contains(t, err.Message, "estimate questions cannot be answered with a choice answer")
+
+		guess := func(number float64) qg.CommandQuizAnswer {
+			return qg.CommandQuizAnswer{Answer: qg.QuizAnswer{Value: qg.QuizAnswerNumber{Number: number}}}
+		}
+
+		sendCommand(ctx, t, alice, guess(40))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+		}
+
+		sendCommand(ctx, t, bob, guess(20))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+
+			reveal := expectEvent[qg.EventQuizReveal](ctx, t, ws)
+			assert.Equal(t, qg.EventQuizReveal{
+				Index: 2,
+				Result: qg.QuizResult{Value: qg.QuizResultEstimate{
+					Answer: 25,
+					Guesses: []qg.QuizEstimateGuess{
+						{PlayerName: "Bob", Guess: 20, Distance: 5, Points: 100},
+						{PlayerName: "Alice", Guess: 40, Distance: 15, Points: 50},
+					},
+				}},
+				Correct: []qg.PlayerName{"Bob"},
+				Leaderboard: qg.Leaderboard{
+					{PlayerName: "Bob", Score: 200},
+					{PlayerName: "Alice", Score: 150},
+				},
+			}, reveal)
+		}
+	})
+
+	t.Run("ordering", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+
+		var choices []string
+		for _, ws := range all {
+			question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+			assert.Equal(t, qg.QuizQuestionTypeOrdering, question.QuestionType)
+			assert.NotEqual(t, []string{"C", "Python", "Go"}, question.Choices)
+			choices = question.Choices
+		}
+
+		// answer is the right order of the shuffled choices.
+		answer := make([]int32, len(choices))
+		for i, item := range []string{"C", "Python", "Go"} {
+			for j, choice := range choices {
+				if choice == item {
+					answer[i] = int32(j)
+				}
+			}
+		}
+		reversed := []int32{answer[2], answer[1], answer[0]}
+
+		order := func(order []int32) qg.CommandQuizAnswer {
+			return qg.CommandQuizAnswer{Answer: qg.QuizAnswer{Value: qg.QuizAnswerOrder{Order: order}}}
+		}
+
+		err := expectCommandError(ctx, t, alice, order([]int32{0, 1}))
+		assert.Contains(t, err.Message, "must have 3")
+
+		sendCommand(ctx, t, alice, order(answer))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+		}
+
+		sendCommand(ctx, t, bob, order(reversed))
+		for _, ws := range all {
+			expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+
+			reveal := expectEvent[qg.EventQuizReveal](ctx, t, ws)
+			assert.Equal(t, qg.EventQuizReveal{
+				Index: 3,
+				Result: qg.QuizResult{Value: qg.QuizResultOrdering{
+					Answer: answer,
+					Orders: []qg.QuizOrderingGuess{
+						{PlayerName: "Alice", Order: answer, Distance: 0, Points: 100},
+						{PlayerName: "Bob", Order: reversed, Distance: 3, Points: 0},
+					},
+				}},
+				Correct: []qg.PlayerName{"Alice"},
+				Leaderboard: qg.Leaderboard{
+					{PlayerName: "Alice", Score: 250},
+					{PlayerName: "Bob", Score: 200},
+				},
+			}, reveal)
+		}
+	})
+
+	t.Run("game_ended", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		ended := expectEvent[qg.EventGameEnded](ctx, t, alice)
+		assert.Equal(t, qg.Leaderboard{
+			{PlayerName: "Alice", Score: 250},
+			{PlayerName: "Bob", Score: 200},
+		}, ended.Leaderboard)
+	})
+}
+
+func TestQuizSurvival(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	data := quizGameData
+	data.Questions = data.Questions[:2]
+	data.TimeLimit = p("5s")
+	data.Survival = &qg.QuizSurvival{
+		TieBreaker: p(qg.QuizTieBreakerSuddenDeath),
+		SuddenDeath: []qg.QuizQuestion{
+			{Value: qg.QuizQuestionTrueFalse{
+				Question: "Go has a while keyword.",
+				Answer:   false,
+			}},
+		},
+	}
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataQuiz{Data: data},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create new game:", err)
+	}
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	alice := startTestWebsocket(ctx, t, srv, "alice")
+	bob := startTestWebsocket(ctx, t, srv, "bob")
+	carol := startTestWebsocket(ctx, t, srv, "carol")
+	all := []*west.WebsocketTest{admin, alice, bob, carol}
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        r.GameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	for _, player := range []struct {
+		name string
+		ws   *west.WebsocketTest
+	}{
+		{"Alice", alice},
+		{"Bob", bob},
+		{"Carol", carol},
+	} {
+		sendCommand(ctx, t, player.ws, qg.CommandJoinGame{
+			GameID:     r.GameID,
+			PlayerName: player.name,
+		})
+		joined := expectEvent[qg.EventJoinedGame](ctx, t, player.ws)
+		assert.Equal(t, qg.GameInfo{Value: qg.GameInfoQuiz{Data: qg.QuizGameInfo{
+			NumQuestions: 2,
+			Survival:     true,
+		}}}, joined.GameInfo)
+	}
+
+	answer := func(choice int32) qg.CommandQuizAnswer {
+		return qg.CommandQuizAnswer{Answer: qg.QuizAnswer{Value: qg.QuizAnswerChoice{Choice: choice}}}
+	}
+
+	// play plays a question where each player answers with the given choice
+	// and returns the elimination event.
+	play := func(t *testing.T, answers map[*west.WebsocketTest]int32) qg.EventQuizEliminated {
+		t.Helper()
+
+		for ws, choice := range answers {
+			sendCommand(ctx, t, ws, answer(choice))
+			for _, ws := range all {
+				expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+			}
+		}
+
+		var eliminated qg.EventQuizEliminated
+		for _, ws := range all {
+			expectEvent[qg.EventQuizReveal](ctx, t, ws)
+			eliminated = expectEvent[qg.EventQuizEliminated](ctx, t, ws)
+		}
+
+		return eliminated
+	}
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+	for _, ws := range all {
+		question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+		assert.False(t, question.SuddenDeath)
+	}
+
+	t.Run("eliminate", func(t *testing.T) {
+		eliminated := play(t, map[*west.WebsocketTest]int32{alice: 1, bob: 1, carol: 0})
+		assert.Equal(t, qg.EventQuizEliminated{
+			Index:      0,
+			Eliminated: []qg.PlayerName{"Carol"},
+			Survivors:  []qg.PlayerName{"Alice", "Bob"},
+		}, eliminated)
+
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		for _, ws := range all {
+			expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+		}
+
+		// Carol is a spectator now.
+		err := expectCommandError(ctx, t, carol, answer(0))
+		assert.Contains(t, err.Message, "you have been eliminated")
+	})
+
+	t.Run("revive", func(t *testing.T) {
+		// Nobody gets it right, so nobody is eliminated.
+		eliminated := play(t, map[*west.WebsocketTest]int32{alice: 1, bob: 1})
+		assert.Equal(t, qg.EventQuizEliminated{
+			Index:      1,
+			Eliminated: []qg.PlayerName{},
+			Survivors:  []qg.PlayerName{"Alice", "Bob"},
+		}, eliminated)
+	})
+
+	t.Run("sudden_death", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		for _, ws := range all {
+			question := expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+			assert.Equal(t, int32(2), question.Index)
+			assert.True(t, question.SuddenDeath)
+		}
+
+		eliminated := play(t, map[*west.WebsocketTest]int32{alice: 1, bob: 0})
+		assert.Equal(t, qg.EventQuizEliminated{
+			Index:      2,
+			Eliminated: []qg.PlayerName{"Bob"},
+			Survivors:  []qg.PlayerName{"Alice"},
+		}, eliminated)
+	})
+
+	t.Run("game_ended", func(t *testing.T) {
+		sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+		ended := expectEvent[qg.EventGameEnded](ctx, t, carol)
+		assert.Equal(t, qg.Leaderboard{
+			{PlayerName: "Alice", Score: 3},
+			{PlayerName: "Bob", Score: 2},
+			{PlayerName: "Carol", Score: 0},
+		}, ended.Leaderboard)
+	})
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/gorilla/websocket"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestScheduledGame(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	startServer := func() *httptest.Server {
+		handler := newHandler(ctx, store, store)
+		t.Cleanup(func() { handler.Close() })
+
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	srv := startServer()
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	opensAt := time.Now().Add(500 * time.Millisecond).Truncate(time.Millisecond)
+
+	r, err := hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataJeopardy{Data: jeopardyGameData},
+			},
+			Schedule: &qg.GameSchedule{
+				OpensAt:   opensAt,
+				AutoBegin: p(uint32(1)),
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to schedule new game:", err)
+	}
+
+	gameID := r.GameID
+
+	game, err := hc.GET[qg.ResponseGetGame](ctx, client, "/game/"+gameID, nil)
+	if err != nil {
+		t.Fatal("failed to get game:", err)
+	}
+
+	assert.NotZero(t, game.Schedule)
+	assert.True(t, game.Schedule.OpensAt.Equal(opensAt), "unexpected opensAt %v", game.Schedule.OpensAt)
+	assert.Equal(t, p(uint32(1)), game.Schedule.AutoBegin)
+
+	t.Run("lobby_closed", func(t *testing.T) {
+		ws := startTestWebsocket(ctx, t, srv, "player 1")
+
+		err := expectCommandError(ctx, t, ws, qg.CommandJoinGame{
+			GameID:     gameID,
+			PlayerName: "Player 1",
+		})
+		assert.Contains(t, err.Message, "the lobby opens at")
+	})
+
+	// Restart the server before the lobby opens. The game must keep its ID
+	// and schedule, and it must still begin on its own.
+	srv = startServer()
+
+	t.Run("lobby_open", func(t *testing.T) {
+		time.Sleep(time.Until(opensAt))
+
+		ws := startTestWebsocket(ctx, t, srv, "player 1")
+
+		sendCommand(ctx, t, ws, qg.CommandJoinGame{
+			GameID:     gameID,
+			PlayerName: "Player 1",
+		})
+
+		expectEvent[qg.EventJoinedGame](ctx, t, ws)
+
+		// Player 1 is the only player that we're waiting for.
+		expectEvent[qg.EventGameStarted](ctx, t, ws)
+
+		turn := expectEvent[qg.EventJeopardyTurnEnded](ctx, t, ws)
+		assert.Equal(t, turn.Chooser, "Player 1")
+	})
+}
+
+func TestScheduledGameRestoredOpen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	gameID, err := store.CreateGame(ctx, qg.GameDataJeopardy{Data: jeopardyGameData})
+	must(t, err)
+	must(t, store.SetGamePassword(ctx, gameID, "admin"))
+
+	// The lobby had already opened before the restart.
+	must(t, store.ScheduleGame(ctx, gameID, qg.GameSchedule{
+		OpensAt:   time.Now().Add(-time.Minute),
+		AutoBegin: p(uint32(1)),
+	}))
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ws := startTestWebsocket(ctx, t, srv, "player 1")
+
+	sendCommand(ctx, t, ws, qg.CommandJoinGame{
+		GameID:     gameID,
+		PlayerName: "Player 1",
+	})
+
+	expectEvent[qg.EventJoinedGame](ctx, t, ws)
+
+	// Players may have been in the middle of the game, so it waits for an
+	// admin to resume it, even though Player 1 is the only player that we're
+	// waiting for.
+	expectEvent[qg.EventGamePaused](ctx, t, ws)
+	snapshot := expectEvent[qg.EventGameSnapshot](ctx, t, ws)
+	assert.True(t, snapshot.Paused)
+	assert.False(t, snapshot.Started)
+
+	pressErr := expectCommandError(ctx, t, ws, qg.CommandJeopardyPressButton{})
+	assert.Equal(t, "the game is paused", pressErr.Message)
+	assert.Equal(t, p(qg.ErrorCodeInvalidState), pressErr.Code)
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        gameID,
+		PlayerName:    "Admin",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	sendCommand(ctx, t, admin, qg.CommandResumeGame{})
+
+	// The game begins as soon as it is resumed.
+	expectEvent[qg.EventGameResumed](ctx, t, ws)
+	expectEvent[qg.EventGameStarted](ctx, t, ws)
+
+	turn := expectEvent[qg.EventJeopardyTurnEnded](ctx, t, ws)
+	assert.Equal(t, turn.Chooser, "Player 1")
+}
+
+func TestScheduledGameExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	id, err := store.CreateGame(ctx, qg.GameDataJeopardy{Data: jeopardyGameData})
+	must(t, err)
+
+	must(t, store.ScheduleGame(ctx, id, qg.GameSchedule{
+		OpensAt: time.Now().Add(-2 * 24 * time.Hour),
+	}))
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	schedule, err := store.GameSchedule(ctx, id)
+	must(t, err)
+	assert.Zero(t, schedule)
+}
+
+func TestScheduledGameInvalid(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	// The game refuses a board without any categories.
+	_, err = hc.POST[qg.ResponseNewGame](ctx, client, "/game",
+		qg.RequestNewGame{
+			AdminPassword: "admin",
+			Data: qg.GameData{
+				Value: qg.GameDataJeopardy{Data: qg.JeopardyGameData{
+					Categories: []qg.JeopardyCategory{},
+				}},
+			},
+			Schedule: &qg.GameSchedule{
+				OpensAt: time.Now().Add(time.Hour),
+			},
+		},
+	)
+	assert.Error(t, err)
+
+	// So it must not be restored after a restart either.
+	scheduled, err := store.ScheduledGames(ctx)
+	must(t, err)
+	assert.Equal(t, 0, len(scheduled))
+}
+
+func startTestWebsocket(ctx context.Context, t *testing.T, srv *httptest.Server, who string) *west.WebsocketTest {
+	t.Helper()
+
+	ws, err := west.NewTestWebsocket(srv.URL+"/ws", &websocket.Dialer{
+		HandshakeTimeout: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal("failed to create websocket:", err)
+	}
+
+	ws.LogSent = func(msg json.RawMessage) {
+		t.Helper()
+		t.Log("sent", who+":", string(msg))
+	}
+
+	ws.LogReceived = func(msg json.RawMessage) {
+		t.Helper()
+		t.Log("recv", who+":", string(msg))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		ws.Start(ctx)
+		wg.Done()
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	return ws
+}
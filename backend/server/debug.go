@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"reflect"
+
+	"oss.acmcsuf.com/qg/backend/internal/cando"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// debugGame returns a snapshot of the state machine of a running game for its
+// admins.
+func (h *apiHandler) debugGame(ctx context.Context, body qg.RequestDebugGame) (qg.ResponseDebugGame, error) {
+	ok, err := h.store.CompareGamePassword(ctx, body.GameID, body.AdminPassword)
+	if err != nil {
+		return qg.ResponseDebugGame{}, err
+	}
+	if !ok {
+		return qg.ResponseDebugGame{}, qg.NewCodedError(qg.ErrorCodeUnauthorized, "invalid admin password")
+	}
+
+	inspection, err := h.gameManager.InspectGame(ctx, body.GameID)
+	if err != nil {
+		return qg.ResponseDebugGame{}, err
+	}
+
+	current := "init"
+	if inspection.Current != nil {
+		current = cando.TypeName(inspection.Current)
+	}
+
+	reactors := make([]qg.DebugReactor, len(inspection.Reactors))
+	for i, reactor := range inspection.Reactors {
+		reactors[i] = qg.DebugReactor{
+			Hook: string(reactor.Hook),
+			From: cando.TypeName(reactor.From),
+			To:   cando.TypeName(reactor.To),
+		}
+	}
+
+	return qg.ResponseDebugGame{
+		Current:    current,
+		Ended:      inspection.Ended(),
+		Next:       typeNames(inspection.Next),
+		Superstate: typeNames(inspection.Superstate),
+		Reactors:   reactors,
+		Dot:        inspection.DOT(),
+		Mermaid:    inspection.Mermaid(),
+	}, nil
+}
+
+func typeNames(types []reflect.Type) []string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = cando.TypeName(t)
+	}
+	return names
+}
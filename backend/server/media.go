@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/internal/hrt"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+// MaxMediaSize is the maximum size of an uploaded media blob.
+const MaxMediaSize = 10 << 20 // 10 MiB
+
+// mediaCacheControl is the Cache-Control header of media blobs. A media ID is
+// the hash of its blob, so a blob never changes and can be cached forever.
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+// postMedia uploads a media blob, which is the raw request body.
+func (h *apiHandler) postMedia(w http.ResponseWriter, r *http.Request) {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		writeError(w, hrt.WrapHTTPError(http.StatusBadRequest, errors.Wrap(err, "invalid Content-Type")))
+		return
+	}
+
+	if !strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "audio/") {
+		writeError(w, hrt.WrapHTTPError(http.StatusUnsupportedMediaType,
+			errors.Errorf("unsupported media type %q, must be an image or audio", contentType)))
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxMediaSize))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, hrt.WrapHTTPError(http.StatusRequestEntityTooLarge,
+				errors.Errorf("media must not be larger than %d bytes", MaxMediaSize)))
+			return
+		}
+		writeError(w, hrt.WrapHTTPError(http.StatusBadRequest, errors.Wrap(err, "cannot read media")))
+		return
+	}
+
+	if len(data) == 0 {
+		writeError(w, hrt.WrapHTTPError(http.StatusBadRequest, errors.New("media is empty")))
+		return
+	}
+
+	media, err := h.media.StoreMedia(r.Context(), contentType, data)
+	if err != nil {
+		writeError(w, errors.Wrap(err, "cannot store media"))
+		return
+	}
+
+	enc := hrt.OptsFromContext(r.Context()).Encoder
+	if err := enc.Encode(w, qg.ResponseUploadMedia{Media: media}); err != nil {
+		writeError(w, err)
+	}
+}
+
+// getMedia serves a media blob. Range requests are supported so that audio
+// clips can be seeked.
+func (h *apiHandler) getMedia(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "mediaID")
+
+	media, blob, err := h.media.OpenMedia(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", media.ContentType)
+	w.Header().Set("Cache-Control", mediaCacheControl)
+	w.Header().Set("ETag", `"`+media.ID+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, "", time.Time{}, blob)
+}
+
+// previewGameMedia lists the media of a game for its admins. It fails if any
+// of the media does not exist.
+func (h *apiHandler) previewGameMedia(ctx context.Context, body qg.RequestPreviewGameMedia) (qg.ResponsePreviewGameMedia, error) {
+	ok, err := h.store.CompareGamePassword(ctx, body.GameID, body.AdminPassword)
+	if err != nil {
+		return qg.ResponsePreviewGameMedia{}, err
+	}
+	if !ok {
+		return qg.ResponsePreviewGameMedia{}, qg.NewCodedError(qg.ErrorCodeUnauthorized, "invalid admin password")
+	}
+
+	data, err := h.gameData(ctx, body.GameID)
+	if err != nil {
+		return qg.ResponsePreviewGameMedia{}, err
+	}
+
+	ids := qg.GameMedia(data)
+	media := make([]qg.Media, len(ids))
+	for i, id := range ids {
+		media[i], err = h.media.Media(ctx, id)
+		if err != nil {
+			return qg.ResponsePreviewGameMedia{}, errors.Wrapf(err, "cannot preview media %q", id)
+		}
+	}
+
+	return qg.ResponsePreviewGameMedia{Media: media}, nil
+}
+
+// gameData returns the game data of a game. Only game types that can have
+// media are supported; nil is returned for the others.
+func (h *apiHandler) gameData(ctx context.Context, id qg.GameID) (qg.IGameData, error) {
+	gameType, err := h.store.GameType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch gameType {
+	case qg.GameTypeJeopardy:
+		data, err := h.store.JeopardyGameData(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return qg.GameDataJeopardy{Data: data}, nil
+	default:
+		return nil, nil
+	}
+}
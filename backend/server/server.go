@@ -11,13 +11,17 @@ import (
 	"oss.acmcsuf.com/qg/backend/qg"
 	"oss.acmcsuf.com/qg/backend/qg/games"
 	"oss.acmcsuf.com/qg/backend/qg/games/jeopardy"
+	"oss.acmcsuf.com/qg/backend/qg/games/poll"
+	"oss.acmcsuf.com/qg/backend/qg/tournament"
 	"oss.acmcsuf.com/qg/backend/server/ws"
 )
 
 // Storer is a storage interface for the server.
 type Storer interface {
 	qg.GameStorer
+	qg.GameScheduleStorer
 	jeopardy.Storer
+	poll.Storer
 }
 
 type handler struct {
@@ -32,12 +36,13 @@ type HTTPHandlerCloser interface {
 	io.Closer
 }
 
-// NewHandler creates a new Handler.
-func NewHandler(storer Storer, gm *games.Manager) HTTPHandlerCloser {
+// NewHandler creates a new Handler. Uploaded media are kept in the given media
+// store.
+func NewHandler(storer Storer, media qg.MediaStorer, gm *games.Manager, tm *tournament.Manager) HTTPHandlerCloser {
 	h := &handler{
 		Mux: chi.NewMux(),
 		ws:  ws.NewHandler(gm),
-		api: newAPIHandler(storer, gm),
+		api: newAPIHandler(storer, media, gm, tm),
 	}
 
 	h.Use(hrt.Use(hrt.Opts{
@@ -52,6 +57,21 @@ func NewHandler(storer Storer, gm *games.Manager) HTTPHandlerCloser {
 		r.Post("/", hrt.Wrap(h.api.postGame))
 
 		r.Get("/jeopardy/{gameID}", hrt.Wrap(h.api.getJeopardy))
+		r.Post("/poll/results", hrt.Wrap(h.api.getPollResults))
+
+		r.Post("/debug", hrt.Wrap(h.api.debugGame))
+	})
+
+	h.Route("/media", func(r chi.Router) {
+		r.Get("/{mediaID}", h.api.getMedia)
+		r.Post("/", h.api.postMedia)
+		r.Post("/preview", hrt.Wrap(h.api.previewGameMedia))
+	})
+
+	h.Route("/tournament", func(r chi.Router) {
+		r.Get("/{tournamentID}", hrt.Wrap(h.api.getTournament))
+		r.Get("/{tournamentID}/events", h.api.streamTournament)
+		r.Post("/", hrt.Wrap(h.api.postTournament))
 	})
 
 	return h
@@ -68,13 +88,17 @@ func writeError(w http.ResponseWriter, err error) {
 
 type apiHandler struct {
 	store       Storer
+	media       qg.MediaStorer
 	gameManager *games.Manager
+	tournaments *tournament.Manager
 }
 
-func newAPIHandler(store Storer, gm *games.Manager) *apiHandler {
+func newAPIHandler(store Storer, media qg.MediaStorer, gm *games.Manager, tm *tournament.Manager) *apiHandler {
 	return &apiHandler{
 		store:       store,
+		media:       media,
 		gameManager: gm,
+		tournaments: tm,
 	}
 }
 
@@ -84,11 +108,25 @@ func (h *apiHandler) getGame(ctx context.Context, body qg.RequestGetGame) (qg.Re
 		return qg.ResponseGetGame{}, err
 	}
 
-	return qg.ResponseGetGame{GameType: gameType}, nil
+	schedule, err := h.store.GameSchedule(ctx, body.GameID)
+	if err != nil {
+		return qg.ResponseGetGame{}, errors.Wrap(err, "failed to get game schedule")
+	}
+
+	return qg.ResponseGetGame{
+		GameType: gameType,
+		Schedule: schedule,
+	}, nil
 }
 
 func (h *apiHandler) postGame(ctx context.Context, body qg.RequestNewGame) (qg.ResponseNewGame, error) {
-	gameID, err := h.gameManager.CreateGame(ctx, body.Data.Value)
+	var gameID qg.GameID
+	var err error
+	if body.Schedule != nil {
+		gameID, err = h.gameManager.ScheduleGame(ctx, body.Data.Value, *body.Schedule)
+	} else {
+		gameID, err = h.gameManager.CreateGame(ctx, body.Data.Value)
+	}
 	if err != nil {
 		return qg.ResponseNewGame{}, err
 	}
@@ -112,3 +150,29 @@ func (h *apiHandler) getJeopardy(ctx context.Context, body qg.RequestGetJeopardy
 	info := qg.ConvertJeopardyGameData(data)
 	return qg.ResponseGetJeopardyGame{Info: info}, nil
 }
+
+func (h *apiHandler) getPollResults(ctx context.Context, body qg.RequestGetPollResults) (qg.ResponseGetPollResults, error) {
+	ok, err := h.store.CompareGamePassword(ctx, body.GameID, body.AdminPassword)
+	if err != nil {
+		return qg.ResponseGetPollResults{}, err
+	}
+	if !ok {
+		return qg.ResponseGetPollResults{}, qg.NewCodedError(qg.ErrorCodeUnauthorized, "invalid admin password")
+	}
+
+	gameType, err := h.store.GameType(ctx, body.GameID)
+	if err != nil {
+		return qg.ResponseGetPollResults{}, err
+	}
+
+	if gameType != qg.GameTypePoll {
+		return qg.ResponseGetPollResults{}, qg.NewCodedError(qg.ErrorCodeInvalidRequest, "game is not a poll")
+	}
+
+	results, err := h.store.PollResults(ctx, body.GameID)
+	if err != nil {
+		return qg.ResponseGetPollResults{}, err
+	}
+
+	return qg.ResponseGetPollResults{Results: results}, nil
+}
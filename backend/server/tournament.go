@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"oss.acmcsuf.com/qg/backend/qg"
+)
+
+func (h *apiHandler) postTournament(ctx context.Context, body qg.RequestNewTournament) (qg.ResponseNewTournament, error) {
+	bracket, err := h.tournaments.CreateTournament(ctx, body.Data, body.AdminPassword)
+	if err != nil {
+		return qg.ResponseNewTournament{}, err
+	}
+
+	return qg.ResponseNewTournament{Bracket: bracket}, nil
+}
+
+func (h *apiHandler) getTournament(ctx context.Context, body qg.RequestGetTournament) (qg.ResponseGetTournament, error) {
+	bracket, err := h.tournaments.Bracket(body.TournamentID)
+	if err != nil {
+		return qg.ResponseGetTournament{}, err
+	}
+
+	return qg.ResponseGetTournament{Bracket: bracket}, nil
+}
+
+// streamTournament streams the bracket of a tournament as server-sent events
+// for bracket displays. The current bracket is sent right away, followed by
+// the whole bracket every time that it changes. The stream ends once the
+// tournament has a winner.
+func (h *apiHandler) streamTournament(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "tournamentID")
+
+	changed, stop, err := h.tournaments.Watch(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer stop()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		bracket, err := h.tournaments.Bracket(id)
+		if err != nil {
+			return
+		}
+
+		b, err := json.Marshal(bracket)
+		if err != nil {
+			return
+		}
+
+		if _, err := w.Write([]byte("data: " + string(b) + "\n\n")); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		if bracket.Winner != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changed:
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package ws
 
 import (
+	"context"
 	"net/http"
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"oss.acmcsuf.com/qg/backend/internal/pubsub"
 	"oss.acmcsuf.com/qg/backend/qg"
 )
 
@@ -18,6 +20,13 @@ type Handler struct {
 	// Upgrader is the websocket upgrader. It is used to upgrade HTTP requests
 	// to websocket connections.
 	Upgrader websocket.Upgrader
+	// SubscribeOpts decides what happens once a connection falls behind on
+	// its events. Its OnDisconnect and Stats are set for each connection. By
+	// default, a connection that falls behind is closed with
+	// websocket.CloseTryAgainLater so that it never holds up the game. The
+	// client may then join again with the rejoin token from its
+	// EventJoinedGame and catch up with a snapshot.
+	SubscribeOpts pubsub.SubscribeOpts
 
 	wg   sync.WaitGroup
 	srvs sync.Map
@@ -27,17 +36,18 @@ type Handler struct {
 // NewHandler creates a new websocket handler.
 func NewHandler(hfac qg.CommandHandlerFactory) *Handler {
 	return &Handler{
-		Upgrader: DefaultUpgrader,
-		hfac:     hfac,
+		Upgrader:      DefaultUpgrader,
+		SubscribeOpts: pubsub.DefaultSubscribeOpts,
+		hfac:          hfac,
 	}
 }
 
 // Stop stops all servers. The function blocks until all servers have been
 // stopped.
 func (h *Handler) Stop() {
-	h.srvs.Range(func(k, _ any) bool {
-		ch := k.(chan qg.IEvent)
-		close(ch)
+	h.srvs.Range(func(_, v any) bool {
+		cancel := v.(context.CancelCauseFunc)
+		cancel(nil)
 		return true
 	})
 	h.wg.Wait()
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
 	"golang.org/x/time/rate"
+	"oss.acmcsuf.com/qg/backend/internal/pubsub"
+	"oss.acmcsuf.com/qg/backend/internal/rtt"
 	"oss.acmcsuf.com/qg/backend/qg"
 )
 
@@ -31,15 +34,29 @@ func (h serverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithCancelCause(r.Context())
 	defer cancel(nil)
 
+	estimator := rtt.NewEstimator()
+	ctx = rtt.WithEstimator(ctx, estimator)
+
+	stats := new(pubsub.Stats)
+
+	subOpts := h.root.SubscribeOpts
+	subOpts.Stats = stats
+	subOpts.OnDisconnect = cancel
+	ctx = pubsub.WithSubscribeOpts(ctx, subOpts)
+
 	defer func() {
 		if context.Cause(ctx) != nil {
 			log.Println("closing websocket:", context.Cause(ctx))
 		}
+		if dropped := stats.Dropped.Load(); dropped > 0 {
+			log.Printf("websocket dropped %d events, sent %d, at most %d buffered",
+				dropped, stats.Sent.Load(), stats.MaxBuffered.Load())
+		}
 	}()
 
 	ch := make(chan qg.IEvent, 16)
 
-	h.root.srvs.Store(ch, struct{}{})
+	h.root.srvs.Store(ch, cancel)
 	defer h.root.srvs.Delete(ch)
 
 	cmdh, err := h.root.hfac.NewCommandHandler(ctx, ch)
@@ -55,6 +72,7 @@ func (h serverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	server := &server{
 		ws:     conn,
 		ev:     ch,
+		rtt:    estimator,
 		cancel: cancel,
 	}
 
@@ -75,6 +93,7 @@ func (h serverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 type server struct {
 	ws     *websocket.Conn
 	ev     chan qg.IEvent
+	rtt    *rtt.Estimator
 	cancel context.CancelCauseFunc
 }
 
@@ -86,26 +105,59 @@ func (s *server) commandLoop(ctx context.Context, cmdh qg.CommandHandler) {
 			return
 		}
 
-		var cmd qg.Command
-		if err := s.ws.ReadJSON(&cmd); err != nil {
+		_, b, err := s.ws.ReadMessage()
+		if err != nil {
 			s.cancel(err)
 			return
 		}
 
-		if err := cmdh.HandleCommand(ctx, cmd.Value); err != nil {
-			event := qg.EventError{
-				Error: qg.Error{
-					Message: err.Error(),
-				},
+		// Every command type has the same optional ID, so we can get it
+		// without knowing the type.
+		var envelope struct {
+			ID *string `json:"id"`
+		}
+		if err := json.Unmarshal(b, &envelope); err != nil {
+			// Without an ID, the client cannot tell which command failed,
+			// but it should still know that one did.
+			err = errors.Wrap(err, "invalid command ID")
+			reply := qg.EventError{
+				Error: qg.NewError(qg.WithErrorCode(err, qg.ErrorCodeInvalidRequest)),
 			}
-
 			select {
-			case s.ev <- event:
+			case s.ev <- reply:
 				continue
 			case <-ctx.Done():
 				return
 			}
 		}
+
+		var cmd qg.Command
+		if err := json.Unmarshal(b, &cmd); err != nil {
+			s.cancel(err)
+			return
+		}
+
+		// Record the time that the command arrived before it waits on the
+		// game, so games can compensate for the connection latency.
+		cmdCtx := rtt.WithReceived(ctx, time.Now())
+
+		var reply qg.IEvent
+		if err := cmdh.HandleCommand(cmdCtx, cmd.Value); err != nil {
+			reply = qg.EventError{
+				Error: qg.NewError(err),
+				ID:    envelope.ID,
+			}
+		} else if envelope.ID != nil {
+			reply = qg.EventAck{ID: *envelope.ID}
+		} else {
+			continue
+		}
+
+		select {
+		case s.ev <- reply:
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
@@ -120,24 +172,38 @@ func (s *server) eventLoop(ctx context.Context) {
 	heartbeat := time.NewTicker(heartrate)
 	defer heartbeat.Stop()
 
-	resetDeadline := func() {
-		deadline := time.Now().Add(2 * heartrate)
-		s.ws.SetReadDeadline(deadline)
-		s.ws.SetWriteDeadline(deadline)
+	// The pong handler runs on the reading goroutine, so it may only touch the
+	// read deadline. The write deadline is set by this goroutine before each
+	// write.
+	resetReadDeadline := func() {
+		s.ws.SetReadDeadline(time.Now().Add(2 * heartrate))
 	}
-	resetDeadline()
+	resetReadDeadline()
 
-	s.ws.SetPongHandler(func(string) error {
-		resetDeadline()
+	s.ws.SetPongHandler(func(data string) error {
+		resetReadDeadline()
+		s.observePong(data)
 		return nil
 	})
 
+	// Ping once right away so that we have an RTT estimate before the first
+	// heartbeat.
+	if err := s.writePing(); err != nil {
+		s.cancel(err)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			var code int
 			var message string
-			if err := context.Cause(ctx); err != ctx.Err() {
+			if err := context.Cause(ctx); errors.Is(err, pubsub.ErrSlowSubscriber) {
+				// Give the client the EventError that says why, if it is
+				// still waiting for us.
+				s.flushEvents()
+				code = websocket.CloseTryAgainLater
+				message = err.Error()
+			} else if err != ctx.Err() {
 				if err == nil {
 					code = websocket.CloseNormalClosure
 				} else {
@@ -170,6 +236,7 @@ func (s *server) eventLoop(ctx context.Context) {
 				continue
 			}
 
+			s.ws.SetWriteDeadline(time.Now().Add(2 * heartrate))
 			if err := s.ws.WriteMessage(websocket.TextMessage, b); err != nil {
 				s.cancel(err)
 				continue
@@ -178,6 +245,31 @@ func (s *server) eventLoop(ctx context.Context) {
 	}
 }
 
+// flushEvents writes the events that are already waiting without waiting for
+// any more.
+func (s *server) flushEvents() {
+	for {
+		select {
+		case event := <-s.ev:
+			if err := s.writeEvent(event); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *server) writeEvent(event qg.IEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.ws.SetWriteDeadline(time.Now().Add(controlMessageTimeout))
+	return s.ws.WriteMessage(websocket.TextMessage, b)
+}
+
 const controlMessageTimeout = 5 * time.Second
 
 func (s *server) writeClose(messageCode int, message string) error {
@@ -187,9 +279,19 @@ func (s *server) writeClose(messageCode int, message string) error {
 		time.Now().Add(controlMessageTimeout))
 }
 
+// writePing writes a ping whose payload the estimator remembers. The client
+// echoes the payload back in its pong, which lets us measure the round-trip
+// time.
 func (s *server) writePing() error {
+	now := time.Now()
 	return s.ws.WriteControl(
 		websocket.PingMessage,
-		nil,
-		time.Now().Add(controlMessageTimeout))
+		s.rtt.Ping(now),
+		now.Add(controlMessageTimeout))
+}
+
+// observePong measures the round-trip time of the last ping. Pongs that don't
+// answer it are ignored, so that clients cannot make up their own latency.
+func (s *server) observePong(data string) {
+	s.rtt.Pong([]byte(data), time.Now())
 }
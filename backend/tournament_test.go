@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"oss.acmcsuf.com/qg/backend/internal/hc"
+	"oss.acmcsuf.com/qg/backend/internal/west"
+	"oss.acmcsuf.com/qg/backend/qg"
+	"oss.acmcsuf.com/qg/backend/qg/stores/sqlite"
+)
+
+func TestTournament(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatal("failed to open SQLite DB:", err)
+	}
+
+	handler := newHandler(ctx, store, store)
+	t.Cleanup(func() { handler.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := hc.NewClient(srv.URL, srv.Client())
+	client.Timeout = 2 * time.Second
+
+	matchData := qg.QuizGameData{
+		Questions: []qg.QuizQuestion{
+			{Value: qg.QuizQuestionMultipleChoice{
+				Question: "Which language has goroutines?",
+				Choices:  []string{"Rust", "Go"},
+				Answer:   1,
+			}},
+		},
+	}
+
+	r, err := hc.POST[qg.ResponseNewTournament](ctx, client, "/tournament",
+		qg.RequestNewTournament{
+			AdminPassword: "admin",
+			Data: qg.TournamentData{
+				Name:     "Finals",
+				Entrants: []qg.PlayerName{"Alice", "Bob", "Carol"},
+				Games: []qg.GameData{
+					{Value: qg.GameDataQuiz{Data: matchData}},
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to create tournament:", err)
+	}
+
+	id := r.Bracket.TournamentID
+	brackets := streamTournament(ctx, t, srv, id)
+
+	// Alice is the top seed and gets a bye into the final.
+	bracket := <-brackets
+	assert.Equal(t, r.Bracket, bracket)
+	assert.Equal(t, 2, len(bracket.Rounds))
+	assert.Equal(t, "Alice", *bracket.Rounds[0][0].Winner)
+	assert.Equal(t, qg.TournamentMatchStatePlaying, bracket.Rounds[0][1].State)
+	assert.Equal(t, []qg.PlayerName{"Bob", "Carol"}, bracket.Rounds[0][1].Entrants)
+	assert.Equal(t, []qg.PlayerName{"Alice"}, bracket.Rounds[1][0].Entrants)
+
+	t.Run("semifinal", func(t *testing.T) {
+		playQuizMatch(ctx, t, srv, *bracket.Rounds[0][1].GameID, "Carol", "Bob")
+
+		bracket = <-brackets
+		assert.Equal(t, qg.TournamentMatchStateDone, bracket.Rounds[0][1].State)
+		assert.Equal(t, "Carol", *bracket.Rounds[0][1].Winner)
+
+		final := bracket.Rounds[1][0]
+		assert.Equal(t, qg.TournamentMatchStatePlaying, final.State)
+		assert.Equal(t, []qg.PlayerName{"Alice", "Carol"}, final.Entrants)
+	})
+
+	t.Run("final", func(t *testing.T) {
+		playQuizMatch(ctx, t, srv, *bracket.Rounds[1][0].GameID, "Alice", "Carol")
+
+		bracket = <-brackets
+		assert.Equal(t, "Alice", *bracket.Winner)
+
+		got, err := hc.GET[qg.ResponseGetTournament](ctx, client, "/tournament/"+id, nil)
+		if err != nil {
+			t.Fatal("failed to get tournament:", err)
+		}
+		assert.Equal(t, bracket, got.Bracket)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := hc.GET[qg.ResponseGetTournament](ctx, client, "/tournament/nope", nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+}
+
+// playQuizMatch plays a one-question quiz match that the winner wins.
+func playQuizMatch(ctx context.Context, t *testing.T, srv *httptest.Server, gameID qg.GameID, winner, loser qg.PlayerName) {
+	t.Helper()
+
+	admin := startTestWebsocket(ctx, t, srv, "admin")
+	sendCommand(ctx, t, admin, qg.CommandJoinGame{
+		GameID:        gameID,
+		PlayerName:    "Host",
+		AdminPassword: p("admin"),
+	})
+	expectEvent[qg.EventJoinedGame](ctx, t, admin)
+
+	players := []struct {
+		name   qg.PlayerName
+		choice int32
+	}{
+		{winner, 1},
+		{loser, 0},
+	}
+
+	conns := make(map[qg.PlayerName]*west.WebsocketTest, len(players))
+	for _, player := range players {
+		ws := startTestWebsocket(ctx, t, srv, player.name)
+		sendCommand(ctx, t, ws, qg.CommandJoinGame{
+			GameID:     gameID,
+			PlayerName: player.name,
+		})
+		expectEvent[qg.EventJoinedGame](ctx, t, ws)
+		conns[player.name] = ws
+	}
+
+	sendCommand(ctx, t, admin, qg.CommandBeginGame{})
+
+	for _, player := range players {
+		ws := conns[player.name]
+		expectEvent[qg.EventQuizBeginQuestion](ctx, t, ws)
+
+		sendCommand(ctx, t, ws, qg.CommandQuizAnswer{
+			Answer: qg.QuizAnswer{Value: qg.QuizAnswerChoice{Choice: player.choice}},
+		})
+		expectEvent[qg.EventQuizPlayerAnswered](ctx, t, ws)
+	}
+
+	expectEvent[qg.EventQuizReveal](ctx, t, admin)
+	sendCommand(ctx, t, admin, qg.CommandQuizNextQuestion{})
+	expectEvent[qg.EventGameEnded](ctx, t, admin)
+}
+
+// streamTournament reads the event stream of a tournament.
+func streamTournament(ctx context.Context, t *testing.T, srv *httptest.Server, id qg.TournamentID) <-chan qg.TournamentBracket {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/tournament/"+id+"/events", nil)
+	must(t, err)
+
+	resp, err := srv.Client().Do(req)
+	must(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	brackets := make(chan qg.TournamentBracket, 10)
+	go func() {
+		defer close(brackets)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+
+			var bracket qg.TournamentBracket
+			if err := json.Unmarshal([]byte(data), &bracket); err != nil {
+				t.Error("invalid bracket:", err)
+				return
+			}
+
+			brackets <- bracket
+		}
+	}()
+
+	return brackets
+}
@@ -2,19 +2,116 @@
 
 export type Qg = any;
 
+/**
+ * BuzzerBuzz is a single button press within a buzz round.
+ */
+export interface BuzzerBuzz {
+  /**
+   * latency is the estimated one-way latency of the player in
+   * milliseconds that was compensated for. It is 0 if the game does not
+   * compensate for latency.
+   */
+  latency: number;
+  playerName: PlayerName;
+}
+
+/**
+ * BuzzerGameData is the game data for a buzzer game. A buzzer game has no
+ * questions: the host asks them on their own, opens a buzz round for each
+ * one, and awards points by hand.
+ */
+export interface BuzzerGameData {
+  /**
+   * compensate_latency orders buzzes by their estimated send time,
+   * which is the arrival time minus half of the player's round-trip
+   * time. If false, buzzes are ordered by their arrival time. The
+   * default is false.
+   */
+  compensate_latency?: boolean;
+
+  /**
+   * early_buzz_penalty is how long a player is locked out of their
+   * buzzer if they press it while no round is open. The format is in
+   * Go's time.Duration. The default is 250ms.
+   */
+  early_buzz_penalty?: string;
+}
+
+export interface BuzzerGameInfo {
+  compensateLatency: boolean;
+}
+
 export type Command =
   | CommandBeginGame
+  | CommandBuzzerAwardPoints
+  | CommandBuzzerCloseRound
+  | CommandBuzzerOpenRound
+  | CommandBuzzerPress
   | CommandEndGame
+  | CommandFeudNextRound
+  | CommandFeudPressButton
+  | CommandFeudRevealAnswer
+  | CommandFeudStrike
+  | CommandJeopardyArmBuzzers
   | CommandJeopardyChooseQuestion
   | CommandJeopardyPlayerJudgment
   | CommandJeopardyPressButton
-  | CommandJoinGame;
+  | CommandJeopardySubmitAnswer
+  | CommandJoinGame
+  | CommandPauseGame
+  | CommandPollNextQuestion
+  | CommandPollVote
+  | CommandPracticeGame
+  | CommandQuizAnswer
+  | CommandQuizNextQuestion
+  | CommandRequestSnapshot
+  | CommandResumeGame;
 
 /**
  * CommandBeginGame is sent by a client to begin a game.
  */
 export interface CommandBeginGame {
   type: "BeginGame";
+  id?: string;
+}
+
+/**
+ * CommandBuzzerAwardPoints is sent by a game admin to award points to a
+ * player at any time. points may be negative to take points away.
+ */
+export interface CommandBuzzerAwardPoints {
+  type: "BuzzerAwardPoints";
+  playerName: PlayerName;
+  points: number;
+  id?: string;
+}
+
+/**
+ * CommandBuzzerCloseRound is sent by a game admin to close the current
+ * buzz round.
+ */
+export interface CommandBuzzerCloseRound {
+  type: "BuzzerCloseRound";
+  id?: string;
+}
+
+/**
+ * CommandBuzzerOpenRound is sent by a game admin to open a new buzz round.
+ * Only one round can be open at a time.
+ */
+export interface CommandBuzzerOpenRound {
+  type: "BuzzerOpenRound";
+  id?: string;
+}
+
+/**
+ * CommandBuzzerPress is sent by a player to buzz in. Each player can only
+ * buzz once per round. Pressing while no round is open locks the player
+ * out for a short while.
+ */
+export interface CommandBuzzerPress {
+  type: "BuzzerPress";
+  id?: string;
 }
 
 /**
@@ -31,6 +128,54 @@ export interface CommandEndGame {
    * false, the game will be ended abruptly.
    */
   declareWinner: boolean;
+  id?: string;
+}
+
+/**
+ * CommandFeudNextRound is sent by a game admin to begin the next round
+ * once the current one has ended.
+ */
+export interface CommandFeudNextRound {
+  type: "FeudNextRound";
+  id?: string;
+}
+
+/**
+ * CommandFeudPressButton is sent by a face-off player to buzz in.
+ */
+export interface CommandFeudPressButton {
+  type: "FeudPressButton";
+  id?: string;
+}
+
+/**
+ * CommandFeudRevealAnswer is sent by a game admin when the answering
+ * player gave one of the answers on the board. answer is its index.
+ */
+export interface CommandFeudRevealAnswer {
+  type: "FeudRevealAnswer";
+  answer: number;
+  id?: string;
+}
+
+/**
+ * CommandFeudStrike is sent by a game admin when the answering player
+ * gave an answer that is not on the board.
+ */
+export interface CommandFeudStrike {
+  type: "FeudStrike";
+  id?: string;
+}
+
+/**
+ * CommandJeopardyArmBuzzers is sent by a game admin once they have finished
+ * reading the current question. Until then, the question is in its reading
+ * phase, and any player that presses their button is locked out for a
+ * short penalty.
+ */
+export interface CommandJeopardyArmBuzzers {
+  type: "JeopardyArmBuzzers";
+  id?: string;
 }
 
 /**
@@ -42,6 +187,7 @@ export interface CommandJeopardyChooseQuestion {
   type: "JeopardyChooseQuestion";
   category: number;
   question: number;
+  id?: string;
 }
 
 /**
@@ -51,25 +197,47 @@ export interface CommandJeopardyChooseQuestion {
  * will instantly receive the points for the question, and the game will let
  * them choose the next category and question. If the player answered wrong,
  * then the game will let others press the button.
+ *
+ * In practice games, the player sends it themselves after an
+ * EventJeopardySelfJudge.
  */
 export interface CommandJeopardyPlayerJudgment {
   type: "JeopardyPlayerJudgment";
   correct: boolean;
+  id?: string;
 }
 
 /**
  * CommandJeopardyPressButton is emitted when a player presses the button
  * during a question. It is only valid to emit this command when the game is
- * in the question state.
+ * in the question state. Pressing the button before the buzzers are armed
+ * locks the player out for a short penalty.
  */
 export interface CommandJeopardyPressButton {
   type: "JeopardyPressButton";
+  id?: string;
+}
+
+/**
+ * CommandJeopardySubmitAnswer is sent by the player of a practice game to
+ * answer the current question after pressing their button. The host
+ * judges the answer against the accepted answers of the question and
+ * replies with an EventJeopardyAnswerJudged. If the question has no
+ * accepted answers, the host replies with an EventJeopardySelfJudge
+ * instead.
+ */
+export interface CommandJeopardySubmitAnswer {
+  type: "JeopardySubmitAnswer";
+  answer: string;
+  id?: string;
 }
 
 /**
  * CommandJoinGame is sent by a client to join a game. The client (or the
  * user) supplies a game ID and a player name. The server will respond with
- * an EventJoinedGame.
+ * an EventJoinedGame followed by an EventGameSnapshot. Players may join at
+ * any time, even once the game has begun, and the snapshot catches them
+ * up.
  */
 export interface CommandJoinGame {
   type: "JoinGame";
@@ -88,6 +256,120 @@ export interface CommandJoinGame {
    * playerName is the wanted name of the user.
    */
   playerName: PlayerName;
+
+  /**
+   * rejoinToken is the rejoinToken of an earlier EventJoinedGame. If
+   * it matches, the player takes back their place in the game under
+   * the same name instead of joining as a new player.
+   */
+  rejoinToken: string | null;
+  id?: string;
+}
+
+/**
+ * CommandPauseGame is sent by a game admin to pause the game. The server
+ * will respond with an EventGamePaused.
+ */
+export interface CommandPauseGame {
+  type: "PauseGame";
+  id?: string;
+}
+
+/**
+ * CommandPollNextQuestion is sent by a game admin to close the current
+ * question and push the next one. Once there are no questions left, the
+ * poll ends.
+ */
+export interface CommandPollNextQuestion {
+  type: "PollNextQuestion";
+  id?: string;
+}
+
+/**
+ * CommandPollVote is sent by a player to vote on the current question.
+ * choices are the indices of the chosen choices. Voting again replaces the
+ * previous vote.
+ */
+export interface CommandPollVote {
+  type: "PollVote";
+  choices: number[];
+  id?: string;
+}
+
+/**
+ * CommandPracticeGame is sent by a client instead of CommandJoinGame to
+ * play a saved game alone. The server creates a private copy of the game
+ * that is hosted by a bot: it begins the game right away, judges answers
+ * using the stored answers and moves on using timers. The server will
+ * respond with an EventJoinedGame, and the score of the player is kept
+ * as their personal best once the game ends. Only Jeopardy games and
+ * quizzes can be practiced.
+ */
+export interface CommandPracticeGame {
+  type: "PracticeGame";
+
+  /**
+   * gameID is the ID of the game to practice.
+   */
+  gameID: GameId;
+
+  /**
+   * playerName is the name of the user.
+   */
+  playerName: PlayerName;
+  id?: string;
+}
+
+/**
+ * CommandQuizAnswer is sent by a player to answer the current question.
+ * Each player can only answer once per question.
+ */
+export interface CommandQuizAnswer {
+  type: "QuizAnswer";
+  answer: QuizAnswer;
+  id?: string;
+}
+
+/**
+ * CommandQuizNextQuestion is sent by a game admin to move on to the next
+ * question once the current one is revealed. Once there are no questions
+ * left, the quiz ends. In practice games, the host moves on by itself
+ * after a short while, but the player may also send this to move on
+ * right away.
+ */
+export interface CommandQuizNextQuestion {
+  type: "QuizNextQuestion";
+  id?: string;
+}
+
+/**
+ * CommandRequestSnapshot is sent by a client to get the current state of
+ * its game. The server will respond with an EventGameSnapshot.
+ */
+export interface CommandRequestSnapshot {
+  type: "RequestSnapshot";
+  id?: string;
+}
+
+/**
+ * CommandResumeGame is sent by a game admin to resume a paused game. The
+ * server will respond with an EventGameResumed.
+ */
+export interface CommandResumeGame {
+  type: "ResumeGame";
+  id?: string;
+}
+
+/**
+ * DebugReactor is a reactor of a state machine. hook is one of leave,
+ * transition, enter and react. from and to are the states that it reacts
+ * to leaving and entering, either of which may be any. A react reactor
+ * runs once the state from is entered and it offers the state to.
+ */
+export interface DebugReactor {
+  from: string;
+  hook: string;
+  to: string;
 }
 
 /**
@@ -98,22 +380,255 @@ export interface Error {
    * Message is the error message
    */
   message: string;
+
+  /**
+   * Code tells what kind of error this is, if it is known
+   */
+  code?: ErrorCode;
+
+  /**
+   * Details are the individual problems that make up the error, if any
+   */
+  details?: ErrorDetail[];
+}
+
+/**
+ * ErrorCode is a stable code for an error. Unlike the message, clients may
+ * match on it. HTTP responses always have a code, which also decides the
+ * status of the response.
+ *
+ * - invalid_request is when the request itself is wrong, such as game
+ *   data that does not make sense (400).
+ * - unauthorized is when the player may never do this, such as a player
+ *   who is not an admin pausing the game or a wrong admin password (403).
+ * - not_found is when the thing that was asked for does not exist (404).
+ * - conflict is when the thing that was created already exists (409).
+ * - invalid_state is when the command cannot be sent at this point of
+ *   the game, such as answering before a question is asked (409).
+ * - not_your_turn is when the command is only for another player right
+ *   now, such as choosing a question when someone else is the chooser
+ *   (409).
+ * - slow_subscriber is when the connection fell too far behind on its
+ *   events and is about to be closed. The client may join again with its
+ *   rejoin token (503).
+ * - internal is when the server failed on its own (500).
+ */
+export enum ErrorCode {
+  InvalidRequest = "invalid_request",
+  Unauthorized = "unauthorized",
+  NotFound = "not_found",
+  Conflict = "conflict",
+  InvalidState = "invalid_state",
+  NotYourTurn = "not_your_turn",
+  SlowSubscriber = "slow_subscriber",
+  Internal = "internal",
+}
+
+/**
+ * ErrorDetail is one of the problems that make up an Error, such as one
+ * of the many mistakes in an uploaded Jeopardy board. message explains
+ * the problem. path is a JSON Pointer to the value that the problem is
+ * in, if it is about one. schema_path is a JSON Pointer to the part of
+ * the schema that the value does not match, if the problem was found by
+ * checking the value against the schema.
+ */
+export interface ErrorDetail {
+  message: string;
+  path?: string;
+  schema_path?: string;
 }
 
 export type Event =
+  | EventAck
+  | EventBuzzerBuzzes
+  | EventBuzzerLockedOut
+  | EventBuzzerPointsAwarded
+  | EventBuzzerRoundClosed
+  | EventBuzzerRoundOpened
   | EventError
+  | EventFeudAnswerKey
+  | EventFeudAnswerRevealed
+  | EventFeudBeginRound
+  | EventFeudButtonPressed
+  | EventFeudRoundEnded
+  | EventFeudStrike
+  | EventFeudTurn
   | EventGameEnded
+  | EventGamePaused
+  | EventGameResumed
+  | EventGameSnapshot
   | EventGameStarted
+  | EventJeopardyAnswerJudged
   | EventJeopardyBeginQuestion
   | EventJeopardyButtonPressed
+  | EventJeopardyBuzzerLockedOut
+  | EventJeopardyBuzzersArmed
   | EventJeopardyResumeButton
+  | EventJeopardySelfJudge
   | EventJeopardyTurnEnded
   | EventJoinedGame
-  | EventPlayerJoined;
+  | EventPlayerJoined
+  | EventPollBeginQuestion
+  | EventPollResults
+  | EventPracticeEnded
+  | EventQuizBeginQuestion
+  | EventQuizEliminated
+  | EventQuizPlayerAnswered
+  | EventQuizReveal;
+
+/**
+ * EventAck is emitted once a command with an ID has been handled without
+ * error. id is the ID of the command. Events that the command caused may
+ * arrive before or after it.
+ */
+export interface EventAck {
+  type: "Ack";
+  id: string;
+}
 
+/**
+ * EventBuzzerBuzzes is emitted whenever a player buzzes in. buzzes lists
+ * every buzz of the round so far in order, starting with the earliest.
+ */
+export interface EventBuzzerBuzzes {
+  type: "BuzzerBuzzes";
+  buzzes: BuzzerBuzz[];
+  round: number;
+}
+
+/**
+ * EventBuzzerLockedOut is emitted when a player presses their button while
+ * no round is open. The player's buzzer is locked out for penalty
+ * milliseconds.
+ */
+export interface EventBuzzerLockedOut {
+  type: "BuzzerLockedOut";
+  penalty: number;
+  playerName: PlayerName;
+}
+
+/**
+ * EventBuzzerPointsAwarded is emitted when the admin awards points to a
+ * player.
+ */
+export interface EventBuzzerPointsAwarded {
+  type: "BuzzerPointsAwarded";
+  leaderboard: Leaderboard;
+  playerName: PlayerName;
+  points: number;
+}
+
+/**
+ * EventBuzzerRoundClosed is emitted when the admin closes the current buzz
+ * round. buzzes is the final order of the buzzes of the round.
+ */
+export interface EventBuzzerRoundClosed {
+  type: "BuzzerRoundClosed";
+  buzzes: BuzzerBuzz[];
+  round: number;
+}
+
+/**
+ * EventBuzzerRoundOpened is emitted when the admin opens a buzz round.
+ * Players can buzz in until the round is closed.
+ */
+export interface EventBuzzerRoundOpened {
+  type: "BuzzerRoundOpened";
+  round: number;
+}
+
+/**
+ * EventError is emitted when a command fails. id is the ID of the
+ * command, if the client gave it one.
+ */
 export interface EventError {
   type: "Error";
   error: Error;
+  id?: string;
+}
+
+/**
+ * EventFeudAnswerKey is emitted only to admins when a round begins. It
+ * holds every answer on the board, so that admins can judge the answers
+ * of the players before they are revealed.
+ */
+export interface EventFeudAnswerKey {
+  type: "FeudAnswerKey";
+  answers: FeudAnswer[];
+  round: number;
+}
+
+/**
+ * EventFeudAnswerRevealed is emitted when an answer on the board is
+ * revealed. bank is the total points of the answers revealed so far this
+ * round, which the winner of the round gets.
+ */
+export interface EventFeudAnswerRevealed {
+  type: "FeudAnswerRevealed";
+  answer: number;
+  bank: number;
+  points: number;
+  team: number;
+  text: string;
+}
+
+/**
+ * EventFeudBeginRound is emitted when a round begins, including the first
+ * one once the game starts. The round begins with a face-off between the
+ * two faceOff players, one from each team.
+ */
+export interface EventFeudBeginRound {
+  type: "FeudBeginRound";
+  faceOff: PlayerName[];
+  numAnswers: number;
+  question: string;
+  round: number;
+  teams: FeudTeam[];
+}
+
+/**
+ * EventFeudButtonPressed is emitted when a face-off player presses their
+ * button first. That player gets to answer first.
+ */
+export interface EventFeudButtonPressed {
+  type: "FeudButtonPressed";
+  playerName: PlayerName;
+  team: number;
+}
+
+/**
+ * EventFeudRoundEnded is emitted when a round ends. The winner team gets
+ * the points in the bank. All answers of the round are revealed.
+ */
+export interface EventFeudRoundEnded {
+  type: "FeudRoundEnded";
+  answers: FeudAnswer[];
+  points: number;
+  teams: FeudTeam[];
+  winner: number;
+}
+
+/**
+ * EventFeudStrike is emitted when a team answers wrong. strikes only
+ * counts up in the play phase.
+ */
+export interface EventFeudStrike {
+  type: "FeudStrike";
+  strikes: number;
+  team: number;
+}
+
+/**
+ * EventFeudTurn is emitted whenever the game waits for a player to answer.
+ * The admin then judges the answer using CommandFeudRevealAnswer or
+ * CommandFeudStrike. In the steal phase, playerName answers on behalf of
+ * their team.
+ */
+export interface EventFeudTurn {
+  type: "FeudTurn";
+  phase: FeudPhase;
+  playerName: PlayerName;
+  team: number;
 }
 
 /**
@@ -124,6 +639,51 @@ export interface EventGameEnded {
   leaderboard: Leaderboard;
 }
 
+/**
+ * EventGamePaused is emitted when an admin pauses the game. While the game
+ * is paused, only admins may send commands other than CommandJoinGame,
+ * and all of the game's timers are frozen.
+ */
+export interface EventGamePaused {
+  type: "GamePaused";
+
+  /**
+   * playerName is the name of the admin who paused the game.
+   */
+  playerName: PlayerName;
+}
+
+/**
+ * EventGameResumed is emitted when an admin resumes a paused game.
+ */
+export interface EventGameResumed {
+  type: "GameResumed";
+
+  /**
+   * playerName is the name of the admin who resumed the game.
+   */
+  playerName: PlayerName;
+}
+
+/**
+ * EventGameSnapshot is emitted to a player once they join, to everyone
+ * once the game is resumed, and to a player who sends a
+ * CommandRequestSnapshot. It describes the whole current state of the
+ * game, so that players who join late or reconnect can catch up without
+ * waiting for the next turn. players are the players who are not admins
+ * in the order that they joined. game holds the state that is specific
+ * to the game type, if the game type has any.
+ */
+export interface EventGameSnapshot {
+  type: "GameSnapshot";
+  ended: boolean;
+  game: GameSnapshot | null;
+  leaderboard: Leaderboard;
+  paused: boolean;
+  players: PlayerName[];
+  started: boolean;
+}
+
 /**
  * EventGameStarted is emitted when the game starts. It contains no data and
  * is only meant to be used to trigger the client to start the game.
@@ -132,19 +692,34 @@ export interface EventGameStarted {
   type: "GameStarted";
 }
 
+/**
+ * EventJeopardyAnswerJudged is emitted in practice games once the host has
+ * judged the answer of the player, or once the player ran out of time, in
+ * which case answer is empty. answers are the accepted answers to the
+ * question.
+ */
+export interface EventJeopardyAnswerJudged {
+  type: "JeopardyAnswerJudged";
+  answer: string;
+  answers: string[];
+  correct: boolean;
+}
+
 /**
  * EventJeopardyBeginQuestion is emitted when a question begins within this
  * Jeopardy game. It is usually emitted once the chooser player has chosen a
- * category and value.
+ * category and value. The question starts in its reading phase, which
+ * lasts until an admin sends a CommandJeopardyArmBuzzers.
  *
  * Each category name and question value will map to a category and question
  * within the game data. Note that a question may repeat across multiple
- * categories.
+ * categories. content holds the content blocks of the question, if any.
  */
 export interface EventJeopardyBeginQuestion {
   type: "JeopardyBeginQuestion";
   category: number;
   chooser: PlayerName;
+  content: QuestionContent[];
   points: number;
   question: string;
 }
@@ -153,12 +728,41 @@ export interface EventJeopardyBeginQuestion {
  * EventJeopardyButtonPressed is emitted when any player had pressed a button
  * on their device, voiding other players' buttons. This event is only
  * emitted when the game is in the "question" state.
+ *
+ * If the game uses a fair buzzer, buzzes contains every button press
+ * collected within the buzz window in compensated order, and playerName
+ * is the first of them. Otherwise, buzzes only contains playerName.
  */
 export interface EventJeopardyButtonPressed {
   type: "JeopardyButtonPressed";
+  buzzes: JeopardyBuzz[];
+  playerName: PlayerName;
+}
+
+/**
+ * EventJeopardyBuzzerLockedOut is emitted when a player presses their
+ * button during the reading phase, before the buzzers are armed. The
+ * player cannot press their button again until the penalty has passed.
+ */
+export interface EventJeopardyBuzzerLockedOut {
+  type: "JeopardyBuzzerLockedOut";
+
+  /**
+   * penalty is the lockout duration in milliseconds.
+   */
+  penalty: number;
   playerName: PlayerName;
 }
 
+/**
+ * EventJeopardyBuzzersArmed is emitted when the admin arms the buzzers,
+ * ending the reading phase of the current question. Players may press
+ * their button from now on.
+ */
+export interface EventJeopardyBuzzersArmed {
+  type: "JeopardyBuzzersArmed";
+}
+
 /**
  * EventJeopardyResumeButton is emitted when the player can now continue to
  * press the button whenever they are ready to answer the question. This
@@ -173,6 +777,20 @@ export interface EventJeopardyResumeButton {
   alreadyAnsweredPlayers: PlayerName[];
 }
 
+/**
+ * EventJeopardySelfJudge is emitted in practice games once the player has
+ * answered a question that has no accepted answers, such as one from a
+ * set that was saved before answers could be stored. The host cannot
+ * judge it, so the player judges their own answer by sending a
+ * CommandJeopardyPlayerJudgment before they run out of time. A correct
+ * answer uses up the question but scores no points, so that personal
+ * bests only count answers that the host could check.
+ */
+export interface EventJeopardySelfJudge {
+  type: "JeopardySelfJudge";
+  answer: string;
+}
+
 /**
  * EventJeopardyTurnEnded is emitted when a turn ends or when the game first
  * starts.
@@ -189,6 +807,11 @@ export interface EventJeopardyTurnEnded {
  * reply to CommandJoinGame and is only for the current player. Not to be
  * confused with EventPlayerJoinedGame, which is emitted when any player
  * joins the current game.
+ *
+ * rejoinToken lets the player take their place back if they lose their
+ * connection, such as when the server closes a connection that fell too
+ * far behind. The client keeps it secret and sends it along with the same
+ * player name in the CommandJoinGame of its new connection.
  */
 export interface EventJoinedGame {
   type: "JoinedGame";
@@ -196,6 +819,7 @@ export interface EventJoinedGame {
   gameID: string;
   gameInfo: GameInfo;
   isAdmin: boolean;
+  rejoinToken: string;
 }
 
 /**
@@ -207,36 +831,265 @@ export interface EventPlayerJoined {
 }
 
 /**
- * GameData is the game data. It contains all the information about the game.
+ * EventPollBeginQuestion is emitted when the admin pushes a new question.
+ * Voting on the previous question is closed.
  */
-export type GameData = GameDataJeopardy | GameDataKahoot;
-
-export interface GameDataJeopardy {
-  game: "jeopardy";
-  data: JeopardyGameData;
+export interface EventPollBeginQuestion {
+  type: "PollBeginQuestion";
+  choices: string[];
+  index: number;
+  multiple: boolean;
+  question: string;
 }
 
-export interface GameDataKahoot {
-  game: "kahoot";
-  data: KahootGameData;
+/**
+ * EventPollResults is emitted whenever a player votes. It contains the
+ * aggregated results of the current question so far.
+ */
+export interface EventPollResults {
+  type: "PollResults";
+  index: number;
+  result: PollResult;
 }
 
 /**
- * GameID is the unique identifier for a game. Each player must type this
- * code to join the game.
+ * EventPracticeEnded is emitted after EventGameEnded once a practice game
+ * ends. score is the final score of the player, and personalBest is their
+ * best score in this game, including this one. newPersonalBest is true if
+ * this score beat every previous one.
  */
-export type GameId = string;
-
-export type GameInfo = GameInfoJeopardy;
+export interface EventPracticeEnded {
+  type: "PracticeEnded";
+  newPersonalBest: boolean;
+  personalBest: number;
+  score: number;
+}
 
-export interface GameInfoJeopardy {
-  type: "jeopardy";
-  data: JeopardyGameInfo;
+/**
+ * EventQuizBeginQuestion is emitted when a question begins. Players have
+ * timeLimit milliseconds to answer it. True or false questions have the
+ * choices "True" and "False", estimation questions have no choices, and
+ * the choices of ordering questions are their items in a random order.
+ * suddenDeath is true for the sudden death questions of a survival quiz.
+ */
+export interface EventQuizBeginQuestion {
+  type: "QuizBeginQuestion";
+  choices: string[];
+  index: number;
+  question: string;
+  questionType: QuizQuestionType;
+  suddenDeath: boolean;
+  timeLimit: number;
 }
 
-export enum GameType {
+/**
+ * EventQuizEliminated is emitted after each reveal of a survival quiz.
+ * eliminated lists the players who were eliminated by the question, and
+ * survivors lists the players who are still in the game.
+ */
+export interface EventQuizEliminated {
+  type: "QuizEliminated";
+  eliminated: PlayerName[];
+  index: number;
+  survivors: PlayerName[];
+}
+
+/**
+ * EventQuizPlayerAnswered is emitted when a player answers the current
+ * question. The answer itself is not revealed until the question ends.
+ */
+export interface EventQuizPlayerAnswered {
+  type: "QuizPlayerAnswered";
+  playerName: PlayerName;
+}
+
+/**
+ * EventQuizReveal is emitted once everyone has answered the current
+ * question or its time is up. correct lists the players who got the
+ * question right, or the closest guesses for an estimation question.
+ */
+export interface EventQuizReveal {
+  type: "QuizReveal";
+  correct: PlayerName[];
+  index: number;
+  leaderboard: Leaderboard;
+  result: QuizResult;
+}
+
+export interface FeudAnswer {
+  answer: string;
+  points: number;
+}
+
+/**
+ * FeudGameData is the game data for a Family Feud game. Two teams play
+ * one round per question.
+ */
+export interface FeudGameData {
+  questions: FeudQuestion[];
+
+  /**
+   * max_strikes is the number of strikes that the team in control may
+   * get before the other team gets to steal. The default is 3.
+   */
+  max_strikes?: number;
+
+  /**
+   * team_names are the names of the two teams. The default is
+   * "Team 1" and "Team 2".
+   */
+  team_names?: string[];
+}
+
+export interface FeudGameInfo {
+  numQuestions: number;
+  teamNames: string[];
+}
+
+/**
+ * FeudPhase is the phase of a Family Feud round.
+ *
+ * - face_off is when one player of each team competes for control of
+ *   the round.
+ * - play is when the team in control guesses the remaining answers.
+ * - steal is when the other team gets one guess to steal the points
+ *   after the team in control has struck out.
+ */
+export enum FeudPhase {
+  FaceOff = "face_off",
+  Play = "play",
+  Steal = "steal",
+}
+
+/**
+ * FeudQuestion is a survey question along with its surveyed answers.
+ */
+export interface FeudQuestion {
+  /**
+   * answers are the surveyed answers, ranked from the most popular to
+   * the least.
+   */
+  answers: FeudAnswer[];
+  question: string;
+}
+
+export interface FeudTeam {
+  name: string;
+  players: PlayerName[];
+  score: number;
+}
+
+/**
+ * GameData is the game data. It contains all the information about the game.
+ */
+export type GameData =
+  | GameDataBuzzer
+  | GameDataFeud
+  | GameDataJeopardy
+  | GameDataKahoot
+  | GameDataPoll
+  | GameDataQuiz;
+
+export interface GameDataBuzzer {
+  game: "buzzer";
+  data: BuzzerGameData;
+}
+
+export interface GameDataFeud {
+  game: "feud";
+  data: FeudGameData;
+}
+
+export interface GameDataJeopardy {
+  game: "jeopardy";
+  data: JeopardyGameData;
+}
+
+export interface GameDataKahoot {
+  game: "kahoot";
+  data: KahootGameData;
+}
+
+export interface GameDataPoll {
+  game: "poll";
+  data: PollGameData;
+}
+
+export interface GameDataQuiz {
+  game: "quiz";
+  data: QuizGameData;
+}
+
+/**
+ * GameID is the unique identifier for a game. Each player must type this
+ * code to join the game.
+ */
+export type GameId = string;
+
+export type GameInfo =
+  | GameInfoBuzzer
+  | GameInfoFeud
+  | GameInfoJeopardy
+  | GameInfoPoll
+  | GameInfoQuiz;
+
+export interface GameInfoBuzzer {
+  type: "buzzer";
+  data: BuzzerGameInfo;
+}
+
+export interface GameInfoFeud {
+  type: "feud";
+  data: FeudGameInfo;
+}
+
+export interface GameInfoJeopardy {
+  type: "jeopardy";
+  data: JeopardyGameInfo;
+}
+
+export interface GameInfoPoll {
+  type: "poll";
+  data: PollGameInfo;
+}
+
+export interface GameInfoQuiz {
+  type: "quiz";
+  data: QuizGameInfo;
+}
+
+/**
+ * GameSchedule describes when the lobby of a scheduled game opens.
+ */
+export interface GameSchedule {
+  /**
+   * opensAt is the time that the lobby opens and players can start
+   * joining the game.
+   */
+  opensAt: string;
+
+  /**
+   * autoBegin begins the game on its own once this many players
+   * (excluding admins) have joined. If omitted, an admin has to begin
+   * the game.
+   */
+  autoBegin?: number;
+}
+
+export type GameSnapshot = GameSnapshotJeopardy;
+
+export interface GameSnapshotJeopardy {
+  type: "jeopardy";
+  data: JeopardySnapshot;
+}
+
+export enum GameType {
   Jeopardy = "jeopardy",
   Kahoot = "kahoot",
+  Feud = "feud",
+  Poll = "poll",
+  Quiz = "quiz",
+  Buzzer = "buzzer",
 }
 
 export interface JeopardyAnsweredQuestion {
@@ -247,9 +1100,24 @@ export interface JeopardyAnsweredQuestion {
 
 /**
  * JeopardyAnsweredQuestions is the list of answered questions for a player.
+ * In practice games, questions that were missed are also used up, and
+ * their player is empty.
  */
 export type JeopardyAnsweredQuestions = JeopardyAnsweredQuestion[];
 
+/**
+ * JeopardyBuzz is a single button press within a buzz window.
+ */
+export interface JeopardyBuzz {
+  /**
+   * latency is the estimated one-way latency of the player in
+   * milliseconds. It was subtracted from the arrival time of the button
+   * press when ordering the buzzes.
+   */
+  latency: number;
+  playerName: PlayerName;
+}
+
 /**
  * JeopardyCategory is a category in a Jeopardy game.
  */
@@ -265,12 +1133,84 @@ export interface JeopardyCategory {
   questions: JeopardyQuestion[];
 }
 
+/**
+ * JeopardyChooserPolicy is a policy for picking the player who chooses the
+ * next question. The starting chooser is picked using the same policy,
+ * with ties going to whoever joined first.
+ *
+ * - random picks a random player every turn.
+ * - last_correct picks the last player who answered correctly. The
+ *   chooser stays the same if nobody did.
+ * - round_robin cycles through the players in the order that they
+ *   joined.
+ * - lowest_score picks the player with the lowest score.
+ */
+export enum JeopardyChooserPolicy {
+  Random = "random",
+  LastCorrect = "last_correct",
+  RoundRobin = "round_robin",
+  LowestScore = "lowest_score",
+}
+
+/**
+ * JeopardyCurrentQuestion is the question that is being played. Its fields
+ * are the same as in EventJeopardyBeginQuestion, and index is the index of
+ * the question within its category.
+ */
+export interface JeopardyCurrentQuestion {
+  category: number;
+  content: QuestionContent[];
+  index: number;
+  points: number;
+  question: string;
+}
+
+/**
+ * JeopardyFairBuzzer configures latency-compensated buzzer ordering. Once
+ * the first button press arrives, the server keeps collecting presses for
+ * the duration of the window, then orders them by their estimated send
+ * time, which is the arrival time minus half of the player's round-trip
+ * time.
+ */
+export interface JeopardyFairBuzzer {
+  /**
+   * window is the collection window after the first button press. The
+   * format is in Go's time.Duration, e.g. 250ms.
+   */
+  window: string;
+}
+
 /**
  * JeopardyGameData is the game data for a Jeopardy game.
  */
 export interface JeopardyGameData {
   categories: JeopardyCategory[];
 
+  /**
+   * chooser_policy determines who chooses the next question once a
+   * turn ends. The default is last_correct.
+   */
+  chooser_policy?: JeopardyChooserPolicy;
+
+  /**
+   * chooser_seed seeds the random number generator of the random
+   * chooser policy. If omitted, a random seed is used.
+   */
+  chooser_seed?: number;
+
+  /**
+   * early_buzz_penalty is how long a player is locked out of their
+   * buzzer if they press it before the admin arms the buzzers. The
+   * format is in Go's time.Duration. The default is 250ms.
+   */
+  early_buzz_penalty?: string;
+
+  /**
+   * fair_buzzer enables latency-compensated buzzer ordering. If
+   * omitted, the first button press to arrive wins.
+   */
+  fair_buzzer?: JeopardyFairBuzzer;
+
   /**
    * score_multiplier is the score multiplier for each question. The
    * default is 100.
@@ -289,6 +1229,22 @@ export interface JeopardyGameInfo {
   scoreMultiplier: number;
 }
 
+/**
+ * JeopardyPhase is the phase of a Jeopardy turn.
+ *
+ * - choosing is when the chooser picks the next question.
+ * - reading is when an admin reads the question out loud. Players who
+ *   press their button now are locked out for a while.
+ * - buzzing is when players may press their button.
+ * - answering is when the player who pressed first answers.
+ */
+export enum JeopardyPhase {
+  Choosing = "choosing",
+  Reading = "reading",
+  Buzzing = "buzzing",
+  Answering = "answering",
+}
+
 /**
  * JeopardyQuestion is a question in a Jeopardy game.
  */
@@ -297,6 +1253,38 @@ export interface JeopardyQuestion {
    * question is the question.
    */
   question: string;
+
+  /**
+   * answers are the accepted answers to the question. They are used
+   * to judge practice games, where the host is a bot. In practice
+   * games, players judge their own answers to questions without
+   * any. Answers are compared ignoring case, punctuation, articles
+   * and a leading "what is" or "who is". They are never sent to
+   * players.
+   */
+  answers?: string[];
+
+  /**
+   * content are extra blocks that are shown along with the question,
+   * such as code snippets, images or audio clips.
+   */
+  content?: QuestionContent[];
+}
+
+/**
+ * JeopardySnapshot is the state of a Jeopardy game. The scores are in the
+ * leaderboard of the EventGameSnapshot. question is the current question,
+ * which is null while the chooser is choosing. answering is the player
+ * who is answering it, if any, and pressed are the players who have
+ * already pressed their button for it.
+ */
+export interface JeopardySnapshot {
+  answered: JeopardyAnsweredQuestions;
+  answering: PlayerName;
+  chooser: PlayerName;
+  phase: JeopardyPhase;
+  pressed: PlayerName[];
+  question: JeopardyCurrentQuestion | null;
 }
 
 /**
@@ -340,11 +1328,362 @@ export interface LeaderboardEntry {
   score: number;
 }
 
+/**
+ * Media describes an uploaded media blob. Its content is served at
+ * /media/{id}.
+ */
+export interface Media {
+  contentType: string;
+  id: MediaId;
+  size: number;
+}
+
+/**
+ * MediaID is the ID of an uploaded media blob. It is the hex-encoded
+ * SHA-256 hash of the blob, so uploading the same blob twice gives the
+ * same ID.
+ */
+export type MediaId = string;
+
 /**
  * PlayerName is the name of a player.
  */
 export type PlayerName = string;
 
+/**
+ * PollGameData is the game data for a poll. A poll is not scored: players
+ * vote on each question and everyone sees the results live.
+ */
+export interface PollGameData {
+  questions: PollQuestion[];
+}
+
+export interface PollGameInfo {
+  numQuestions: number;
+}
+
+export interface PollQuestion {
+  choices: string[];
+  question: string;
+
+  /**
+   * multiple allows players to vote for more than one choice. The
+   * default is false.
+   */
+  multiple?: boolean;
+}
+
+/**
+ * PollResult is the result of a poll question. votes holds the number of
+ * votes for each choice, and voters is the number of players who voted.
+ */
+export interface PollResult {
+  choices: string[];
+  question: string;
+  voters: number;
+  votes: number[];
+}
+
+/**
+ * QuestionContent is a block of content that is shown along with a
+ * question.
+ */
+export type QuestionContent =
+  | QuestionContentAudio
+  | QuestionContentCode
+  | QuestionContentImage
+  | QuestionContentText;
+
+/**
+ * QuestionContentAudio is an uploaded audio clip.
+ */
+export interface QuestionContentAudio {
+  type: "audio";
+  media: MediaId;
+}
+
+/**
+ * QuestionContentCode is a code snippet.
+ */
+export interface QuestionContentCode {
+  type: "code";
+  code: string;
+
+  /**
+   * language is a hint for syntax highlighting, such as go or
+   * python.
+   */
+  language?: string;
+}
+
+/**
+ * QuestionContentImage is an uploaded image.
+ */
+export interface QuestionContentImage {
+  type: "image";
+  media: MediaId;
+
+  /**
+   * alt describes the image for players who cannot see it. It
+   * should not give the answer away.
+   */
+  alt?: string;
+}
+
+/**
+ * QuestionContentText is a paragraph of plain text.
+ */
+export interface QuestionContentText {
+  type: "text";
+  text: string;
+}
+
+/**
+ * QuizAnswer is a player's answer to a quiz question. Its type must match
+ * the type of the question.
+ */
+export type QuizAnswer = QuizAnswerChoice | QuizAnswerNumber | QuizAnswerOrder;
+
+/**
+ * QuizAnswerChoice answers a multiple-choice or true or false
+ * question. choice is the index of the chosen choice.
+ */
+export interface QuizAnswerChoice {
+  type: "choice";
+  choice: number;
+}
+
+/**
+ * QuizAnswerNumber answers an estimation question.
+ */
+export interface QuizAnswerNumber {
+  type: "number";
+  number: number;
+}
+
+/**
+ * QuizAnswerOrder answers an ordering question. order lists the
+ * indices of the choices of the question from first to last, and it
+ * must contain every index exactly once.
+ */
+export interface QuizAnswerOrder {
+  type: "order";
+  order: number[];
+}
+
+export interface QuizEstimateGuess {
+  distance: number;
+  guess: number;
+  playerName: PlayerName;
+  points: number;
+}
+
+/**
+ * QuizEstimateScoring is how guesses to an estimation question are scored.
+ * The default is rank.
+ *
+ * - rank ranks the guesses by their distance to the answer. The closest
+ *   guesses get the full points, and each rank after that gets a smaller
+ *   share of them, down to 1/n of the points for the farthest of n ranks.
+ * - linear gives the full points to an exact guess, and the points drop
+ *   linearly with the distance until they reach 0 at the tolerance.
+ */
+export enum QuizEstimateScoring {
+  Rank = "rank",
+  Linear = "linear",
+}
+
+/**
+ * QuizGameData is the game data for a quiz. Everyone answers every
+ * question within the time limit, and correct answers are scored
+ * automatically.
+ */
+export interface QuizGameData {
+  questions: QuizQuestion[];
+
+  /**
+   * points is the number of points for each correct answer. The
+   * default is 100.
+   */
+  points?: number;
+
+  /**
+   * survival turns the quiz into a survival game. See QuizSurvival.
+   */
+  survival?: QuizSurvival;
+
+  /**
+   * time_limit is the time limit for each question. The format is in
+   * Go's time.Duration, e.g. 10s for 10 seconds. The default is 20s.
+   */
+  time_limit?: string;
+}
+
+export interface QuizGameInfo {
+  numQuestions: number;
+  survival: boolean;
+}
+
+/**
+ * QuizOrderingGuess is a player's answer to an ordering question. distance
+ * is the Kendall tau distance to the right order, which is the number of
+ * pairs of items that the player put in the wrong order.
+ */
+export interface QuizOrderingGuess {
+  distance: number;
+  order: number[];
+  playerName: PlayerName;
+  points: number;
+}
+
+/**
+ * QuizQuestion is a question in a quiz.
+ */
+export type QuizQuestion =
+  | QuizQuestionEstimate
+  | QuizQuestionMultipleChoice
+  | QuizQuestionOrdering
+  | QuizQuestionTrueFalse;
+
+/**
+ * QuizQuestionEstimate is a question with a numeric answer that
+ * players have to estimate. The closer a guess is to answer, the more
+ * points it gets.
+ */
+export interface QuizQuestionEstimate {
+  type: "estimate";
+  answer: number;
+  question: string;
+  scoring?: QuizEstimateScoring;
+
+  /**
+   * tolerance is the distance from the answer at which a guess
+   * stops getting any points when scoring is linear. The default
+   * is the answer itself, so a guess of 0 or twice the answer gets
+   * no points.
+   */
+  tolerance?: number;
+}
+
+/**
+ * QuizQuestionMultipleChoice is a multiple-choice question. answer
+ * is the index of the correct choice.
+ */
+export interface QuizQuestionMultipleChoice {
+  type: "multiple_choice";
+  answer: number;
+  choices: string[];
+  question: string;
+}
+
+/**
+ * QuizQuestionOrdering is a question where players put items into
+ * the right order, such as sorting languages by their release year.
+ * items are listed in the right order and are shown to players in a
+ * random order. Answers that are partly in order get partial credit.
+ */
+export interface QuizQuestionOrdering {
+  type: "ordering";
+  items: string[];
+  question: string;
+}
+
+/**
+ * QuizQuestionTrueFalse is a true or false question. Players answer
+ * with choice 0 for true and choice 1 for false.
+ */
+export interface QuizQuestionTrueFalse {
+  type: "true_false";
+  answer: boolean;
+  question: string;
+}
+
+export enum QuizQuestionType {
+  MultipleChoice = "multiple_choice",
+  TrueFalse = "true_false",
+  Estimate = "estimate",
+  Ordering = "ordering",
+}
+
+/**
+ * QuizResult is the result of a quiz question once it is revealed. Its
+ * type depends on the type of the question.
+ */
+export type QuizResult =
+  | QuizResultChoice
+  | QuizResultEstimate
+  | QuizResultOrdering;
+
+/**
+ * QuizResultChoice is the result of a multiple-choice or true or
+ * false question. distribution holds the number of players who picked
+ * each choice.
+ */
+export interface QuizResultChoice {
+  type: "choice";
+  answer: number;
+  distribution: number[];
+}
+
+/**
+ * QuizResultEstimate is the result of an estimation question. guesses
+ * are sorted from the closest to the farthest from answer.
+ */
+export interface QuizResultEstimate {
+  type: "estimate";
+  answer: number;
+  guesses: QuizEstimateGuess[];
+}
+
+/**
+ * QuizResultOrdering is the result of an ordering question. answer
+ * lists the indices of the choices in the right order, and orders
+ * are sorted from the closest to the farthest from it.
+ */
+export interface QuizResultOrdering {
+  type: "ordering";
+  answer: number[];
+  orders: QuizOrderingGuess[];
+}
+
+/**
+ * QuizSurvival configures a survival quiz. A wrong answer or no answer
+ * eliminates the player, who stays connected as a spectator. The game ends
+ * once one player remains or the questions run out. Players are ranked by
+ * the number of questions that they survived.
+ */
+export interface QuizSurvival {
+  /**
+   * sudden_death are the questions that are played one at a time
+   * when the tie_breaker is sudden_death.
+   */
+  sudden_death?: QuizQuestion[];
+  tie_breaker?: QuizTieBreaker;
+}
+
+/**
+ * QuizTieBreaker is how a survival quiz handles players who are tied. The
+ * default is share.
+ *
+ * - share eliminates everyone who fails a question, even if nobody would
+ *   be left. Players who are left at the end share the win.
+ * - revive lets everyone survive a question that all remaining players
+ *   failed. Players who are left at the end share the win.
+ * - sudden_death works like revive, but players who are left once the
+ *   questions run out play the sudden_death questions until only one of
+ *   them survives. If those run out too, the players share the win.
+ */
+export enum QuizTieBreaker {
+  Share = "share",
+  Revive = "revive",
+  SuddenDeath = "sudden_death",
+}
+
+export interface RequestDebugGame {
+  admin_password: string;
+  gameID: GameId;
+}
+
 export interface RequestGetGame {
   gameID: string;
 }
@@ -353,20 +1692,199 @@ export interface RequestGetJeopardyGame {
   gameID: string;
 }
 
+/**
+ * RequestGetPollResults asks for the results of a poll. Voters may not
+ * want their answers to be seen by everyone who knows the game ID, so
+ * only admins may export them.
+ */
+export interface RequestGetPollResults {
+  admin_password: string;
+  gameID: GameId;
+}
+
+export interface RequestGetTournament {
+  tournamentID: TournamentId;
+}
+
 export interface RequestNewGame {
   admin_password: string;
   data: GameData;
+
+  /**
+   * schedule schedules the game ahead of time. The game ID is returned
+   * right away, but players cannot join until the lobby opens. If
+   * omitted, the lobby opens immediately.
+   */
+  schedule?: GameSchedule;
+}
+
+export interface RequestNewTournament {
+  /**
+   * admin_password is the admin password of every match game, so that
+   * the host can run the matches.
+   */
+  admin_password: string;
+  data: TournamentData;
+}
+
+export interface RequestPreviewGameMedia {
+  admin_password: string;
+  gameID: GameId;
+}
+
+/**
+ * ResponseDebugGame is a snapshot of the state machine of a running game,
+ * for admins to debug it. Inputs are named by their Go types. The graphs
+ * only contain the transitions that the game has offered so far.
+ */
+export interface ResponseDebugGame {
+  /**
+   * current is the input of the current state, or init before the game
+   * has begun taking inputs.
+   */
+  current: string;
+
+  /**
+   * dot is the graph of the state machine in the Graphviz DOT language.
+   */
+  dot: string;
+  ended: boolean;
+
+  /**
+   * mermaid is the graph of the state machine as a Mermaid state
+   * diagram.
+   */
+  mermaid: string;
+
+  /**
+   * next lists the inputs that the game accepts next, besides those of
+   * the superstate.
+   */
+  next: string[];
+  reactors: DebugReactor[];
+
+  /**
+   * superstate lists the inputs that the game accepts in any state.
+   */
+  superstate: string[];
 }
 
 export interface ResponseGetGame {
   gameType: GameType;
+
+  /**
+   * schedule is the schedule of the game if it was scheduled ahead of
+   * time.
+   */
+  schedule?: GameSchedule;
 }
 
 export interface ResponseGetJeopardyGame {
   info: JeopardyGameInfo;
 }
 
+/**
+ * ResponseGetPollResults contains the results of every question of a poll
+ * that has been closed so far.
+ */
+export interface ResponseGetPollResults {
+  results: PollResult[];
+}
+
+export interface ResponseGetTournament {
+  bracket: TournamentBracket;
+}
+
 export interface ResponseNewGame {
   gameID: string;
   gameType: GameType;
 }
+
+export interface ResponseNewTournament {
+  bracket: TournamentBracket;
+}
+
+/**
+ * ResponsePreviewGameMedia lists every media blob that the questions of a
+ * game use, so that admins can check them before players see them.
+ */
+export interface ResponsePreviewGameMedia {
+  media: Media[];
+}
+
+/**
+ * ResponseUploadMedia is returned after uploading a media blob. The blob
+ * is uploaded as the raw request body, and its Content-Type header must
+ * be an image or audio type.
+ */
+export interface ResponseUploadMedia {
+  media: Media;
+}
+
+/**
+ * TournamentBracket is the current state of a tournament. rounds holds
+ * the matches of each round, from the first round to the final. winner
+ * is set once the final has ended.
+ */
+export interface TournamentBracket {
+  name: string;
+  rounds: TournamentMatch[][];
+  tournamentID: TournamentId;
+  winner?: PlayerName;
+}
+
+/**
+ * TournamentData describes a single-elimination tournament. Each match
+ * is its own game, and the winner of a match advances to the next round.
+ * The entrant with the higher score wins a match, and ties go to the
+ * higher seed. An entrant who never joins their match loses it, unless
+ * neither entrant joins, in which case the higher seed wins.
+ */
+export interface TournamentData {
+  /**
+   * entrants are the players or teams in the tournament, from the top
+   * seed to the bottom one. Each entrant joins their matches using
+   * their name as the player name. Top seeds get a bye if the number of
+   * entrants is not a power of two.
+   */
+  entrants: PlayerName[];
+
+  /**
+   * games are the question sets that the matches are played with. The
+   * matches of round i use games[i % len(games)].
+   */
+  games: GameData[];
+  name: string;
+}
+
+/**
+ * TournamentID is the unique identifier for a tournament.
+ */
+export type TournamentId = string;
+
+/**
+ * TournamentMatch is a match between two entrants. entrants only holds
+ * the entrants that are known so far. A match with a single entrant in
+ * the first round is a bye.
+ */
+export interface TournamentMatch {
+  entrants: PlayerName[];
+  state: TournamentMatchState;
+  gameID?: GameId;
+  leaderboard?: Leaderboard;
+  winner?: PlayerName;
+}
+
+/**
+ * TournamentMatchState is the state of a tournament match.
+ *
+ * - pending is when the match is waiting for its entrants.
+ * - playing is when the match game has been created. Its entrants can
+ *   join it using gameID.
+ * - done is when the match has a winner.
+ */
+export enum TournamentMatchState {
+  Pending = "pending",
+  Playing = "playing",
+  Done = "done",
+}
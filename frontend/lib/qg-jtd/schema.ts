@@ -2,6 +2,54 @@ import * as jtd from "jtd";
 
 export default {
   definitions: {
+    BuzzerBuzz: {
+      metadata: {
+        description:
+          "BuzzerBuzz is a single button press within a buzz round.\n",
+      },
+      properties: {
+        latency: {
+          metadata: {
+            description:
+              "latency is the estimated one-way latency of the player in\nmilliseconds that was compensated for. It is 0 if the game does not\ncompensate for latency.\n",
+          },
+          type: "float32",
+        },
+        playerName: {
+          ref: "PlayerName",
+        },
+      },
+    },
+    BuzzerGameData: {
+      metadata: {
+        description:
+          "BuzzerGameData is the game data for a buzzer game. A buzzer game has no\nquestions: the host asks them on their own, opens a buzz round for each\none, and awards points by hand.\n",
+      },
+      optionalProperties: {
+        compensate_latency: {
+          metadata: {
+            description:
+              "compensate_latency orders buzzes by their estimated send time,\nwhich is the arrival time minus half of the player's round-trip\ntime. If false, buzzes are ordered by their arrival time. The\ndefault is false.\n",
+          },
+          type: "boolean",
+        },
+        early_buzz_penalty: {
+          metadata: {
+            description:
+              "early_buzz_penalty is how long a player is locked out of their\nbuzzer if they press it while no round is open. The format is in\nGo's time.Duration. The default is 250ms.\n",
+          },
+          type: "string",
+        },
+      },
+      properties: {},
+    },
+    BuzzerGameInfo: {
+      properties: {
+        compensateLatency: {
+          type: "boolean",
+        },
+      },
+    },
     Command: {
       discriminator: "type",
       mapping: {
@@ -10,6 +58,66 @@ export default {
             description:
               "CommandBeginGame is sent by a client to begin a game.\n",
           },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        BuzzerAwardPoints: {
+          metadata: {
+            description:
+              "CommandBuzzerAwardPoints is sent by a game admin to award points to a\nplayer at any time. points may be negative to take points away.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {
+            playerName: {
+              ref: "PlayerName",
+            },
+            points: {
+              type: "float32",
+            },
+          },
+        },
+        BuzzerCloseRound: {
+          metadata: {
+            description:
+              "CommandBuzzerCloseRound is sent by a game admin to close the current\nbuzz round.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        BuzzerOpenRound: {
+          metadata: {
+            description:
+              "CommandBuzzerOpenRound is sent by a game admin to open a new buzz round.\nOnly one round can be open at a time.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        BuzzerPress: {
+          metadata: {
+            description:
+              "CommandBuzzerPress is sent by a player to buzz in. Each player can only\nbuzz once per round. Pressing while no round is open locks the player\nout for a short while.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
           properties: {},
         },
         EndGame: {
@@ -17,6 +125,11 @@ export default {
             description:
               "CommandEndGame is sent by a client to end the current game. The server\nwill respond with an EventGameEnded. Only game admins (including the\nhost) can end the game.\n",
           },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
           properties: {
             declareWinner: {
               metadata: {
@@ -27,11 +140,80 @@ export default {
             },
           },
         },
+        FeudNextRound: {
+          metadata: {
+            description:
+              "CommandFeudNextRound is sent by a game admin to begin the next round\nonce the current one has ended.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        FeudPressButton: {
+          metadata: {
+            description:
+              "CommandFeudPressButton is sent by a face-off player to buzz in.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        FeudRevealAnswer: {
+          metadata: {
+            description:
+              "CommandFeudRevealAnswer is sent by a game admin when the answering\nplayer gave one of the answers on the board. answer is its index.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {
+            answer: {
+              type: "int32",
+            },
+          },
+        },
+        FeudStrike: {
+          metadata: {
+            description:
+              "CommandFeudStrike is sent by a game admin when the answering player\ngave an answer that is not on the board.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
+        JeopardyArmBuzzers: {
+          metadata: {
+            description:
+              "CommandJeopardyArmBuzzers is sent by a game admin once they have finished\nreading the current question. Until then, the question is in its reading\nphase, and any player that presses their button is locked out for a\nshort penalty.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {},
+        },
         JeopardyChooseQuestion: {
           metadata: {
             description:
               "CommandJeopardyChooseQuestion is sent by a player to choose a question.\nThe server must do validation to ensure that the player is allowed to\nchoose the question.\n",
           },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
           properties: {
             category: {
               type: "int32",
@@ -44,7 +226,12 @@ export default {
         JeopardyPlayerJudgment: {
           metadata: {
             description:
-              "CommandJeopardyPlayerJudgment is emitted by a game admin to indicate\nwhether a player has answered a question correctly. The winning player is\nwhoever the last EventJeopardyButtonPressed event indicated. That player\nwill instantly receive the points for the question, and the game will let\nthem choose the next category and question. If the player answered wrong,\nthen the game will let others press the button.\n",
+              "CommandJeopardyPlayerJudgment is emitted by a game admin to indicate\nwhether a player has answered a question correctly. The winning player is\nwhoever the last EventJeopardyButtonPressed event indicated. That player\nwill instantly receive the points for the question, and the game will let\nthem choose the next category and question. If the player answered wrong,\nthen the game will let others press the button.\n\nIn practice games, the player sends it themselves after an\nEventJeopardySelfJudge.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {
             correct: {
@@ -55,14 +242,40 @@ export default {
         JeopardyPressButton: {
           metadata: {
             description:
-              "CommandJeopardyPressButton is emitted when a player presses the button\nduring a question. It is only valid to emit this command when the game is\nin the question state.\n",
+              "CommandJeopardyPressButton is emitted when a player presses the button\nduring a question. It is only valid to emit this command when the game is\nin the question state. Pressing the button before the buzzers are armed\nlocks the player out for a short penalty.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {},
         },
+        JeopardySubmitAnswer: {
+          metadata: {
+            description:
+              "CommandJeopardySubmitAnswer is sent by the player of a practice game to\nanswer the current question after pressing their button. The host\njudges the answer against the accepted answers of the question and\nreplies with an EventJeopardyAnswerJudged. If the question has no\naccepted answers, the host replies with an EventJeopardySelfJudge\ninstead.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {
+            answer: {
+              type: "string",
+            },
+          },
+        },
         JoinGame: {
           metadata: {
             description:
-              "CommandJoinGame is sent by a client to join a game. The client (or the\nuser) supplies a game ID and a player name. The server will respond with\nan EventJoinedGame.\n",
+              "CommandJoinGame is sent by a client to join a game. The client (or the\nuser) supplies a game ID and a player name. The server will respond with\nan EventJoinedGame followed by an EventGameSnapshot. Players may join at\nany time, even once the game has begun, and the snapshot catches them\nup.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {
             adminPassword: {
@@ -85,398 +298,2159 @@ export default {
               },
               ref: "PlayerName",
             },
-          },
-        },
-      },
-    },
-    Error: {
-      metadata: {
-        description: "Error is returned on every API error.\n",
-      },
-      properties: {
-        message: {
-          metadata: {
-            description: "Message is the error message",
-          },
-          type: "string",
-        },
-      },
-    },
-    Event: {
-      discriminator: "type",
-      mapping: {
-        Error: {
-          properties: {
-            error: {
-              ref: "Error",
+            rejoinToken: {
+              metadata: {
+                description:
+                  "rejoinToken is the rejoinToken of an earlier EventJoinedGame. If\nit matches, the player takes back their place in the game under\nthe same name instead of joining as a new player.\n",
+              },
+              nullable: true,
+              type: "string",
             },
           },
         },
-        GameEnded: {
+        PauseGame: {
           metadata: {
             description:
-              "EventGameEnded is emitted when the current game ends.\n",
+              "CommandPauseGame is sent by a game admin to pause the game. The server\nwill respond with an EventGamePaused.\n",
           },
-          properties: {
-            leaderboard: {
-              ref: "Leaderboard",
+          optionalProperties: {
+            id: {
+              type: "string",
             },
           },
+          properties: {},
         },
-        GameStarted: {
+        PollNextQuestion: {
           metadata: {
             description:
-              "EventGameStarted is emitted when the game starts. It contains no data and\nis only meant to be used to trigger the client to start the game.\n",
+              "CommandPollNextQuestion is sent by a game admin to close the current\nquestion and push the next one. Once there are no questions left, the\npoll ends.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {},
         },
-        JeopardyBeginQuestion: {
+        PollVote: {
           metadata: {
             description:
-              "EventJeopardyBeginQuestion is emitted when a question begins within this\nJeopardy game. It is usually emitted once the chooser player has chosen a\ncategory and value.\n\nEach category name and question value will map to a category and question\nwithin the game data. Note that a question may repeat across multiple\ncategories.\n",
+              "CommandPollVote is sent by a player to vote on the current question.\nchoices are the indices of the chosen choices. Voting again replaces the\nprevious vote.\n",
           },
-          properties: {
-            category: {
-              type: "int32",
-            },
-            chooser: {
-              ref: "PlayerName",
-            },
-            points: {
-              type: "float32",
-            },
-            question: {
+          optionalProperties: {
+            id: {
               type: "string",
             },
           },
+          properties: {
+            choices: {
+              elements: {
+                type: "int32",
+              },
+            },
+          },
         },
-        JeopardyButtonPressed: {
+        PracticeGame: {
           metadata: {
             description:
-              'EventJeopardyButtonPressed is emitted when any player had pressed a button\non their device, voiding other players\' buttons. This event is only\nemitted when the game is in the "question" state.\n',
+              "CommandPracticeGame is sent by a client instead of CommandJoinGame to\nplay a saved game alone. The server creates a private copy of the game\nthat is hosted by a bot: it begins the game right away, judges answers\nusing the stored answers and moves on using timers. The server will\nrespond with an EventJoinedGame, and the score of the player is kept\nas their personal best once the game ends. Only Jeopardy games and\nquizzes can be practiced.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {
+            gameID: {
+              metadata: {
+                description: "gameID is the ID of the game to practice.",
+              },
+              ref: "GameID",
+            },
             playerName: {
+              metadata: {
+                description: "playerName is the name of the user.",
+              },
               ref: "PlayerName",
             },
           },
         },
-        JeopardyResumeButton: {
+        QuizAnswer: {
           metadata: {
             description:
-              "EventJeopardyResumeButton is emitted when the player can now continue to\npress the button whenever they are ready to answer the question. This\ncould happen if the other player who pressed the button first got the\nquestion wrong.\n\nNote that if alreadyPressed is true, then the player has already pressed\nthe button, so they cannot press it again.\n",
+              "CommandQuizAnswer is sent by a player to answer the current question.\nEach player can only answer once per question.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
           },
           properties: {
-            alreadyAnsweredPlayers: {
-              elements: {
-                ref: "PlayerName",
-              },
+            answer: {
+              ref: "QuizAnswer",
             },
           },
         },
-        JeopardyTurnEnded: {
+        QuizNextQuestion: {
           metadata: {
             description:
-              "EventJeopardyTurnEnded is emitted when a turn ends or when the game first\nstarts.\n",
+              "CommandQuizNextQuestion is sent by a game admin to move on to the next\nquestion once the current one is revealed. Once there are no questions\nleft, the quiz ends. In practice games, the host moves on by itself\nafter a short while, but the player may also send this to move on\nright away.\n",
           },
-          properties: {
-            answered: {
-              ref: "JeopardyAnsweredQuestions",
-            },
-            chooser: {
-              ref: "PlayerName",
-            },
-            leaderboard: {
-              ref: "Leaderboard",
+          optionalProperties: {
+            id: {
+              type: "string",
             },
           },
+          properties: {},
         },
-        JoinedGame: {
+        RequestSnapshot: {
           metadata: {
             description:
-              "EventJoinedGame is emitted when the current player joins a game. It is a\nreply to CommandJoinGame and is only for the current player. Not to be\nconfused with EventPlayerJoinedGame, which is emitted when any player\njoins the current game.\n",
+              "CommandRequestSnapshot is sent by a client to get the current state of\nits game. The server will respond with an EventGameSnapshot.\n",
           },
-          properties: {
-            gameData: {
-              nullable: true,
-              ref: "GameData",
-            },
-            gameID: {
+          optionalProperties: {
+            id: {
               type: "string",
             },
-            gameInfo: {
-              ref: "GameInfo",
-            },
-            isAdmin: {
-              type: "boolean",
-            },
           },
+          properties: {},
         },
-        PlayerJoined: {
+        ResumeGame: {
           metadata: {
             description:
-              "EventPlayerJoined is emitted when a player joins the current game.\n",
+              "CommandResumeGame is sent by a game admin to resume a paused game. The\nserver will respond with an EventGameResumed.\n",
           },
-          properties: {
-            playerName: {
-              ref: "PlayerName",
+          optionalProperties: {
+            id: {
+              type: "string",
             },
           },
+          properties: {},
         },
       },
     },
-    GameData: {
+    DebugReactor: {
+      metadata: {
+        description:
+          "DebugReactor is a reactor of a state machine. hook is one of leave,\ntransition, enter and react. from and to are the states that it reacts\nto leaving and entering, either of which may be any. A react reactor\nruns once the state from is entered and it offers the state to.\n",
+      },
+      properties: {
+        from: {
+          type: "string",
+        },
+        hook: {
+          type: "string",
+        },
+        to: {
+          type: "string",
+        },
+      },
+    },
+    Error: {
+      metadata: {
+        description: "Error is returned on every API error.\n",
+      },
+      optionalProperties: {
+        code: {
+          metadata: {
+            description:
+              "Code tells what kind of error this is, if it is known",
+          },
+          ref: "ErrorCode",
+        },
+        details: {
+          elements: {
+            ref: "ErrorDetail",
+          },
+          metadata: {
+            description:
+              "Details are the individual problems that make up the error, if any",
+          },
+        },
+      },
+      properties: {
+        message: {
+          metadata: {
+            description: "Message is the error message",
+          },
+          type: "string",
+        },
+      },
+    },
+    ErrorCode: {
+      enum: [
+        "invalid_request",
+        "unauthorized",
+        "not_found",
+        "conflict",
+        "invalid_state",
+        "not_your_turn",
+        "slow_subscriber",
+        "internal",
+      ],
+      metadata: {
+        description:
+          "ErrorCode is a stable code for an error. Unlike the message, clients may\nmatch on it. HTTP responses always have a code, which also decides the\nstatus of the response.\n\n- invalid_request is when the request itself is wrong, such as game\n  data that does not make sense (400).\n- unauthorized is when the player may never do this, such as a player\n  who is not an admin pausing the game or a wrong admin password (403).\n- not_found is when the thing that was asked for does not exist (404).\n- conflict is when the thing that was created already exists (409).\n- invalid_state is when the command cannot be sent at this point of\n  the game, such as answering before a question is asked (409).\n- not_your_turn is when the command is only for another player right\n  now, such as choosing a question when someone else is the chooser\n  (409).\n- slow_subscriber is when the connection fell too far behind on its\n  events and is about to be closed. The client may join again with its\n  rejoin token (503).\n- internal is when the server failed on its own (500).\n",
+      },
+    },
+    ErrorDetail: {
+      metadata: {
+        description:
+          "ErrorDetail is one of the problems that make up an Error, such as one\nof the many mistakes in an uploaded Jeopardy board. message explains\nthe problem. path is a JSON Pointer to the value that the problem is\nin, if it is about one. schema_path is a JSON Pointer to the part of\nthe schema that the value does not match, if the problem was found by\nchecking the value against the schema.\n",
+      },
+      optionalProperties: {
+        path: {
+          type: "string",
+        },
+        schema_path: {
+          type: "string",
+        },
+      },
+      properties: {
+        message: {
+          type: "string",
+        },
+      },
+    },
+    Event: {
+      discriminator: "type",
+      mapping: {
+        Ack: {
+          metadata: {
+            description:
+              "EventAck is emitted once a command with an ID has been handled without\nerror. id is the ID of the command. Events that the command caused may\narrive before or after it.\n",
+          },
+          properties: {
+            id: {
+              type: "string",
+            },
+          },
+        },
+        BuzzerBuzzes: {
+          metadata: {
+            description:
+              "EventBuzzerBuzzes is emitted whenever a player buzzes in. buzzes lists\nevery buzz of the round so far in order, starting with the earliest.\n",
+          },
+          properties: {
+            buzzes: {
+              elements: {
+                ref: "BuzzerBuzz",
+              },
+            },
+            round: {
+              type: "int32",
+            },
+          },
+        },
+        BuzzerLockedOut: {
+          metadata: {
+            description:
+              "EventBuzzerLockedOut is emitted when a player presses their button while\nno round is open. The player's buzzer is locked out for penalty\nmilliseconds.\n",
+          },
+          properties: {
+            penalty: {
+              type: "float32",
+            },
+            playerName: {
+              ref: "PlayerName",
+            },
+          },
+        },
+        BuzzerPointsAwarded: {
+          metadata: {
+            description:
+              "EventBuzzerPointsAwarded is emitted when the admin awards points to a\nplayer.\n",
+          },
+          properties: {
+            leaderboard: {
+              ref: "Leaderboard",
+            },
+            playerName: {
+              ref: "PlayerName",
+            },
+            points: {
+              type: "float32",
+            },
+          },
+        },
+        BuzzerRoundClosed: {
+          metadata: {
+            description:
+              "EventBuzzerRoundClosed is emitted when the admin closes the current buzz\nround. buzzes is the final order of the buzzes of the round.\n",
+          },
+          properties: {
+            buzzes: {
+              elements: {
+                ref: "BuzzerBuzz",
+              },
+            },
+            round: {
+              type: "int32",
+            },
+          },
+        },
+        BuzzerRoundOpened: {
+          metadata: {
+            description:
+              "EventBuzzerRoundOpened is emitted when the admin opens a buzz round.\nPlayers can buzz in until the round is closed.\n",
+          },
+          properties: {
+            round: {
+              type: "int32",
+            },
+          },
+        },
+        Error: {
+          metadata: {
+            description:
+              "EventError is emitted when a command fails. id is the ID of the\ncommand, if the client gave it one.\n",
+          },
+          optionalProperties: {
+            id: {
+              type: "string",
+            },
+          },
+          properties: {
+            error: {
+              ref: "Error",
+            },
+          },
+        },
+        FeudAnswerKey: {
+          metadata: {
+            description:
+              "EventFeudAnswerKey is emitted only to admins when a round begins. It\nholds every answer on the board, so that admins can judge the answers\nof the players before they are revealed.\n",
+          },
+          properties: {
+            answers: {
+              elements: {
+                ref: "FeudAnswer",
+              },
+            },
+            round: {
+              type: "int32",
+            },
+          },
+        },
+        FeudAnswerRevealed: {
+          metadata: {
+            description:
+              "EventFeudAnswerRevealed is emitted when an answer on the board is\nrevealed. bank is the total points of the answers revealed so far this\nround, which the winner of the round gets.\n",
+          },
+          properties: {
+            answer: {
+              type: "int32",
+            },
+            bank: {
+              type: "float32",
+            },
+            points: {
+              type: "float32",
+            },
+            team: {
+              type: "int32",
+            },
+            text: {
+              type: "string",
+            },
+          },
+        },
+        FeudBeginRound: {
+          metadata: {
+            description:
+              "EventFeudBeginRound is emitted when a round begins, including the first\none once the game starts. The round begins with a face-off between the\ntwo faceOff players, one from each team.\n",
+          },
+          properties: {
+            faceOff: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+            numAnswers: {
+              type: "int32",
+            },
+            question: {
+              type: "string",
+            },
+            round: {
+              type: "int32",
+            },
+            teams: {
+              elements: {
+                ref: "FeudTeam",
+              },
+            },
+          },
+        },
+        FeudButtonPressed: {
+          metadata: {
+            description:
+              "EventFeudButtonPressed is emitted when a face-off player presses their\nbutton first. That player gets to answer first.\n",
+          },
+          properties: {
+            playerName: {
+              ref: "PlayerName",
+            },
+            team: {
+              type: "int32",
+            },
+          },
+        },
+        FeudRoundEnded: {
+          metadata: {
+            description:
+              "EventFeudRoundEnded is emitted when a round ends. The winner team gets\nthe points in the bank. All answers of the round are revealed.\n",
+          },
+          properties: {
+            answers: {
+              elements: {
+                ref: "FeudAnswer",
+              },
+            },
+            points: {
+              type: "float32",
+            },
+            teams: {
+              elements: {
+                ref: "FeudTeam",
+              },
+            },
+            winner: {
+              type: "int32",
+            },
+          },
+        },
+        FeudStrike: {
+          metadata: {
+            description:
+              "EventFeudStrike is emitted when a team answers wrong. strikes only\ncounts up in the play phase.\n",
+          },
+          properties: {
+            strikes: {
+              type: "int32",
+            },
+            team: {
+              type: "int32",
+            },
+          },
+        },
+        FeudTurn: {
+          metadata: {
+            description:
+              "EventFeudTurn is emitted whenever the game waits for a player to answer.\nThe admin then judges the answer using CommandFeudRevealAnswer or\nCommandFeudStrike. In the steal phase, playerName answers on behalf of\ntheir team.\n",
+          },
+          properties: {
+            phase: {
+              ref: "FeudPhase",
+            },
+            playerName: {
+              ref: "PlayerName",
+            },
+            team: {
+              type: "int32",
+            },
+          },
+        },
+        GameEnded: {
+          metadata: {
+            description:
+              "EventGameEnded is emitted when the current game ends.\n",
+          },
+          properties: {
+            leaderboard: {
+              ref: "Leaderboard",
+            },
+          },
+        },
+        GamePaused: {
+          metadata: {
+            description:
+              "EventGamePaused is emitted when an admin pauses the game. While the game\nis paused, only admins may send commands other than CommandJoinGame,\nand all of the game's timers are frozen.\n",
+          },
+          properties: {
+            playerName: {
+              metadata: {
+                description:
+                  "playerName is the name of the admin who paused the game.",
+              },
+              ref: "PlayerName",
+            },
+          },
+        },
+        GameResumed: {
+          metadata: {
+            description:
+              "EventGameResumed is emitted when an admin resumes a paused game.\n",
+          },
+          properties: {
+            playerName: {
+              metadata: {
+                description:
+                  "playerName is the name of the admin who resumed the game.",
+              },
+              ref: "PlayerName",
+            },
+          },
+        },
+        GameSnapshot: {
+          metadata: {
+            description:
+              "EventGameSnapshot is emitted to a player once they join, to everyone\nonce the game is resumed, and to a player who sends a\nCommandRequestSnapshot. It describes the whole current state of the\ngame, so that players who join late or reconnect can catch up without\nwaiting for the next turn. players are the players who are not admins\nin the order that they joined. game holds the state that is specific\nto the game type, if the game type has any.\n",
+          },
+          properties: {
+            ended: {
+              type: "boolean",
+            },
+            game: {
+              nullable: true,
+              ref: "GameSnapshot",
+            },
+            leaderboard: {
+              ref: "Leaderboard",
+            },
+            paused: {
+              type: "boolean",
+            },
+            players: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+            started: {
+              type: "boolean",
+            },
+          },
+        },
+        GameStarted: {
+          metadata: {
+            description:
+              "EventGameStarted is emitted when the game starts. It contains no data and\nis only meant to be used to trigger the client to start the game.\n",
+          },
+          properties: {},
+        },
+        JeopardyAnswerJudged: {
+          metadata: {
+            description:
+              "EventJeopardyAnswerJudged is emitted in practice games once the host has\njudged the answer of the player, or once the player ran out of time, in\nwhich case answer is empty. answers are the accepted answers to the\nquestion.\n",
+          },
+          properties: {
+            answer: {
+              type: "string",
+            },
+            answers: {
+              elements: {
+                type: "string",
+              },
+            },
+            correct: {
+              type: "boolean",
+            },
+          },
+        },
+        JeopardyBeginQuestion: {
+          metadata: {
+            description:
+              "EventJeopardyBeginQuestion is emitted when a question begins within this\nJeopardy game. It is usually emitted once the chooser player has chosen a\ncategory and value. The question starts in its reading phase, which\nlasts until an admin sends a CommandJeopardyArmBuzzers.\n\nEach category name and question value will map to a category and question\nwithin the game data. Note that a question may repeat across multiple\ncategories. content holds the content blocks of the question, if any.\n",
+          },
+          properties: {
+            category: {
+              type: "int32",
+            },
+            chooser: {
+              ref: "PlayerName",
+            },
+            content: {
+              elements: {
+                ref: "QuestionContent",
+              },
+            },
+            points: {
+              type: "float32",
+            },
+            question: {
+              type: "string",
+            },
+          },
+        },
+        JeopardyButtonPressed: {
+          metadata: {
+            description:
+              'EventJeopardyButtonPressed is emitted when any player had pressed a button\non their device, voiding other players\' buttons. This event is only\nemitted when the game is in the "question" state.\n\nIf the game uses a fair buzzer, buzzes contains every button press\ncollected within the buzz window in compensated order, and playerName\nis the first of them. Otherwise, buzzes only contains playerName.\n',
+          },
+          properties: {
+            buzzes: {
+              elements: {
+                ref: "JeopardyBuzz",
+              },
+            },
+            playerName: {
+              ref: "PlayerName",
+            },
+          },
+        },
+        JeopardyBuzzerLockedOut: {
+          metadata: {
+            description:
+              "EventJeopardyBuzzerLockedOut is emitted when a player presses their\nbutton during the reading phase, before the buzzers are armed. The\nplayer cannot press their button again until the penalty has passed.\n",
+          },
+          properties: {
+            penalty: {
+              metadata: {
+                description: "penalty is the lockout duration in milliseconds.",
+              },
+              type: "float32",
+            },
+            playerName: {
+              ref: "PlayerName",
+            },
+          },
+        },
+        JeopardyBuzzersArmed: {
+          metadata: {
+            description:
+              "EventJeopardyBuzzersArmed is emitted when the admin arms the buzzers,\nending the reading phase of the current question. Players may press\ntheir button from now on.\n",
+          },
+          properties: {},
+        },
+        JeopardyResumeButton: {
+          metadata: {
+            description:
+              "EventJeopardyResumeButton is emitted when the player can now continue to\npress the button whenever they are ready to answer the question. This\ncould happen if the other player who pressed the button first got the\nquestion wrong.\n\nNote that if alreadyPressed is true, then the player has already pressed\nthe button, so they cannot press it again.\n",
+          },
+          properties: {
+            alreadyAnsweredPlayers: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+          },
+        },
+        JeopardySelfJudge: {
+          metadata: {
+            description:
+              "EventJeopardySelfJudge is emitted in practice games once the player has\nanswered a question that has no accepted answers, such as one from a\nset that was saved before answers could be stored. The host cannot\njudge it, so the player judges their own answer by sending a\nCommandJeopardyPlayerJudgment before they run out of time. A correct\nanswer uses up the question but scores no points, so that personal\nbests only count answers that the host could check.\n",
+          },
+          properties: {
+            answer: {
+              type: "string",
+            },
+          },
+        },
+        JeopardyTurnEnded: {
+          metadata: {
+            description:
+              "EventJeopardyTurnEnded is emitted when a turn ends or when the game first\nstarts.\n",
+          },
+          properties: {
+            answered: {
+              ref: "JeopardyAnsweredQuestions",
+            },
+            chooser: {
+              ref: "PlayerName",
+            },
+            leaderboard: {
+              ref: "Leaderboard",
+            },
+          },
+        },
+        JoinedGame: {
+          metadata: {
+            description:
+              "EventJoinedGame is emitted when the current player joins a game. It is a\nreply to CommandJoinGame and is only for the current player. Not to be\nconfused with EventPlayerJoinedGame, which is emitted when any player\njoins the current game.\n\nrejoinToken lets the player take their place back if they lose their\nconnection, such as when the server closes a connection that fell too\nfar behind. The client keeps it secret and sends it along with the same\nplayer name in the CommandJoinGame of its new connection.\n",
+          },
+          properties: {
+            gameData: {
+              nullable: true,
+              ref: "GameData",
+            },
+            gameID: {
+              type: "string",
+            },
+            gameInfo: {
+              ref: "GameInfo",
+            },
+            isAdmin: {
+              type: "boolean",
+            },
+            rejoinToken: {
+              type: "string",
+            },
+          },
+        },
+        PlayerJoined: {
+          metadata: {
+            description:
+              "EventPlayerJoined is emitted when a player joins the current game.\n",
+          },
+          properties: {
+            playerName: {
+              ref: "PlayerName",
+            },
+          },
+        },
+        PollBeginQuestion: {
+          metadata: {
+            description:
+              "EventPollBeginQuestion is emitted when the admin pushes a new question.\nVoting on the previous question is closed.\n",
+          },
+          properties: {
+            choices: {
+              elements: {
+                type: "string",
+              },
+            },
+            index: {
+              type: "int32",
+            },
+            multiple: {
+              type: "boolean",
+            },
+            question: {
+              type: "string",
+            },
+          },
+        },
+        PollResults: {
+          metadata: {
+            description:
+              "EventPollResults is emitted whenever a player votes. It contains the\naggregated results of the current question so far.\n",
+          },
+          properties: {
+            index: {
+              type: "int32",
+            },
+            result: {
+              ref: "PollResult",
+            },
+          },
+        },
+        PracticeEnded: {
+          metadata: {
+            description:
+              "EventPracticeEnded is emitted after EventGameEnded once a practice game\nends. score is the final score of the player, and personalBest is their\nbest score in this game, including this one. newPersonalBest is true if\nthis score beat every previous one.\n",
+          },
+          properties: {
+            newPersonalBest: {
+              type: "boolean",
+            },
+            personalBest: {
+              type: "float32",
+            },
+            score: {
+              type: "float32",
+            },
+          },
+        },
+        QuizBeginQuestion: {
+          metadata: {
+            description:
+              'EventQuizBeginQuestion is emitted when a question begins. Players have\ntimeLimit milliseconds to answer it. True or false questions have the\nchoices "True" and "False", estimation questions have no choices, and\nthe choices of ordering questions are their items in a random order.\nsuddenDeath is true for the sudden death questions of a survival quiz.\n',
+          },
+          properties: {
+            choices: {
+              elements: {
+                type: "string",
+              },
+            },
+            index: {
+              type: "int32",
+            },
+            question: {
+              type: "string",
+            },
+            questionType: {
+              ref: "QuizQuestionType",
+            },
+            suddenDeath: {
+              type: "boolean",
+            },
+            timeLimit: {
+              type: "float32",
+            },
+          },
+        },
+        QuizEliminated: {
+          metadata: {
+            description:
+              "EventQuizEliminated is emitted after each reveal of a survival quiz.\neliminated lists the players who were eliminated by the question, and\nsurvivors lists the players who are still in the game.\n",
+          },
+          properties: {
+            eliminated: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+            index: {
+              type: "int32",
+            },
+            survivors: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+          },
+        },
+        QuizPlayerAnswered: {
+          metadata: {
+            description:
+              "EventQuizPlayerAnswered is emitted when a player answers the current\nquestion. The answer itself is not revealed until the question ends.\n",
+          },
+          properties: {
+            playerName: {
+              ref: "PlayerName",
+            },
+          },
+        },
+        QuizReveal: {
+          metadata: {
+            description:
+              "EventQuizReveal is emitted once everyone has answered the current\nquestion or its time is up. correct lists the players who got the\nquestion right, or the closest guesses for an estimation question.\n",
+          },
+          properties: {
+            correct: {
+              elements: {
+                ref: "PlayerName",
+              },
+            },
+            index: {
+              type: "int32",
+            },
+            leaderboard: {
+              ref: "Leaderboard",
+            },
+            result: {
+              ref: "QuizResult",
+            },
+          },
+        },
+      },
+    },
+    FeudAnswer: {
+      properties: {
+        answer: {
+          type: "string",
+        },
+        points: {
+          type: "float32",
+        },
+      },
+    },
+    FeudGameData: {
+      metadata: {
+        description:
+          "FeudGameData is the game data for a Family Feud game. Two teams play\none round per question.\n",
+      },
+      optionalProperties: {
+        max_strikes: {
+          metadata: {
+            description:
+              "max_strikes is the number of strikes that the team in control may\nget before the other team gets to steal. The default is 3.\n",
+          },
+          type: "uint32",
+        },
+        team_names: {
+          elements: {
+            type: "string",
+          },
+          metadata: {
+            description:
+              'team_names are the names of the two teams. The default is\n"Team 1" and "Team 2".\n',
+          },
+        },
+      },
+      properties: {
+        questions: {
+          elements: {
+            ref: "FeudQuestion",
+          },
+        },
+      },
+    },
+    FeudGameInfo: {
+      properties: {
+        numQuestions: {
+          type: "int32",
+        },
+        teamNames: {
+          elements: {
+            type: "string",
+          },
+        },
+      },
+    },
+    FeudPhase: {
+      enum: ["face_off", "play", "steal"],
+      metadata: {
+        description:
+          "FeudPhase is the phase of a Family Feud round.\n\n- face_off is when one player of each team competes for control of\n  the round.\n- play is when the team in control guesses the remaining answers.\n- steal is when the other team gets one guess to steal the points\n  after the team in control has struck out.\n",
+      },
+    },
+    FeudQuestion: {
+      metadata: {
+        description:
+          "FeudQuestion is a survey question along with its surveyed answers.\n",
+      },
+      properties: {
+        answers: {
+          elements: {
+            ref: "FeudAnswer",
+          },
+          metadata: {
+            description:
+              "answers are the surveyed answers, ranked from the most popular to\nthe least.\n",
+          },
+        },
+        question: {
+          type: "string",
+        },
+      },
+    },
+    FeudTeam: {
+      properties: {
+        name: {
+          type: "string",
+        },
+        players: {
+          elements: {
+            ref: "PlayerName",
+          },
+        },
+        score: {
+          type: "float32",
+        },
+      },
+    },
+    GameData: {
       discriminator: "game",
       mapping: {
-        jeopardy: {
+        buzzer: {
+          properties: {
+            data: {
+              ref: "BuzzerGameData",
+            },
+          },
+        },
+        feud: {
+          properties: {
+            data: {
+              ref: "FeudGameData",
+            },
+          },
+        },
+        jeopardy: {
+          properties: {
+            data: {
+              ref: "JeopardyGameData",
+            },
+          },
+        },
+        kahoot: {
+          properties: {
+            data: {
+              ref: "KahootGameData",
+            },
+          },
+        },
+        poll: {
+          properties: {
+            data: {
+              ref: "PollGameData",
+            },
+          },
+        },
+        quiz: {
+          properties: {
+            data: {
+              ref: "QuizGameData",
+            },
+          },
+        },
+      },
+      metadata: {
+        description:
+          "GameData is the game data. It contains all the information about the game.\n",
+      },
+    },
+    GameID: {
+      metadata: {
+        description:
+          "GameID is the unique identifier for a game. Each player must type this\ncode to join the game.\n",
+      },
+      type: "string",
+    },
+    GameInfo: {
+      discriminator: "type",
+      mapping: {
+        buzzer: {
+          properties: {
+            data: {
+              ref: "BuzzerGameInfo",
+            },
+          },
+        },
+        feud: {
+          properties: {
+            data: {
+              ref: "FeudGameInfo",
+            },
+          },
+        },
+        jeopardy: {
+          properties: {
+            data: {
+              ref: "JeopardyGameInfo",
+            },
+          },
+        },
+        poll: {
+          properties: {
+            data: {
+              ref: "PollGameInfo",
+            },
+          },
+        },
+        quiz: {
+          properties: {
+            data: {
+              ref: "QuizGameInfo",
+            },
+          },
+        },
+      },
+    },
+    GameSchedule: {
+      metadata: {
+        description:
+          "GameSchedule describes when the lobby of a scheduled game opens.\n",
+      },
+      optionalProperties: {
+        autoBegin: {
+          metadata: {
+            description:
+              "autoBegin begins the game on its own once this many players\n(excluding admins) have joined. If omitted, an admin has to begin\nthe game.\n",
+          },
+          type: "uint32",
+        },
+      },
+      properties: {
+        opensAt: {
+          metadata: {
+            description:
+              "opensAt is the time that the lobby opens and players can start\njoining the game.\n",
+          },
+          type: "timestamp",
+        },
+      },
+    },
+    GameSnapshot: {
+      discriminator: "type",
+      mapping: {
+        jeopardy: {
+          properties: {
+            data: {
+              ref: "JeopardySnapshot",
+            },
+          },
+        },
+      },
+    },
+    GameType: {
+      enum: ["jeopardy", "kahoot", "feud", "poll", "quiz", "buzzer"],
+    },
+    JeopardyAnsweredQuestions: {
+      elements: {
+        properties: {
+          category: {
+            type: "int32",
+          },
+          player: {
+            ref: "PlayerName",
+          },
+          question: {
+            type: "int32",
+          },
+        },
+      },
+      metadata: {
+        description:
+          "JeopardyAnsweredQuestions is the list of answered questions for a player.\nIn practice games, questions that were missed are also used up, and\ntheir player is empty.\n",
+      },
+    },
+    JeopardyBuzz: {
+      metadata: {
+        description:
+          "JeopardyBuzz is a single button press within a buzz window.\n",
+      },
+      properties: {
+        latency: {
+          metadata: {
+            description:
+              "latency is the estimated one-way latency of the player in\nmilliseconds. It was subtracted from the arrival time of the button\npress when ordering the buzzes.\n",
+          },
+          type: "float32",
+        },
+        playerName: {
+          ref: "PlayerName",
+        },
+      },
+    },
+    JeopardyCategory: {
+      metadata: {
+        description: "JeopardyCategory is a category in a Jeopardy game.\n",
+      },
+      properties: {
+        name: {
+          metadata: {
+            description: "name is the name of the category.\n",
+          },
+          type: "string",
+        },
+        questions: {
+          elements: {
+            ref: "JeopardyQuestion",
+          },
+          metadata: {
+            description: "questions are the questions in the category.\n",
+          },
+        },
+      },
+    },
+    JeopardyChooserPolicy: {
+      enum: ["random", "last_correct", "round_robin", "lowest_score"],
+      metadata: {
+        description:
+          "JeopardyChooserPolicy is a policy for picking the player who chooses the\nnext question. The starting chooser is picked using the same policy,\nwith ties going to whoever joined first.\n\n- random picks a random player every turn.\n- last_correct picks the last player who answered correctly. The\n  chooser stays the same if nobody did.\n- round_robin cycles through the players in the order that they\n  joined.\n- lowest_score picks the player with the lowest score.\n",
+      },
+    },
+    JeopardyCurrentQuestion: {
+      metadata: {
+        description:
+          "JeopardyCurrentQuestion is the question that is being played. Its fields\nare the same as in EventJeopardyBeginQuestion, and index is the index of\nthe question within its category.\n",
+      },
+      properties: {
+        category: {
+          type: "int32",
+        },
+        content: {
+          elements: {
+            ref: "QuestionContent",
+          },
+        },
+        index: {
+          type: "int32",
+        },
+        points: {
+          type: "float32",
+        },
+        question: {
+          type: "string",
+        },
+      },
+    },
+    JeopardyFairBuzzer: {
+      metadata: {
+        description:
+          "JeopardyFairBuzzer configures latency-compensated buzzer ordering. Once\nthe first button press arrives, the server keeps collecting presses for\nthe duration of the window, then orders them by their estimated send\ntime, which is the arrival time minus half of the player's round-trip\ntime.\n",
+      },
+      properties: {
+        window: {
+          metadata: {
+            description:
+              "window is the collection window after the first button press. The\nformat is in Go's time.Duration, e.g. 250ms.\n",
+          },
+          type: "string",
+        },
+      },
+    },
+    JeopardyGameData: {
+      metadata: {
+        description: "JeopardyGameData is the game data for a Jeopardy game.\n",
+      },
+      optionalProperties: {
+        chooser_policy: {
+          metadata: {
+            description:
+              "chooser_policy determines who chooses the next question once a\nturn ends. The default is last_correct.\n",
+          },
+          ref: "JeopardyChooserPolicy",
+        },
+        chooser_seed: {
+          metadata: {
+            description:
+              "chooser_seed seeds the random number generator of the random\nchooser policy. If omitted, a random seed is used.\n",
+          },
+          type: "uint32",
+        },
+        early_buzz_penalty: {
+          metadata: {
+            description:
+              "early_buzz_penalty is how long a player is locked out of their\nbuzzer if they press it before the admin arms the buzzers. The\nformat is in Go's time.Duration. The default is 250ms.\n",
+          },
+          type: "string",
+        },
+        fair_buzzer: {
+          metadata: {
+            description:
+              "fair_buzzer enables latency-compensated buzzer ordering. If\nomitted, the first button press to arrive wins.\n",
+          },
+          ref: "JeopardyFairBuzzer",
+        },
+        score_multiplier: {
+          metadata: {
+            description:
+              "score_multiplier is the score multiplier for each question. The\ndefault is 100.\n",
+          },
+          type: "float32",
+        },
+      },
+      properties: {
+        categories: {
+          elements: {
+            ref: "JeopardyCategory",
+          },
+        },
+      },
+    },
+    JeopardyGameInfo: {
+      metadata: {
+        description:
+          "JeopardyGameInfo is the initial information for a Jeopardy game. This type\ncontains no useful information about the entire game data, so it's used to\nsend to players the first time they join.\n",
+      },
+      properties: {
+        categories: {
+          elements: {
+            type: "string",
+          },
+        },
+        numQuestions: {
+          type: "int32",
+        },
+        scoreMultiplier: {
+          type: "float32",
+        },
+      },
+    },
+    JeopardyPhase: {
+      enum: ["choosing", "reading", "buzzing", "answering"],
+      metadata: {
+        description:
+          "JeopardyPhase is the phase of a Jeopardy turn.\n\n- choosing is when the chooser picks the next question.\n- reading is when an admin reads the question out loud. Players who\n  press their button now are locked out for a while.\n- buzzing is when players may press their button.\n- answering is when the player who pressed first answers.\n",
+      },
+    },
+    JeopardyQuestion: {
+      metadata: {
+        description: "JeopardyQuestion is a question in a Jeopardy game.\n",
+      },
+      optionalProperties: {
+        answers: {
+          elements: {
+            type: "string",
+          },
+          metadata: {
+            description:
+              'answers are the accepted answers to the question. They are used\nto judge practice games, where the host is a bot. In practice\ngames, players judge their own answers to questions without\nany. Answers are compared ignoring case, punctuation, articles\nand a leading "what is" or "who is". They are never sent to\nplayers.\n',
+          },
+        },
+        content: {
+          elements: {
+            ref: "QuestionContent",
+          },
+          metadata: {
+            description:
+              "content are extra blocks that are shown along with the question,\nsuch as code snippets, images or audio clips.\n",
+          },
+        },
+      },
+      properties: {
+        question: {
+          metadata: {
+            description: "question is the question.\n",
+          },
+          type: "string",
+        },
+      },
+    },
+    JeopardySnapshot: {
+      metadata: {
+        description:
+          "JeopardySnapshot is the state of a Jeopardy game. The scores are in the\nleaderboard of the EventGameSnapshot. question is the current question,\nwhich is null while the chooser is choosing. answering is the player\nwho is answering it, if any, and pressed are the players who have\nalready pressed their button for it.\n",
+      },
+      properties: {
+        answered: {
+          ref: "JeopardyAnsweredQuestions",
+        },
+        answering: {
+          ref: "PlayerName",
+        },
+        chooser: {
+          ref: "PlayerName",
+        },
+        phase: {
+          ref: "JeopardyPhase",
+        },
+        pressed: {
+          elements: {
+            ref: "PlayerName",
+          },
+        },
+        question: {
+          nullable: true,
+          ref: "JeopardyCurrentQuestion",
+        },
+      },
+    },
+    KahootGameData: {
+      metadata: {
+        description: "KahootGameData is the game data for a Kahoot game.\n",
+      },
+      properties: {
+        questions: {
+          elements: {
+            ref: "KahootQuestion",
+          },
+          metadata: {
+            description: "questions are the questions in the game.\n",
+          },
+        },
+        time_limit: {
+          metadata: {
+            description:
+              "time_limit is the time limit for each question. The format is in\nGo's time.Duration, e.g. 10s for 10 seconds.\n",
+          },
+          type: "string",
+        },
+      },
+    },
+    KahootQuestion: {
+      metadata: {
+        description: "KahootQuestion is a question in a Kahoot game.\n",
+      },
+      properties: {
+        answers: {
+          elements: {
+            type: "string",
+          },
+          metadata: {
+            description: "answers are the possible answers.\n",
+          },
+        },
+        question: {
+          metadata: {
+            description: "question is the question.\n",
+          },
+          type: "string",
+        },
+      },
+    },
+    Leaderboard: {
+      elements: {
+        ref: "LeaderboardEntry",
+      },
+      metadata: {
+        description: "Leaderboard is a list of players and their scores.\n",
+      },
+    },
+    LeaderboardEntry: {
+      properties: {
+        playerName: {
+          type: "string",
+        },
+        score: {
+          type: "float32",
+        },
+      },
+    },
+    Media: {
+      metadata: {
+        description:
+          "Media describes an uploaded media blob. Its content is served at\n/media/{id}.\n",
+      },
+      properties: {
+        contentType: {
+          type: "string",
+        },
+        id: {
+          ref: "MediaID",
+        },
+        size: {
+          type: "uint32",
+        },
+      },
+    },
+    MediaID: {
+      metadata: {
+        description:
+          "MediaID is the ID of an uploaded media blob. It is the hex-encoded\nSHA-256 hash of the blob, so uploading the same blob twice gives the\nsame ID.\n",
+      },
+      type: "string",
+    },
+    PlayerName: {
+      metadata: {
+        description: "PlayerName is the name of a player.\n",
+      },
+      type: "string",
+    },
+    PollGameData: {
+      metadata: {
+        description:
+          "PollGameData is the game data for a poll. A poll is not scored: players\nvote on each question and everyone sees the results live.\n",
+      },
+      properties: {
+        questions: {
+          elements: {
+            ref: "PollQuestion",
+          },
+        },
+      },
+    },
+    PollGameInfo: {
+      properties: {
+        numQuestions: {
+          type: "int32",
+        },
+      },
+    },
+    PollQuestion: {
+      optionalProperties: {
+        multiple: {
+          metadata: {
+            description:
+              "multiple allows players to vote for more than one choice. The\ndefault is false.\n",
+          },
+          type: "boolean",
+        },
+      },
+      properties: {
+        choices: {
+          elements: {
+            type: "string",
+          },
+        },
+        question: {
+          type: "string",
+        },
+      },
+    },
+    PollResult: {
+      metadata: {
+        description:
+          "PollResult is the result of a poll question. votes holds the number of\nvotes for each choice, and voters is the number of players who voted.\n",
+      },
+      properties: {
+        choices: {
+          elements: {
+            type: "string",
+          },
+        },
+        question: {
+          type: "string",
+        },
+        voters: {
+          type: "int32",
+        },
+        votes: {
+          elements: {
+            type: "int32",
+          },
+        },
+      },
+    },
+    QuestionContent: {
+      discriminator: "type",
+      mapping: {
+        audio: {
+          metadata: {
+            description: "QuestionContentAudio is an uploaded audio clip.\n",
+          },
           properties: {
-            data: {
-              ref: "JeopardyGameData",
+            media: {
+              ref: "MediaID",
             },
           },
         },
-        kahoot: {
+        code: {
+          metadata: {
+            description: "QuestionContentCode is a code snippet.\n",
+          },
+          optionalProperties: {
+            language: {
+              metadata: {
+                description:
+                  "language is a hint for syntax highlighting, such as go or\npython.\n",
+              },
+              type: "string",
+            },
+          },
           properties: {
-            data: {
-              ref: "KahootGameData",
+            code: {
+              type: "string",
+            },
+          },
+        },
+        image: {
+          metadata: {
+            description: "QuestionContentImage is an uploaded image.\n",
+          },
+          optionalProperties: {
+            alt: {
+              metadata: {
+                description:
+                  "alt describes the image for players who cannot see it. It\nshould not give the answer away.\n",
+              },
+              type: "string",
+            },
+          },
+          properties: {
+            media: {
+              ref: "MediaID",
+            },
+          },
+        },
+        text: {
+          metadata: {
+            description: "QuestionContentText is a paragraph of plain text.\n",
+          },
+          properties: {
+            text: {
+              type: "string",
             },
           },
         },
       },
       metadata: {
         description:
-          "GameData is the game data. It contains all the information about the game.\n",
+          "QuestionContent is a block of content that is shown along with a\nquestion.\n",
       },
     },
-    GameID: {
+    QuizAnswer: {
+      discriminator: "type",
+      mapping: {
+        choice: {
+          metadata: {
+            description:
+              "QuizAnswerChoice answers a multiple-choice or true or false\nquestion. choice is the index of the chosen choice.\n",
+          },
+          properties: {
+            choice: {
+              type: "int32",
+            },
+          },
+        },
+        number: {
+          metadata: {
+            description: "QuizAnswerNumber answers an estimation question.\n",
+          },
+          properties: {
+            number: {
+              type: "float64",
+            },
+          },
+        },
+        order: {
+          metadata: {
+            description:
+              "QuizAnswerOrder answers an ordering question. order lists the\nindices of the choices of the question from first to last, and it\nmust contain every index exactly once.\n",
+          },
+          properties: {
+            order: {
+              elements: {
+                type: "int32",
+              },
+            },
+          },
+        },
+      },
       metadata: {
         description:
-          "GameID is the unique identifier for a game. Each player must type this\ncode to join the game.\n",
+          "QuizAnswer is a player's answer to a quiz question. Its type must match\nthe type of the question.\n",
       },
-      type: "string",
     },
-    GameInfo: {
+    QuizEstimateGuess: {
+      properties: {
+        distance: {
+          type: "float64",
+        },
+        guess: {
+          type: "float64",
+        },
+        playerName: {
+          ref: "PlayerName",
+        },
+        points: {
+          type: "float32",
+        },
+      },
+    },
+    QuizEstimateScoring: {
+      enum: ["rank", "linear"],
+      metadata: {
+        description:
+          "QuizEstimateScoring is how guesses to an estimation question are scored.\nThe default is rank.\n\n- rank ranks the guesses by their distance to the answer. The closest\n  guesses get the full points, and each rank after that gets a smaller\n  share of them, down to 1/n of the points for the farthest of n ranks.\n- linear gives the full points to an exact guess, and the points drop\n  linearly with the distance until they reach 0 at the tolerance.\n",
+      },
+    },
+    QuizGameData: {
+      metadata: {
+        description:
+          "QuizGameData is the game data for a quiz. Everyone answers every\nquestion within the time limit, and correct answers are scored\nautomatically.\n",
+      },
+      optionalProperties: {
+        points: {
+          metadata: {
+            description:
+              "points is the number of points for each correct answer. The\ndefault is 100.\n",
+          },
+          type: "float32",
+        },
+        survival: {
+          metadata: {
+            description:
+              "survival turns the quiz into a survival game. See QuizSurvival.\n",
+          },
+          ref: "QuizSurvival",
+        },
+        time_limit: {
+          metadata: {
+            description:
+              "time_limit is the time limit for each question. The format is in\nGo's time.Duration, e.g. 10s for 10 seconds. The default is 20s.\n",
+          },
+          type: "string",
+        },
+      },
+      properties: {
+        questions: {
+          elements: {
+            ref: "QuizQuestion",
+          },
+        },
+      },
+    },
+    QuizGameInfo: {
+      properties: {
+        numQuestions: {
+          type: "int32",
+        },
+        survival: {
+          type: "boolean",
+        },
+      },
+    },
+    QuizOrderingGuess: {
+      metadata: {
+        description:
+          "QuizOrderingGuess is a player's answer to an ordering question. distance\nis the Kendall tau distance to the right order, which is the number of\npairs of items that the player put in the wrong order.\n",
+      },
+      properties: {
+        distance: {
+          type: "int32",
+        },
+        order: {
+          elements: {
+            type: "int32",
+          },
+        },
+        playerName: {
+          ref: "PlayerName",
+        },
+        points: {
+          type: "float32",
+        },
+      },
+    },
+    QuizQuestion: {
       discriminator: "type",
       mapping: {
-        jeopardy: {
+        estimate: {
+          metadata: {
+            description:
+              "QuizQuestionEstimate is a question with a numeric answer that\nplayers have to estimate. The closer a guess is to answer, the more\npoints it gets.\n",
+          },
+          optionalProperties: {
+            scoring: {
+              ref: "QuizEstimateScoring",
+            },
+            tolerance: {
+              metadata: {
+                description:
+                  "tolerance is the distance from the answer at which a guess\nstops getting any points when scoring is linear. The default\nis the answer itself, so a guess of 0 or twice the answer gets\nno points.\n",
+              },
+              type: "float64",
+            },
+          },
           properties: {
-            data: {
-              ref: "JeopardyGameInfo",
+            answer: {
+              type: "float64",
+            },
+            question: {
+              type: "string",
+            },
+          },
+        },
+        multiple_choice: {
+          metadata: {
+            description:
+              "QuizQuestionMultipleChoice is a multiple-choice question. answer\nis the index of the correct choice.\n",
+          },
+          properties: {
+            answer: {
+              type: "int32",
+            },
+            choices: {
+              elements: {
+                type: "string",
+              },
+            },
+            question: {
+              type: "string",
+            },
+          },
+        },
+        ordering: {
+          metadata: {
+            description:
+              "QuizQuestionOrdering is a question where players put items into\nthe right order, such as sorting languages by their release year.\nitems are listed in the right order and are shown to players in a\nrandom order. Answers that are partly in order get partial credit.\n",
+          },
+          properties: {
+            items: {
+              elements: {
+                type: "string",
+              },
+            },
+            question: {
+              type: "string",
+            },
+          },
+        },
+        true_false: {
+          metadata: {
+            description:
+              "QuizQuestionTrueFalse is a true or false question. Players answer\nwith choice 0 for true and choice 1 for false.\n",
+          },
+          properties: {
+            answer: {
+              type: "boolean",
+            },
+            question: {
+              type: "string",
             },
           },
         },
       },
+      metadata: {
+        description: "QuizQuestion is a question in a quiz.\n",
+      },
     },
-    GameType: {
-      enum: ["jeopardy", "kahoot"],
+    QuizQuestionType: {
+      enum: ["multiple_choice", "true_false", "estimate", "ordering"],
     },
-    JeopardyAnsweredQuestions: {
-      elements: {
-        properties: {
-          category: {
-            type: "int32",
+    QuizResult: {
+      discriminator: "type",
+      mapping: {
+        choice: {
+          metadata: {
+            description:
+              "QuizResultChoice is the result of a multiple-choice or true or\nfalse question. distribution holds the number of players who picked\neach choice.\n",
           },
-          player: {
-            ref: "PlayerName",
+          properties: {
+            answer: {
+              type: "int32",
+            },
+            distribution: {
+              elements: {
+                type: "int32",
+              },
+            },
           },
-          question: {
-            type: "int32",
+        },
+        estimate: {
+          metadata: {
+            description:
+              "QuizResultEstimate is the result of an estimation question. guesses\nare sorted from the closest to the farthest from answer.\n",
+          },
+          properties: {
+            answer: {
+              type: "float64",
+            },
+            guesses: {
+              elements: {
+                ref: "QuizEstimateGuess",
+              },
+            },
+          },
+        },
+        ordering: {
+          metadata: {
+            description:
+              "QuizResultOrdering is the result of an ordering question. answer\nlists the indices of the choices in the right order, and orders\nare sorted from the closest to the farthest from it.\n",
+          },
+          properties: {
+            answer: {
+              elements: {
+                type: "int32",
+              },
+            },
+            orders: {
+              elements: {
+                ref: "QuizOrderingGuess",
+              },
+            },
           },
         },
       },
       metadata: {
         description:
-          "JeopardyAnsweredQuestions is the list of answered questions for a player.\n",
+          "QuizResult is the result of a quiz question once it is revealed. Its\ntype depends on the type of the question.\n",
       },
     },
-    JeopardyCategory: {
+    QuizSurvival: {
       metadata: {
-        description: "JeopardyCategory is a category in a Jeopardy game.\n",
+        description:
+          "QuizSurvival configures a survival quiz. A wrong answer or no answer\neliminates the player, who stays connected as a spectator. The game ends\nonce one player remains or the questions run out. Players are ranked by\nthe number of questions that they survived.\n",
       },
-      properties: {
-        name: {
-          metadata: {
-            description: "name is the name of the category.\n",
-          },
-          type: "string",
-        },
-        questions: {
+      optionalProperties: {
+        sudden_death: {
           elements: {
-            ref: "JeopardyQuestion",
+            ref: "QuizQuestion",
           },
           metadata: {
-            description: "questions are the questions in the category.\n",
+            description:
+              "sudden_death are the questions that are played one at a time\nwhen the tie_breaker is sudden_death.\n",
           },
         },
+        tie_breaker: {
+          ref: "QuizTieBreaker",
+        },
       },
+      properties: {},
     },
-    JeopardyGameData: {
+    QuizTieBreaker: {
+      enum: ["share", "revive", "sudden_death"],
       metadata: {
-        description: "JeopardyGameData is the game data for a Jeopardy game.\n",
+        description:
+          "QuizTieBreaker is how a survival quiz handles players who are tied. The\ndefault is share.\n\n- share eliminates everyone who fails a question, even if nobody would\n  be left. Players who are left at the end share the win.\n- revive lets everyone survive a question that all remaining players\n  failed. Players who are left at the end share the win.\n- sudden_death works like revive, but players who are left once the\n  questions run out play the sudden_death questions until only one of\n  them survives. If those run out too, the players share the win.\n",
       },
-      optionalProperties: {
-        score_multiplier: {
-          metadata: {
-            description:
-              "score_multiplier is the score multiplier for each question. The\ndefault is 100.\n",
-          },
-          type: "float32",
+    },
+    RequestDebugGame: {
+      properties: {
+        admin_password: {
+          type: "string",
+        },
+        gameID: {
+          ref: "GameID",
         },
       },
+    },
+    RequestGetGame: {
       properties: {
-        categories: {
-          elements: {
-            ref: "JeopardyCategory",
-          },
+        gameID: {
+          type: "string",
         },
       },
     },
-    JeopardyGameInfo: {
+    RequestGetJeopardyGame: {
+      properties: {
+        gameID: {
+          type: "string",
+        },
+      },
+    },
+    RequestGetPollResults: {
       metadata: {
         description:
-          "JeopardyGameInfo is the initial information for a Jeopardy game. This type\ncontains no useful information about the entire game data, so it's used to\nsend to players the first time they join.\n",
+          "RequestGetPollResults asks for the results of a poll. Voters may not\nwant their answers to be seen by everyone who knows the game ID, so\nonly admins may export them.\n",
       },
       properties: {
-        categories: {
-          elements: {
-            type: "string",
-          },
+        admin_password: {
+          type: "string",
         },
-        numQuestions: {
-          type: "int32",
+        gameID: {
+          ref: "GameID",
         },
-        scoreMultiplier: {
-          type: "float32",
+      },
+    },
+    RequestGetTournament: {
+      properties: {
+        tournamentID: {
+          ref: "TournamentID",
         },
       },
     },
-    JeopardyQuestion: {
-      metadata: {
-        description: "JeopardyQuestion is a question in a Jeopardy game.\n",
+    RequestNewGame: {
+      optionalProperties: {
+        schedule: {
+          metadata: {
+            description:
+              "schedule schedules the game ahead of time. The game ID is returned\nright away, but players cannot join until the lobby opens. If\nomitted, the lobby opens immediately.\n",
+          },
+          ref: "GameSchedule",
+        },
       },
       properties: {
-        question: {
+        admin_password: {
+          type: "string",
+        },
+        data: {
+          ref: "GameData",
+        },
+      },
+    },
+    RequestNewTournament: {
+      properties: {
+        admin_password: {
           metadata: {
-            description: "question is the question.\n",
+            description:
+              "admin_password is the admin password of every match game, so that\nthe host can run the matches.\n",
           },
           type: "string",
         },
+        data: {
+          ref: "TournamentData",
+        },
       },
     },
-    KahootGameData: {
+    RequestPreviewGameMedia: {
+      properties: {
+        admin_password: {
+          type: "string",
+        },
+        gameID: {
+          ref: "GameID",
+        },
+      },
+    },
+    ResponseDebugGame: {
       metadata: {
-        description: "KahootGameData is the game data for a Kahoot game.\n",
+        description:
+          "ResponseDebugGame is a snapshot of the state machine of a running game,\nfor admins to debug it. Inputs are named by their Go types. The graphs\nonly contain the transitions that the game has offered so far.\n",
       },
       properties: {
-        questions: {
-          elements: {
-            ref: "KahootQuestion",
+        current: {
+          metadata: {
+            description:
+              "current is the input of the current state, or init before the game\nhas begun taking inputs.\n",
           },
+          type: "string",
+        },
+        dot: {
           metadata: {
-            description: "questions are the questions in the game.\n",
+            description:
+              "dot is the graph of the state machine in the Graphviz DOT language.\n",
           },
+          type: "string",
         },
-        time_limit: {
+        ended: {
+          type: "boolean",
+        },
+        mermaid: {
           metadata: {
             description:
-              "time_limit is the time limit for each question. The format is in\nGo's time.Duration, e.g. 10s for 10 seconds.\n",
+              "mermaid is the graph of the state machine as a Mermaid state\ndiagram.\n",
           },
           type: "string",
         },
-      },
-    },
-    KahootQuestion: {
-      metadata: {
-        description: "KahootQuestion is a question in a Kahoot game.\n",
-      },
-      properties: {
-        answers: {
+        next: {
           elements: {
             type: "string",
           },
           metadata: {
-            description: "answers are the possible answers.\n",
+            description:
+              "next lists the inputs that the game accepts next, besides those of\nthe superstate.\n",
           },
         },
-        question: {
+        reactors: {
+          elements: {
+            ref: "DebugReactor",
+          },
+        },
+        superstate: {
+          elements: {
+            type: "string",
+          },
           metadata: {
-            description: "question is the question.\n",
+            description:
+              "superstate lists the inputs that the game accepts in any state.\n",
           },
-          type: "string",
         },
       },
     },
-    Leaderboard: {
-      elements: {
-        ref: "LeaderboardEntry",
+    ResponseGetGame: {
+      optionalProperties: {
+        schedule: {
+          metadata: {
+            description:
+              "schedule is the schedule of the game if it was scheduled ahead of\ntime.\n",
+          },
+          ref: "GameSchedule",
+        },
       },
-      metadata: {
-        description: "Leaderboard is a list of players and their scores.\n",
+      properties: {
+        gameType: {
+          ref: "GameType",
+        },
       },
     },
-    LeaderboardEntry: {
+    ResponseGetJeopardyGame: {
       properties: {
-        playerName: {
-          type: "string",
-        },
-        score: {
-          type: "float32",
+        info: {
+          ref: "JeopardyGameInfo",
         },
       },
     },
-    PlayerName: {
+    ResponseGetPollResults: {
       metadata: {
-        description: "PlayerName is the name of a player.\n",
+        description:
+          "ResponseGetPollResults contains the results of every question of a poll\nthat has been closed so far.\n",
+      },
+      properties: {
+        results: {
+          elements: {
+            ref: "PollResult",
+          },
+        },
       },
-      type: "string",
     },
-    RequestGetGame: {
+    ResponseGetTournament: {
       properties: {
-        gameID: {
-          type: "string",
+        bracket: {
+          ref: "TournamentBracket",
         },
       },
     },
-    RequestGetJeopardyGame: {
+    ResponseNewGame: {
       properties: {
         gameID: {
           type: "string",
         },
+        gameType: {
+          ref: "GameType",
+        },
       },
     },
-    RequestNewGame: {
+    ResponseNewTournament: {
       properties: {
-        admin_password: {
-          type: "string",
+        bracket: {
+          ref: "TournamentBracket",
         },
-        data: {
-          ref: "GameData",
+      },
+    },
+    ResponsePreviewGameMedia: {
+      metadata: {
+        description:
+          "ResponsePreviewGameMedia lists every media blob that the questions of a\ngame use, so that admins can check them before players see them.\n",
+      },
+      properties: {
+        media: {
+          elements: {
+            ref: "Media",
+          },
         },
       },
     },
-    ResponseGetGame: {
+    ResponseUploadMedia: {
+      metadata: {
+        description:
+          "ResponseUploadMedia is returned after uploading a media blob. The blob\nis uploaded as the raw request body, and its Content-Type header must\nbe an image or audio type.\n",
+      },
       properties: {
-        gameType: {
-          ref: "GameType",
+        media: {
+          ref: "Media",
         },
       },
     },
-    ResponseGetJeopardyGame: {
+    TournamentBracket: {
+      metadata: {
+        description:
+          "TournamentBracket is the current state of a tournament. rounds holds\nthe matches of each round, from the first round to the final. winner\nis set once the final has ended.\n",
+      },
+      optionalProperties: {
+        winner: {
+          ref: "PlayerName",
+        },
+      },
       properties: {
-        info: {
-          ref: "JeopardyGameInfo",
+        name: {
+          type: "string",
+        },
+        rounds: {
+          elements: {
+            elements: {
+              ref: "TournamentMatch",
+            },
+          },
+        },
+        tournamentID: {
+          ref: "TournamentID",
         },
       },
     },
-    ResponseNewGame: {
+    TournamentData: {
+      metadata: {
+        description:
+          "TournamentData describes a single-elimination tournament. Each match\nis its own game, and the winner of a match advances to the next round.\nThe entrant with the higher score wins a match, and ties go to the\nhigher seed. An entrant who never joins their match loses it, unless\nneither entrant joins, in which case the higher seed wins.\n",
+      },
       properties: {
-        gameID: {
+        entrants: {
+          elements: {
+            ref: "PlayerName",
+          },
+          metadata: {
+            description:
+              "entrants are the players or teams in the tournament, from the top\nseed to the bottom one. Each entrant joins their matches using\ntheir name as the player name. Top seeds get a bye if the number of\nentrants is not a power of two.\n",
+          },
+        },
+        games: {
+          elements: {
+            ref: "GameData",
+          },
+          metadata: {
+            description:
+              "games are the question sets that the matches are played with. The\nmatches of round i use games[i % len(games)].\n",
+          },
+        },
+        name: {
           type: "string",
         },
-        gameType: {
-          ref: "GameType",
+      },
+    },
+    TournamentID: {
+      metadata: {
+        description:
+          "TournamentID is the unique identifier for a tournament.\n",
+      },
+      type: "string",
+    },
+    TournamentMatch: {
+      metadata: {
+        description:
+          "TournamentMatch is a match between two entrants. entrants only holds\nthe entrants that are known so far. A match with a single entrant in\nthe first round is a bye.\n",
+      },
+      optionalProperties: {
+        gameID: {
+          ref: "GameID",
+        },
+        leaderboard: {
+          ref: "Leaderboard",
+        },
+        winner: {
+          ref: "PlayerName",
+        },
+      },
+      properties: {
+        entrants: {
+          elements: {
+            ref: "PlayerName",
+          },
+        },
+        state: {
+          ref: "TournamentMatchState",
         },
       },
     },
+    TournamentMatchState: {
+      enum: ["pending", "playing", "done"],
+      metadata: {
+        description:
+          "TournamentMatchState is the state of a tournament match.\n\n- pending is when the match is waiting for its entrants.\n- playing is when the match game has been created. Its entrants can\n  join it using gameID.\n- done is when the match has a winner.\n",
+      },
+    },
   },
 } as jtd.Schema;
@@ -4,7 +4,6 @@ go 1.20
 
 require (
 	github.com/alecthomas/assert/v2 v2.2.1
-	github.com/davecgh/go-spew v1.1.0
 	github.com/diamondburned/listener v0.0.0-20220315064222-63f8ebce5f60
 	github.com/go-chi/chi/v5 v5.0.8
 	github.com/gorilla/websocket v1.5.0